@@ -1,14 +1,18 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"io"
+	"io/ioutil"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,12 +22,23 @@ import (
 	"github.com/pivotal/kpack/pkg/blob"
 	"github.com/pivotal/kpack/pkg/buildchange"
 	"github.com/pivotal/kpack/pkg/cnb"
+	"github.com/pivotal/kpack/pkg/customsource"
 	"github.com/pivotal/kpack/pkg/dockercreds"
 	"github.com/pivotal/kpack/pkg/flaghelpers"
 	"github.com/pivotal/kpack/pkg/git"
 	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/s3"
 )
 
+func getEnvInt(key string, defaultValue int) int {
+	s := os.Getenv(key)
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
 var (
 	platformEnvVars = flag.String("platformEnvVars", os.Getenv("PLATFORM_ENV_VARS"), "a JSON string of build time environment variables formatted as key/value pairs")
 	imageTag        = flag.String("imageTag", os.Getenv("IMAGE_TAG"), "tag of image that will get created by the lifecycle")
@@ -31,8 +46,21 @@ var (
 
 	gitURL         = flag.String("git-url", os.Getenv("GIT_URL"), "The url of the Git repository to initialize.")
 	gitRevision    = flag.String("git-revision", os.Getenv("GIT_REVISION"), "The Git revision to make the repository HEAD.")
+	gitSubmodules  = flag.Bool("git-submodules", os.Getenv("GIT_SUBMODULES") == "true", "Recursively initialize and check out the Git submodules.")
+	gitLFS         = flag.Bool("git-lfs", os.Getenv("GIT_LFS") == "true", "Download the Git LFS objects of the checked out revision.")
+	gitDepth       = flag.Int("git-depth", getEnvInt("GIT_DEPTH", 0), "The number of commits of the Git history to fetch, the complete history is fetched if not set.")
 	blobURL        = flag.String("blob-url", os.Getenv("BLOB_URL"), "The url of the source code blob.")
+	blobSha256     = flag.String("blob-sha256", os.Getenv("BLOB_SHA256"), "The expected sha256 digest of the source code blob.")
 	registryImage  = flag.String("registry-image", os.Getenv("REGISTRY_IMAGE"), "The registry location of the source code image.")
+	s3Bucket       = flag.String("s3-bucket", os.Getenv("S3_BUCKET"), "The bucket of the source code archive.")
+	s3Key          = flag.String("s3-key", os.Getenv("S3_KEY"), "The key of the source code archive.")
+	s3Endpoint     = flag.String("s3-endpoint", os.Getenv("S3_ENDPOINT"), "The endpoint of the S3 compatible object store.")
+	s3Region       = flag.String("s3-region", os.Getenv("S3_REGION"), "The region of the bucket.")
+	s3Version      = flag.String("s3-version", os.Getenv("S3_VERSION"), "The resolved version of the source code archive.")
+	customKind     = flag.String("custom-source-kind", os.Getenv("CUSTOM_SOURCE_KIND"), "The kind of the custom source.")
+	customConfig   = flag.String("custom-source-config", os.Getenv("CUSTOM_SOURCE_CONFIG"), "A JSON string of the custom source config.")
+	customEndpoint = flag.String("custom-source-endpoint", os.Getenv("CUSTOM_SOURCE_ENDPOINT"), "The url of the source resolver of the custom source.")
+	customVersion  = flag.String("custom-source-version", os.Getenv("CUSTOM_SOURCE_VERSION"), "The resolved version of the custom source.")
 	hostName       = flag.String("dns-probe-hostname", os.Getenv("DNS_PROBE_HOSTNAME"), "hostname to dns poll")
 	sourceSubPath  = flag.String("source-sub-path", os.Getenv("SOURCE_SUB_PATH"), "the subpath inside the source directory that will be the buildpack workspace")
 	buildChanges   = flag.String("build-changes", os.Getenv("BUILD_CHANGES"), "JSON string of build changes and their reason")
@@ -61,6 +89,8 @@ const (
 	platformDir                  = "/platform"
 	buildSecretsDir              = "/var/build-secrets"
 	registrySourcePullSecretsDir = "/registrySourcePullSecrets"
+	blobAuthDir                  = "/blobAuth"
+	customSourceTokenPath        = "/customSourceToken/token"
 	projectMetadataDir           = "/projectMetadata"
 	networkWaitLauncherDir       = "/networkWait"
 	networkWaitLauncherBinary    = "network-wait-launcher.exe"
@@ -129,7 +159,10 @@ func main() {
 	}
 
 	err = fetchSource(logger, creds)
-	if err != nil {
+	if digestMismatchErr := (blob.DigestMismatchError{}); errors.As(err, &digestMismatchErr) {
+		logger.Println(err)
+		os.Exit(blob.DigestMismatchExitCode)
+	} else if err != nil {
 		logger.Fatal(err)
 	}
 
@@ -180,13 +213,23 @@ func fetchSource(logger *log.Logger, serviceAccountCreds dockercreds.DockerCreds
 		}
 
 		fetcher := git.Fetcher{
-			Logger:   logger,
-			Keychain: gitKeychain,
+			Logger:     logger,
+			Keychain:   gitKeychain,
+			Submodules: *gitSubmodules,
+			LFS:        *gitLFS,
+			Depth:      *gitDepth,
 		}
 		return fetcher.Fetch(appDir, *gitURL, *gitRevision, projectMetadataDir)
 	case *blobURL != "":
+		headers, err := blob.ReadAuthSecret(blobAuthDir)
+		if err != nil {
+			return err
+		}
+
 		fetcher := blob.Fetcher{
-			Logger: logger,
+			Logger:  logger,
+			Sha256:  *blobSha256,
+			Headers: headers,
 		}
 		return fetcher.Fetch(appDir, *blobURL)
 	case *registryImage != "":
@@ -201,8 +244,39 @@ func fetchSource(logger *log.Logger, serviceAccountCreds dockercreds.DockerCreds
 			Keychain: authn.NewMultiKeychain(registrySourcePullSecrets, serviceAccountCreds),
 		}
 		return fetcher.Fetch(appDir, *registryImage)
+	case *s3Bucket != "":
+		// the source pull secrets volume contains the s3 credentials secret for s3 sources
+		credentials, err := s3.ReadCredentialsSecret(registrySourcePullSecretsDir)
+		if err != nil {
+			return err
+		}
+
+		fetcher := s3.Fetcher{
+			Logger:      logger,
+			Credentials: credentials,
+			Endpoint:    *s3Endpoint,
+			Region:      *s3Region,
+		}
+		return fetcher.Fetch(appDir, *s3Bucket, *s3Key, *s3Version)
+	case *customKind != "":
+		var config map[string]string
+		if err := json.Unmarshal([]byte(*customConfig), &config); err != nil {
+			return errors.Wrap(err, "parsing custom source config")
+		}
+
+		token, err := ioutil.ReadFile(customSourceTokenPath)
+		if err != nil {
+			return errors.Wrap(err, "reading custom source token")
+		}
+
+		fetcher := customsource.Fetcher{
+			Logger: logger,
+			Client: &http.Client{Timeout: 30 * time.Minute},
+			Token:  string(token),
+		}
+		return fetcher.Fetch(appDir, *customEndpoint, *customKind, config, *customVersion)
 	default:
-		return errors.New("no git url, blob url, or registry image provided")
+		return errors.New("no git url, blob url, registry image, s3 bucket, or custom source provided")
 	}
 }
 
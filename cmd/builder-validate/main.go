@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"github.com/pivotal/kpack/cmd"
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	"github.com/pivotal/kpack/pkg/cnb"
+	"github.com/pivotal/kpack/pkg/config"
+	"github.com/pivotal/kpack/pkg/cosign"
+	"github.com/pivotal/kpack/pkg/dockercreds/k8sdockercreds"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+var (
+	kubeconfig      = flag.String("kubeconfig", "", "Path to a kubeconfig.")
+	masterURL       = flag.String("master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig.")
+	file            = flag.String("f", "", "Path to the Builder or ClusterBuilder yaml to validate")
+	namespace       = flag.String("namespace", "default", "The namespace of the Builder. Ignored for ClusterBuilders")
+	systemNamespace = flag.String("system-namespace", "kpack", "The namespace kpack is installed in")
+)
+
+func main() {
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("a builder file must be provided with -f")
+	}
+
+	clusterConfig, err := cmd.BuildConfigFromFlags(*masterURL, *kubeconfig)
+	if err != nil {
+		log.Fatalf("Error building kubeconfig: %v", err)
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(clusterConfig)
+	if err != nil {
+		log.Fatalf("could not get kubernetes client: %s", err.Error())
+	}
+
+	client, err := versioned.NewForConfig(clusterConfig)
+	if err != nil {
+		log.Fatalf("could not get Build client: %s", err)
+	}
+
+	ctx := context.Background()
+
+	keychainFactory, err := k8sdockercreds.NewSecretKeychainFactory(k8sClient)
+	if err != nil {
+		log.Fatalf("could not create k8s keychain factory: %s", err)
+	}
+
+	kpackKeychain, err := keychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{})
+	if err != nil {
+		log.Fatalf("could not create empty keychain %s", err)
+	}
+
+	lifecycleConfig, err := k8sClient.CoreV1().ConfigMaps(*systemNamespace).Get(ctx, config.LifecycleConfigName, metav1.GetOptions{})
+	if err != nil {
+		log.Fatalf("could not get lifecycle config: %s", err)
+	}
+
+	imageVerifier := cosign.NewImageVerifier()
+	lifecycleProvider := config.NewLifecycleProvider(&registry.Client{}, keychainFactory, imageVerifier)
+	lifecycleProvider.UpdateImage(lifecycleConfig)
+
+	builderCreator := &cnb.RemoteBuilderCreator{
+		RegistryClient:    &registry.Client{},
+		KpackVersion:      cmd.Identifer,
+		LifecycleProvider: lifecycleProvider,
+		ImageVerifier:     imageVerifier,
+		NewBuildpackRepository: func(clusterStore *buildapi.ClusterStore) cnb.BuildpackRepository {
+			return &cnb.StoreBuildpackRepository{
+				Keychain:     authn.NewMultiKeychain(authn.DefaultKeychain, kpackKeychain),
+				ClusterStore: clusterStore,
+			}
+		},
+	}
+
+	contents, err := ioutil.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("could not read builder file: %s", err)
+	}
+
+	problems, err := validate(ctx, contents, *namespace, client, keychainFactory, builderCreator)
+	if err != nil {
+		log.Fatalf("error validating builder: %s", err)
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("builder is valid")
+		return
+	}
+
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	os.Exit(1)
+}
+
+type BuilderValidator interface {
+	ValidateBuilder(keychain authn.Keychain, clusterStore *buildapi.ClusterStore, clusterStack *buildapi.ClusterStack, spec buildapi.BuilderSpec) []error
+}
+
+// validate validates the Builder or ClusterBuilder in contents against the
+// stores and stacks in the cluster. A Builder without a namespace is validated
+// in namespace.
+func validate(ctx context.Context, contents []byte, namespace string, client versioned.Interface, keychainFactory registry.KeychainFactory, builderValidator BuilderValidator) ([]error, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(contents, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	var (
+		spec      buildapi.BuilderSpec
+		secretRef registry.SecretRef
+	)
+	switch typeMeta.Kind {
+	case buildapi.BuilderKind:
+		builder := &buildapi.Builder{}
+		if err := yaml.Unmarshal(contents, builder); err != nil {
+			return nil, err
+		}
+
+		builderNamespace := namespace
+		if builder.Namespace != "" {
+			builderNamespace = builder.Namespace
+		}
+
+		spec = builder.Spec.BuilderSpec
+		secretRef = registry.SecretRef{
+			ServiceAccount: builder.Spec.ServiceAccount(),
+			Namespace:      builderNamespace,
+		}
+	case buildapi.ClusterBuilderKind:
+		builder := &buildapi.ClusterBuilder{}
+		if err := yaml.Unmarshal(contents, builder); err != nil {
+			return nil, err
+		}
+
+		spec = builder.Spec.BuilderSpec
+		secretRef = registry.SecretRef{
+			ServiceAccount: builder.Spec.ServiceAccountRef.Name,
+			Namespace:      builder.Spec.ServiceAccountRef.Namespace,
+		}
+	default:
+		return nil, errors.Errorf("unsupported kind %q, expected %s or %s", typeMeta.Kind, buildapi.BuilderKind, buildapi.ClusterBuilderKind)
+	}
+
+	clusterStore, err := getStore(ctx, client, spec.Store, secretRef.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterStack, err := getStack(ctx, client, spec.Stack, secretRef.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	builderKeychain, err := keychainFactory.KeychainForSecretRef(ctx, secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return builderValidator.ValidateBuilder(authn.NewMultiKeychain(authn.DefaultKeychain, builderKeychain), clusterStore, clusterStack, spec), nil
+}
+
+func getStore(ctx context.Context, client versioned.Interface, ref corev1.ObjectReference, namespace string) (*buildapi.ClusterStore, error) {
+	if ref.Kind != buildapi.StoreKind {
+		return client.KpackV1alpha2().ClusterStores().Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+
+	store, err := client.KpackV1alpha2().Stores(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &buildapi.ClusterStore{ObjectMeta: store.ObjectMeta, Spec: buildapi.ClusterStoreSpec{Verification: store.Spec.Verification}, Status: store.Status}, nil
+}
+
+func getStack(ctx context.Context, client versioned.Interface, ref corev1.ObjectReference, namespace string) (*buildapi.ClusterStack, error) {
+	if ref.Kind != buildapi.StackKind {
+		return client.KpackV1alpha2().ClusterStacks().Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+
+	stack, err := client.KpackV1alpha2().Stacks(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &buildapi.ClusterStack{ObjectMeta: stack.ObjectMeta, Status: stack.Status}, nil
+}
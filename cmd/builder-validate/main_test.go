@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestValidate(t *testing.T) {
+	spec.Run(t, "Validate", testValidate)
+}
+
+func testValidate(t *testing.T, when spec.G, it spec.S) {
+	var (
+		ctx             = context.Background()
+		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		validator       = &fakeBuilderValidator{}
+		keychain        = &registryfakes.FakeKeychain{Name: "builder-keychain"}
+	)
+
+	readyStatus := corev1alpha1.Status{
+		Conditions: corev1alpha1.Conditions{
+			{Type: corev1alpha1.ConditionReady, Status: "True"},
+		},
+	}
+
+	store := &buildapi.Store{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-store", Namespace: "some-namespace"},
+		Status: buildapi.ClusterStoreStatus{
+			Status: readyStatus,
+		},
+	}
+
+	stack := &buildapi.Stack{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-stack", Namespace: "some-namespace"},
+		Status: buildapi.ClusterStackStatus{
+			Status: readyStatus,
+			ResolvedClusterStack: buildapi.ResolvedClusterStack{
+				Id: "some.stack.id",
+			},
+		},
+	}
+
+	clusterStore := &buildapi.ClusterStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-cluster-store"},
+		Status: buildapi.ClusterStoreStatus{
+			Status: readyStatus,
+		},
+	}
+
+	clusterStack := &buildapi.ClusterStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-cluster-stack"},
+		Status: buildapi.ClusterStackStatus{
+			Status: readyStatus,
+		},
+	}
+
+	client := fake.NewSimpleClientset(store, stack, clusterStore, clusterStack)
+
+	const builderYaml = `
+apiVersion: kpack.io/v1alpha2
+kind: Builder
+metadata:
+  name: some-builder
+spec:
+  tag: example.com/some-builder
+  serviceAccountName: some-service-account
+  store:
+    kind: Store
+    name: some-store
+  stack:
+    kind: Stack
+    name: some-stack
+  order:
+  - group:
+    - id: some-buildpack
+`
+
+	it("validates a builder in the given namespace with its namespaced store and stack", func() {
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount: "some-service-account",
+			Namespace:      "some-namespace",
+		}, keychain)
+		validator.problems = []error{errors.New("some-problem")}
+
+		problems, err := validate(ctx, []byte(builderYaml), "some-namespace", client, keychainFactory, validator)
+		require.NoError(t, err)
+
+		assert.Equal(t, []error{errors.New("some-problem")}, problems)
+		assert.Equal(t, authn.NewMultiKeychain(authn.DefaultKeychain, keychain), validator.keychain)
+		assert.Equal(t, &buildapi.ClusterStore{ObjectMeta: store.ObjectMeta, Status: store.Status}, validator.clusterStore)
+		assert.Equal(t, &buildapi.ClusterStack{ObjectMeta: stack.ObjectMeta, Status: stack.Status}, validator.clusterStack)
+		assert.Equal(t, "example.com/some-builder", validator.spec.Tag)
+		assert.Equal(t, "some-buildpack", validator.spec.Order[0].Group[0].Id)
+	})
+
+	it("prefers the namespace of the builder", func() {
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount: "some-service-account",
+			Namespace:      "some-namespace",
+		}, keychain)
+
+		const withNamespace = `
+apiVersion: kpack.io/v1alpha2
+kind: Builder
+metadata:
+  name: some-builder
+  namespace: some-namespace
+spec:
+  tag: example.com/some-builder
+  serviceAccountName: some-service-account
+  store:
+    kind: Store
+    name: some-store
+  stack:
+    kind: Stack
+    name: some-stack
+`
+
+		problems, err := validate(ctx, []byte(withNamespace), "other-namespace", client, keychainFactory, validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, problems)
+		assert.Equal(t, store.Name, validator.clusterStore.Name)
+	})
+
+	it("validates a cluster builder with its cluster store and cluster stack", func() {
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount: "some-service-account",
+			Namespace:      "kpack",
+		}, keychain)
+
+		const clusterBuilderYaml = `
+apiVersion: kpack.io/v1alpha2
+kind: ClusterBuilder
+metadata:
+  name: some-cluster-builder
+spec:
+  tag: example.com/some-cluster-builder
+  serviceAccountRef:
+    name: some-service-account
+    namespace: kpack
+  store:
+    kind: ClusterStore
+    name: some-cluster-store
+  stack:
+    kind: ClusterStack
+    name: some-cluster-stack
+`
+
+		problems, err := validate(ctx, []byte(clusterBuilderYaml), "some-namespace", client, keychainFactory, validator)
+		require.NoError(t, err)
+
+		assert.Empty(t, problems)
+		assert.Equal(t, clusterStore, validator.clusterStore)
+		assert.Equal(t, clusterStack, validator.clusterStack)
+		assert.Equal(t, "example.com/some-cluster-builder", validator.spec.Tag)
+	})
+
+	it("returns an error when the store does not exist", func() {
+		const missingStore = `
+apiVersion: kpack.io/v1alpha2
+kind: Builder
+metadata:
+  name: some-builder
+spec:
+  tag: example.com/some-builder
+  store:
+    kind: Store
+    name: missing-store
+  stack:
+    kind: Stack
+    name: some-stack
+`
+
+		_, err := validate(ctx, []byte(missingStore), "some-namespace", client, keychainFactory, validator)
+		assert.EqualError(t, err, `stores.kpack.io "missing-store" not found`)
+	})
+
+	it("returns an error for other kinds", func() {
+		_, err := validate(ctx, []byte("apiVersion: kpack.io/v1alpha2\nkind: Image\n"), "some-namespace", client, keychainFactory, validator)
+		assert.EqualError(t, err, `unsupported kind "Image", expected Builder or ClusterBuilder`)
+	})
+}
+
+type fakeBuilderValidator struct {
+	problems []error
+
+	keychain     authn.Keychain
+	clusterStore *buildapi.ClusterStore
+	clusterStack *buildapi.ClusterStack
+	spec         buildapi.BuilderSpec
+}
+
+func (f *fakeBuilderValidator) ValidateBuilder(keychain authn.Keychain, clusterStore *buildapi.ClusterStore, clusterStack *buildapi.ClusterStack, spec buildapi.BuilderSpec) []error {
+	f.keychain = keychain
+	f.clusterStore = clusterStore
+	f.clusterStack = clusterStack
+	f.spec = spec
+	return f.problems
+}
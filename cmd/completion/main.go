@@ -7,10 +7,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/cmd/cosign/cli/attest"
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/cmd/cosign/cli/sign"
 
 	"github.com/pivotal/kpack/pkg/cosign"
@@ -25,6 +30,7 @@ const (
 	reportFilePath       = "/var/report/report.toml"
 	notarySecretDir      = "/var/notary/v1"
 	cosignSecretLocation = "/var/build-secrets/cosign"
+	cosignOIDCTokenPath  = "/var/run/sigstore/cosign/oidc-token"
 )
 
 var (
@@ -35,6 +41,27 @@ var (
 	cosignAnnotations       flaghelpers.CredentialsFlags
 	cosignRepositories      flaghelpers.CredentialsFlags
 	cosignDockerMediaTypes  flaghelpers.CredentialsFlags
+	cosignKeyless           bool
+	cosignFulcioURL         string
+	cosignRekorURL          string
+	cosignOIDCIssuer        string
+	cosignAttestProvenance  bool
+	cosignAttestSBOM        flaghelpers.CredentialsFlags
+	builderImage            string
+	buildInvocationID       string
+	buildChanges            string
+	gitURL                  string
+	gitRevision             string
+	blobURL                 string
+	blobSha256              string
+	registryImage           string
+	s3Bucket                string
+	s3Key                   string
+	s3Endpoint              string
+	s3Version               string
+	customKind              string
+	customEndpoint          string
+	customVersion           string
 	basicGitCredentials     flaghelpers.CredentialsFlags
 	sshGitCredentials       flaghelpers.CredentialsFlags
 	logger                  *log.Logger
@@ -51,13 +78,35 @@ func init() {
 	flag.Var(&cosignAnnotations, "cosign-annotations", "Cosign custom signing annotations")
 	flag.Var(&cosignRepositories, "cosign-repositories", "Cosign signing repository of the form 'secretname=registry.example.com/project'")
 	flag.Var(&cosignDockerMediaTypes, "cosign-docker-media-types", "Cosign signing with legacy docker media types of the form 'secretname=1'")
+	flag.BoolVar(&cosignKeyless, "cosign-keyless", false, "Cosign keyless signing with the identity token of the build service account")
+	flag.StringVar(&cosignFulcioURL, "cosign-fulcio-url", options.DefaultFulcioURL, "Fulcio server url used for cosign keyless signing")
+	flag.StringVar(&cosignRekorURL, "cosign-rekor-url", options.DefaultRekorURL, "Rekor server url used for cosign keyless signing")
+	flag.StringVar(&cosignOIDCIssuer, "cosign-oidc-issuer", options.DefaultOIDCIssuerURL, "OIDC issuer of the identity token used for cosign keyless signing")
+	flag.BoolVar(&cosignAttestProvenance, "cosign-attest-provenance", false, "Attach a SLSA provenance attestation to the image")
+	flag.Var(&cosignAttestSBOM, "cosign-attest-sbom", "Attach the sbom of the image as an attestation in the format 'spdx' or 'cyclonedx'")
+
+	flag.StringVar(&builderImage, "builder-image", "", "The builder image recorded in the provenance attestation")
+	flag.StringVar(&buildInvocationID, "build-invocation-id", "", "The id of the build recorded in the provenance attestation")
+	flag.StringVar(&buildChanges, "build-changes", os.Getenv("BUILD_CHANGES"), "JSON string of build changes and their reason")
+	flag.StringVar(&gitURL, "git-url", os.Getenv("GIT_URL"), "The url of the Git repository that was built")
+	flag.StringVar(&gitRevision, "git-revision", os.Getenv("GIT_REVISION"), "The Git revision that was built")
+	flag.StringVar(&blobURL, "blob-url", os.Getenv("BLOB_URL"), "The url of the source code blob that was built")
+	flag.StringVar(&blobSha256, "blob-sha256", os.Getenv("BLOB_SHA256"), "The sha256 digest of the source code blob that was built")
+	flag.StringVar(&registryImage, "registry-image", os.Getenv("REGISTRY_IMAGE"), "The registry location of the source code image that was built")
+	flag.StringVar(&s3Bucket, "s3-bucket", os.Getenv("S3_BUCKET"), "The bucket of the source code archive that was built")
+	flag.StringVar(&s3Key, "s3-key", os.Getenv("S3_KEY"), "The key of the source code archive that was built")
+	flag.StringVar(&s3Endpoint, "s3-endpoint", os.Getenv("S3_ENDPOINT"), "The endpoint of the S3 compatible object store of the source code archive")
+	flag.StringVar(&s3Version, "s3-version", os.Getenv("S3_VERSION"), "The version of the source code archive that was built")
+	flag.StringVar(&customKind, "custom-source-kind", os.Getenv("CUSTOM_SOURCE_KIND"), "The kind of the custom source that was built")
+	flag.StringVar(&customEndpoint, "custom-source-endpoint", os.Getenv("CUSTOM_SOURCE_ENDPOINT"), "The url of the source resolver of the custom source")
+	flag.StringVar(&customVersion, "custom-source-version", os.Getenv("CUSTOM_SOURCE_VERSION"), "The version of the custom source that was built")
 	logger = log.New(os.Stdout, "", 0)
 }
 
 func main() {
 	flag.Parse()
 
-	if hasCosign() || notaryV1URL != "" {
+	if hasCosign() || cosignKeyless || hasAttestations() || notaryV1URL != "" {
 		err := signImage()
 		if err != nil {
 			log.Fatal(err)
@@ -107,9 +156,11 @@ func signImage() error {
 		}
 	}
 
-	if hasCosign() {
-		cosignSigner := cosign.NewImageSigner(logger, sign.SignCmd)
+	if hasAttestations() && !hasCosign() && !cosignKeyless {
+		return errors.New("cosign attestations require a cosign secret or keyless signing")
+	}
 
+	if hasCosign() || cosignKeyless {
 		annotations, err := mapKeyValueArgs(cosignAnnotations)
 		if err != nil {
 			return err
@@ -125,14 +176,66 @@ func signImage() error {
 			return err
 		}
 
-		if err := cosignSigner.Sign(
-			context.Background(),
-			report,
-			cosignSecretLocation,
-			annotations,
-			repositories,
-			mediaTypes); err != nil {
-			return errors.Wrap(err, "cosign sign")
+		keyless := cosign.KeylessOptions{
+			FulcioURL:   cosignFulcioURL,
+			RekorURL:    cosignRekorURL,
+			OIDCIssuer:  cosignOIDCIssuer,
+			IDTokenPath: cosignOIDCTokenPath,
+		}
+
+		cosignSigner := cosign.NewImageSigner(logger, sign.SignCmd)
+
+		if cosignKeyless {
+			if err := cosignSigner.SignKeyless(
+				context.Background(),
+				report,
+				keyless,
+				annotations); err != nil {
+				return errors.Wrap(err, "cosign keyless sign")
+			}
+		}
+
+		if hasCosign() {
+			if err := cosignSigner.Sign(
+				context.Background(),
+				report,
+				cosignSecretLocation,
+				annotations,
+				repositories,
+				mediaTypes); err != nil {
+				return errors.Wrap(err, "cosign sign")
+			}
+		}
+
+		if hasAttestations() {
+			attestations, err := imageAttestations(report, creds)
+			if err != nil {
+				return errors.Wrap(err, "cosign attestations")
+			}
+
+			cosignAttester := cosign.NewImageAttester(logger, attest.AttestCmd)
+
+			if cosignKeyless {
+				if err := cosignAttester.AttestKeyless(
+					context.Background(),
+					report,
+					keyless,
+					attestations); err != nil {
+					return errors.Wrap(err, "cosign keyless attest")
+				}
+			}
+
+			if hasCosign() {
+				if err := cosignAttester.Attest(
+					context.Background(),
+					report,
+					cosignSecretLocation,
+					attestations,
+					repositories,
+					mediaTypes); err != nil {
+					return errors.Wrap(err, "cosign attest")
+				}
+			}
 		}
 	}
 
@@ -149,6 +252,62 @@ func signImage() error {
 	return nil
 }
 
+func imageAttestations(report platform.ExportReport, keychain authn.Keychain) ([]cosign.Attestation, error) {
+	if len(report.Image.Tags) == 0 {
+		return nil, errors.New("no image found in report to attest")
+	}
+
+	ref, err := name.ParseReference(report.Image.Tags[0])
+	if err != nil {
+		return nil, err
+	}
+
+	image, _, err := (&registry.Client{}).Fetch(keychain, ref.Context().Name()+"@"+report.Image.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	var attestations []cosign.Attestation
+	if cosignAttestProvenance {
+		provenance := cosign.BuildProvenance{
+			BuildInvocationID: buildInvocationID,
+			BuilderImage:      builderImage,
+			BuildChanges:      buildChanges,
+			Source: cosign.ProvenanceSource{
+				GitURL:         gitURL,
+				GitRevision:    gitRevision,
+				BlobURL:        blobURL,
+				BlobSHA256:     blobSha256,
+				RegistryImage:  registryImage,
+				S3Bucket:       s3Bucket,
+				S3Key:          s3Key,
+				S3Endpoint:     s3Endpoint,
+				S3Version:      s3Version,
+				CustomKind:     customKind,
+				CustomEndpoint: customEndpoint,
+				CustomVersion:  customVersion,
+			},
+			FinishedOn: time.Now(),
+		}
+
+		attestation, err := provenance.Attestation(image)
+		if err != nil {
+			return nil, err
+		}
+		attestations = append(attestations, attestation)
+	}
+
+	sboms, err := cosign.SBOMAttestations(image, cosignAttestSBOM)
+	if err != nil {
+		return nil, err
+	}
+	if len(cosignAttestSBOM) > 0 && len(sboms) == 0 {
+		logger.Printf("No %s sbom found in image", strings.Join(cosignAttestSBOM, " or "))
+	}
+
+	return append(attestations, sboms...), nil
+}
+
 func mapKeyValueArgs(args flaghelpers.CredentialsFlags) (map[string]interface{}, error) {
 	overrides := make(map[string]interface{})
 
@@ -168,6 +327,10 @@ func mapKeyValueArgs(args flaghelpers.CredentialsFlags) (map[string]interface{},
 	return overrides, nil
 }
 
+func hasAttestations() bool {
+	return cosignAttestProvenance || len(cosignAttestSBOM) > 0
+}
+
 func hasCosign() bool {
 	_, err := os.Stat(cosignSecretLocation)
 	return !os.IsNotExist(err)
@@ -8,10 +8,13 @@ import (
 	"os"
 	"strconv"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -27,6 +30,7 @@ import (
 	"knative.dev/pkg/metrics"
 	"knative.dev/pkg/profiling"
 	"knative.dev/pkg/signals"
+	"knative.dev/pkg/system"
 
 	"github.com/pivotal/kpack/cmd"
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -34,20 +38,28 @@ import (
 	"github.com/pivotal/kpack/pkg/buildpod"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	"github.com/pivotal/kpack/pkg/client/informers/externalversions"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/cnb"
 	"github.com/pivotal/kpack/pkg/config"
+	"github.com/pivotal/kpack/pkg/cosign"
+	"github.com/pivotal/kpack/pkg/customsource"
 	"github.com/pivotal/kpack/pkg/dockercreds/k8sdockercreds"
 	"github.com/pivotal/kpack/pkg/duckbuilder"
 	"github.com/pivotal/kpack/pkg/git"
+	"github.com/pivotal/kpack/pkg/gitwebhook"
 	"github.com/pivotal/kpack/pkg/reconciler"
 	"github.com/pivotal/kpack/pkg/reconciler/build"
 	"github.com/pivotal/kpack/pkg/reconciler/builder"
+	"github.com/pivotal/kpack/pkg/reconciler/builderrollout"
 	clusterBuilder "github.com/pivotal/kpack/pkg/reconciler/clusterbuilder"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstack"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstore"
 	"github.com/pivotal/kpack/pkg/reconciler/image"
 	"github.com/pivotal/kpack/pkg/reconciler/sourceresolver"
+	"github.com/pivotal/kpack/pkg/reconciler/stack"
+	"github.com/pivotal/kpack/pkg/reconciler/store"
 	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/s3"
 )
 
 const (
@@ -64,6 +76,24 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return v
 }
 
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	s := os.Getenv(key)
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	s := os.Getenv(key)
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
 var (
 	kubeconfig = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	masterURL  = flag.String("master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
@@ -74,6 +104,21 @@ var (
 	completionImage        = flag.String("completion-image", os.Getenv("COMPLETION_IMAGE"), "The image used to finish a build")
 	completionWindowsImage = flag.String("completion-windows-image", os.Getenv("COMPLETION_WINDOWS_IMAGE"), "The image used to finish a build on windows")
 	enablePriorityClasses  = flag.Bool("enable-priority-classes", getEnvBool("ENABLE_PRIORITY_CLASSES", false), "if set to true, enables different pod priority classes for normal builds and automated builds")
+	buildTimeout           = flag.Duration("build-timeout", getEnvDuration("BUILD_TIMEOUT", 0), "The default timeout for builds that do not specify one. Builds without a timeout run indefinitely if unset")
+
+	maxConcurrentBuilds             = flag.Int("max-concurrent-builds", getEnvInt("MAX_CONCURRENT_BUILDS", 0), "The maximum number of builds running in the cluster at once. Additional builds are queued. Unlimited if 0")
+	maxConcurrentBuildsPerNamespace = flag.Int("max-concurrent-builds-per-namespace", getEnvInt("MAX_CONCURRENT_BUILDS_PER_NAMESPACE", 0), "The maximum number of builds running in a namespace at once. Additional builds are queued. Unlimited if 0")
+
+	maintenanceWindowSchedule = flag.String("maintenance-window-schedule", os.Getenv("MAINTENANCE_WINDOW_SCHEDULE"), "The cron schedule of the default maintenance window for builder triggered rebuilds. Rebuilds are not deferred if unset")
+	maintenanceWindowDuration = flag.Duration("maintenance-window-duration", getEnvDuration("MAINTENANCE_WINDOW_DURATION", 0), "How long the default maintenance window stays open")
+	maintenanceWindowTimeZone = flag.String("maintenance-window-timezone", os.Getenv("MAINTENANCE_WINDOW_TIMEZONE"), "The timezone of the default maintenance window schedule. Defaults to UTC")
+
+	sourcePollingFrequency        = flag.Duration("source-polling-frequency", getEnvDuration("SOURCE_POLLING_FREQUENCY", 1*time.Minute), "How often git sources are polled for new revisions")
+	storePollingFrequency         = flag.Duration("store-polling-frequency", getEnvDuration("STORE_POLLING_FREQUENCY", 10*time.Minute), "How often ClusterStore sources referenced by tag are polled for new digests. Polling is disabled if 0")
+	stackPollingFrequency         = flag.Duration("stack-polling-frequency", getEnvDuration("STACK_POLLING_FREQUENCY", 10*time.Minute), "How often ClusterStack images referenced by tag are polled for new digests. Polling is disabled if 0")
+	gitWebhookSecretName          = flag.String("git-webhook-secret-name", os.Getenv("GIT_WEBHOOK_SECRET_NAME"), "Name of the secret in the system namespace used to validate git webhooks. The git webhook receiver is disabled if unset")
+	gitWebhookAddress             = flag.String("git-webhook-address", ":8090", "The address the git webhook receiver listens on")
+	gitWebhookFallbackPollingFreq = flag.Duration("git-webhook-fallback-polling-frequency", getEnvDuration("GIT_WEBHOOK_FALLBACK_POLLING_FREQUENCY", 10*time.Minute), "How often git sources are polled when the git webhook receiver is enabled")
 )
 
 func main() {
@@ -103,8 +148,29 @@ func main() {
 		Logger:                  logger,
 		Client:                  client,
 		ResyncPeriod:            10 * time.Hour,
-		SourcePollingFrequency:  1 * time.Minute,
+		SourcePollingFrequency:  *sourcePollingFrequency,
 		BuilderPollingFrequency: 1 * time.Minute,
+		StorePollingFrequency:   *storePollingFrequency,
+		StackPollingFrequency:   *stackPollingFrequency,
+		BuildTimeout:            *buildTimeout,
+
+		MaxConcurrentBuilds:             *maxConcurrentBuilds,
+		MaxConcurrentBuildsPerNamespace: *maxConcurrentBuildsPerNamespace,
+	}
+
+	if *maintenanceWindowSchedule != "" {
+		options.MaintenanceWindow = &buildapi.MaintenanceWindow{
+			Schedule: *maintenanceWindowSchedule,
+			Duration: metav1.Duration{Duration: *maintenanceWindowDuration},
+			TimeZone: *maintenanceWindowTimeZone,
+		}
+		if err := options.MaintenanceWindow.Validate(ctx); err != nil {
+			log.Fatalf("invalid maintenance window: %s", err)
+		}
+	}
+
+	if *gitWebhookSecretName != "" {
+		options.SourcePollingFrequency = *gitWebhookFallbackPollingFreq
 	}
 
 	informerFactory := externalversions.NewSharedInformerFactory(client, options.ResyncPeriod)
@@ -115,6 +181,8 @@ func main() {
 	clusterBuilderInformer := informerFactory.Kpack().V1alpha2().ClusterBuilders()
 	clusterStoreInformer := informerFactory.Kpack().V1alpha2().ClusterStores()
 	clusterStackInformer := informerFactory.Kpack().V1alpha2().ClusterStacks()
+	storeInformer := informerFactory.Kpack().V1alpha2().Stores()
+	stackInformer := informerFactory.Kpack().V1alpha2().Stacks()
 
 	duckBuilderInformer := &duckbuilder.DuckBuilderInformer{
 		BuilderInformer:        builderInformer,
@@ -124,6 +192,7 @@ func main() {
 	k8sInformerFactory := informers.NewSharedInformerFactory(k8sClient, options.ResyncPeriod)
 	pvcInformer := k8sInformerFactory.Core().V1().PersistentVolumeClaims()
 	podInformer := k8sInformerFactory.Core().V1().Pods()
+	namespaceInformer := k8sInformerFactory.Core().V1().Namespaces()
 
 	keychainFactory, err := k8sdockercreds.NewSecretKeychainFactory(k8sClient)
 	if err != nil {
@@ -155,15 +224,27 @@ func main() {
 	}
 
 	gitResolver := git.NewResolver(k8sClient)
-	blobResolver := &blob.Resolver{}
-	registryResolver := &registry.Resolver{}
+	blobResolver := blob.NewResolver(k8sClient)
+	registryResolver := &registry.Resolver{
+		KeychainFactory: keychainFactory,
+		ImageFetcher:    &registry.Client{},
+	}
+	s3Resolver := s3.NewResolver(k8sClient)
+
+	customSourceEndpoints := customsource.NewEndpoints()
+	configMapWatcher.WatchWithDefault(corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: customsource.ConfigName}}, customSourceEndpoints.Update)
+	customSourceResolver := customsource.NewResolver(k8sClient, customSourceEndpoints)
+
+	imageVerifier := cosign.NewImageVerifier()
 
 	remoteStoreReader := &cnb.RemoteStoreReader{
 		RegistryClient: &registry.Client{},
+		Verifier:       imageVerifier,
 	}
 
 	remoteStackReader := &cnb.RemoteStackReader{
 		RegistryClient: &registry.Client{},
+		Verifier:       imageVerifier,
 	}
 
 	kpackKeychain, err := keychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{})
@@ -171,23 +252,32 @@ func main() {
 		log.Fatalf("could not create empty keychain %s", err)
 	}
 
-	lifecycleProvider := config.NewLifecycleProvider(&registry.Client{}, keychainFactory)
+	lifecycleProvider := config.NewLifecycleProvider(&registry.Client{}, keychainFactory, imageVerifier)
 	configMapWatcher.Watch(config.LifecycleConfigName, lifecycleProvider.UpdateImage)
 
 	builderCreator := &cnb.RemoteBuilderCreator{
 		RegistryClient:         &registry.Client{},
 		KpackVersion:           cmd.Identifer,
 		LifecycleProvider:      lifecycleProvider,
+		ImageVerifier:          imageVerifier,
 		NewBuildpackRepository: newBuildpackRepository(kpackKeychain),
 	}
 
 	buildController := build.NewController(options, k8sClient, buildInformer, podInformer, metadataRetriever, buildpodGenerator)
-	imageController := image.NewController(options, k8sClient, imageInformer, buildInformer, duckBuilderInformer, sourceResolverInformer, pvcInformer, *enablePriorityClasses)
-	sourceResolverController := sourceresolver.NewController(options, sourceResolverInformer, gitResolver, blobResolver, registryResolver)
-	builderController, builderResync := builder.NewController(options, builderInformer, builderCreator, keychainFactory, clusterStoreInformer, clusterStackInformer)
+	imageController := image.NewController(options, k8sClient, imageInformer, buildInformer, duckBuilderInformer, sourceResolverInformer, pvcInformer, namespaceInformer, *enablePriorityClasses)
+	sourceResolverController := sourceresolver.NewController(options, sourceResolverInformer, gitResolver, blobResolver, registryResolver, s3Resolver, customSourceResolver)
+	builderController, builderResync := builder.NewController(options, builderInformer, builderCreator, keychainFactory, clusterStoreInformer, clusterStackInformer, storeInformer, stackInformer)
 	clusterBuilderController, clusterBuilderResync := clusterBuilder.NewController(options, clusterBuilderInformer, builderCreator, keychainFactory, clusterStoreInformer, clusterStackInformer)
+	builderRolloutController := builderrollout.NewController(options, builderInformer, clusterBuilderInformer, imageInformer, buildInformer)
 	clusterStoreController := clusterstore.NewController(options, keychainFactory, clusterStoreInformer, remoteStoreReader)
 	clusterStackController := clusterstack.NewController(options, keychainFactory, clusterStackInformer, remoteStackReader)
+	storeController := store.NewController(options, keychainFactory, storeInformer, remoteStoreReader)
+	stackController := stack.NewController(options, keychainFactory, stackInformer, remoteStackReader)
+
+	gitWebhookServer, err := newGitWebhookServer(logger, k8sClient, sourceResolverInformer, sourceResolverController)
+	if err != nil {
+		log.Fatalf("could not create git webhook receiver: %s", err)
+	}
 
 	lifecycleProvider.AddEventHandler(builderResync)
 	lifecycleProvider.AddEventHandler(clusterBuilderResync)
@@ -206,6 +296,9 @@ func main() {
 		clusterBuilderInformer.Informer(),
 		clusterStoreInformer.Informer(),
 		clusterStackInformer.Informer(),
+		storeInformer.Informer(),
+		stackInformer.Informer(),
+		namespaceInformer.Informer(),
 	)
 
 	err = runGroup(
@@ -215,7 +308,10 @@ func main() {
 		run(buildController, routinesPerController),
 		run(builderController, routinesPerController),
 		run(clusterBuilderController, routinesPerController),
+		run(builderRolloutController, routinesPerController),
 		run(clusterStoreController, routinesPerController),
+		run(storeController, routinesPerController),
+		run(stackController, routinesPerController),
 		run(sourceResolverController, 2*routinesPerController),
 		configMapWatcher.Start,
 		func(done <-chan struct{}) error {
@@ -225,6 +321,19 @@ func main() {
 			<-done
 			return profilingServer.Shutdown(ctx)
 		},
+		func(done <-chan struct{}) error {
+			if gitWebhookServer == nil {
+				return nil
+			}
+			return gitWebhookServer.ListenAndServe()
+		},
+		func(done <-chan struct{}) error {
+			<-done
+			if gitWebhookServer == nil {
+				return nil
+			}
+			return gitWebhookServer.Shutdown(ctx)
+		},
 	)
 	if err != nil && err != http.ErrServerClosed {
 		logger.Fatalw("Error running controller", zap.Error(err))
@@ -260,9 +369,33 @@ func newBuildpackRepository(keychain authn.Keychain) func(clusterStore *buildapi
 	}
 }
 
-const controllerCount = 7
+func newGitWebhookServer(logger *zap.SugaredLogger, k8sClient kubernetes.Interface, sourceResolverInformer buildinformers.SourceResolverInformer, sourceResolverController *controller.Impl) (*http.Server, error) {
+	if *gitWebhookSecretName == "" {
+		return nil, nil
+	}
+
+	if err := gitwebhook.AddIndexers(sourceResolverInformer.Informer()); err != nil {
+		return nil, err
+	}
+
+	receiver := &gitwebhook.Receiver{
+		Logger:          logger,
+		K8sClient:       k8sClient,
+		SecretNamespace: system.Namespace(),
+		SecretName:      *gitWebhookSecretName,
+		Indexer:         sourceResolverInformer.Informer().GetIndexer(),
+		Enqueue:         sourceResolverController.Enqueue,
+	}
+
+	return &http.Server{
+		Addr:    *gitWebhookAddress,
+		Handler: receiver.Handler(),
+	}, nil
+}
+
+const controllerCount = 10
 
-//lifted from knative.dev/pkg/injection/sharedmain
+// lifted from knative.dev/pkg/injection/sharedmain
 func genericControllerSetup(ctx context.Context, cfg *rest.Config) (*zap.SugaredLogger, *informer.InformedWatcher, *http.Server) {
 	metrics.MemStatsOrDie(ctx)
 
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// BuildConfigFromFlags loads the client config of command line tools. Unlike
+// clientcmd.BuildConfigFromFlags it falls back to the default kubeconfig
+// loading rules instead of the in-cluster config.
+func BuildConfigFromFlags(masterURL, kubeconfigPath string) (*rest.Config, error) {
+
+	var clientConfigLoader clientcmd.ClientConfigLoader
+
+	if kubeconfigPath == "" {
+		clientConfigLoader = clientcmd.NewDefaultClientConfigLoadingRules()
+	} else {
+		clientConfigLoader = &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath}
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientConfigLoader,
+		&clientcmd.ConfigOverrides{ClusterInfo: api.Cluster{Server: masterURL}}).ClientConfig()
+
+}
@@ -8,10 +8,8 @@ import (
 
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/pivotal/kpack/cmd"
 	"github.com/pivotal/kpack/pkg/logs"
 )
 
@@ -26,7 +24,7 @@ var (
 func main() {
 	flag.Parse()
 
-	clusterConfig, err := BuildConfigFromFlags(*masterURL, *kubeconfig)
+	clusterConfig, err := cmd.BuildConfigFromFlags(*masterURL, *kubeconfig)
 	if err != nil {
 		log.Fatalf("Error building kubeconfig: %v", err)
 	}
@@ -47,19 +45,3 @@ func main() {
 	}
 
 }
-
-func BuildConfigFromFlags(masterURL, kubeconfigPath string) (*rest.Config, error) {
-
-	var clientConfigLoader clientcmd.ClientConfigLoader
-
-	if kubeconfigPath == "" {
-		clientConfigLoader = clientcmd.NewDefaultClientConfigLoadingRules()
-	} else {
-		clientConfigLoader = &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath}
-	}
-
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		clientConfigLoader,
-		&clientcmd.ConfigOverrides{ClusterInfo: api.Cluster{Server: masterURL}}).ClientConfig()
-
-}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	_ "time/tzdata"
 
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -33,6 +34,8 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	v1alpha2.SchemeGroupVersion.WithKind("ClusterBuilder"): &v1alpha2.ClusterBuilder{},
 	v1alpha2.SchemeGroupVersion.WithKind("ClusterStore"):   &v1alpha2.ClusterStore{},
 	v1alpha2.SchemeGroupVersion.WithKind("ClusterStack"):   &v1alpha2.ClusterStack{},
+	v1alpha2.SchemeGroupVersion.WithKind("Store"):          &v1alpha2.Store{},
+	v1alpha2.SchemeGroupVersion.WithKind("Stack"):          &v1alpha2.Stack{},
 }
 
 func init() {
@@ -107,7 +107,7 @@ func testBuildValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			build.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(build, apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(build, apis.ErrMissingOneOf("git", "blob", "registry", "s3", "custom").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
@@ -177,7 +177,7 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			image.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry", "s3", "custom").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
@@ -1,6 +1,7 @@
 package v1alpha2
 
 import (
+	"math"
 	"strconv"
 
 	"github.com/google/go-containerregistry/pkg/name"
@@ -11,6 +12,18 @@ import (
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
+const (
+	BuildTimedOut  = "BuildTimedOut"
+	BuildCancelled = "BuildCancelled"
+	BuildQueued    = "BuildQueued"
+	// BlobDigestMismatch is the reason of a failed build whose blob source does not match its sha256 digest
+	BlobDigestMismatch = "BlobDigestMismatch"
+	// TransientRegistryError is the reason of a failed build whose lifecycle failed to reach the registry
+	TransientRegistryError = "TransientRegistryError"
+
+	ConditionQueued corev1alpha1.ConditionType = "Queued"
+)
+
 func (*Build) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Build")
 }
@@ -70,6 +83,25 @@ func (b *Build) PriorityClassName() string {
 	return b.Spec.PriorityClassName
 }
 
+func (b *Build) ActiveDeadlineSeconds() *int64 {
+	if b == nil || b.Spec.Timeout == nil {
+		return nil
+	}
+	seconds := int64(math.Ceil(b.Spec.Timeout.Seconds()))
+	return &seconds
+}
+
+func (b *Build) RetryAttempt() int64 {
+	if b == nil {
+		return 0
+	}
+	attempt, err := strconv.ParseInt(b.GetAnnotations()[BuildRetryAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return attempt
+}
+
 func (b *Build) ImageGeneration() int64 {
 	if b == nil {
 		return 0
@@ -161,6 +193,12 @@ func (b *Build) rebasable(builderStack string) bool {
 		b.Annotations[BuildReasonAnnotation] == BuildReasonStack && b.Spec.LastBuild.StackId == builderStack
 }
 
+// OutdatedBy reports whether a successful build used a different run image or
+// a buildpack that is no longer provided by the builder
+func (b *Build) OutdatedBy(runImage string, buildpacks corev1alpha1.BuildpackMetadataList) bool {
+	return b.IsSuccess() && (!b.builtWithStack(runImage) || !b.builtWithBuildpacks(buildpacks))
+}
+
 func (b *Build) builtWithStack(runImage string) bool {
 	if b.Status.Stack.RunImage == "" {
 		return false
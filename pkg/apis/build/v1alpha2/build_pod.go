@@ -29,16 +29,28 @@ const (
 	COSIGNSecretDataCosignPassword         = "cosign.password"
 	k8sOSLabel                             = "kubernetes.io/os"
 
+	PrepareContainerName = "prepare"
+
 	cacheDirName                 = "cache-dir"
 	layersDirName                = "layers-dir"
 	platformDir                  = "platform-dir"
 	homeDir                      = "home-dir"
 	workspaceDir                 = "workspace-dir"
 	registrySourcePullSecretsDir = "registry-source-pull-secrets-dir"
+	blobAuthDirName              = "blob-auth-dir"
+	customSourceTokenDirName     = "custom-source-token-dir"
+
+	customSourceTokenPath          = "token"
+	customSourceTokenExpirySeconds = 600
 
 	notaryDirName = "notary-dir"
 	reportDirName = "report-dir"
 
+	cosignOIDCTokenDirName       = "cosign-oidc-token-dir"
+	cosignOIDCTokenPath          = "oidc-token"
+	cosignDefaultAudience        = "sigstore"
+	cosignOIDCTokenExpirySeconds = 600
+
 	networkWaitLauncherDir = "network-wait-launcher-dir"
 
 	buildChangesEnvVar = "BUILD_CHANGES"
@@ -131,6 +143,16 @@ var (
 		MountPath: "/registrySourcePullSecrets",
 		ReadOnly:  true,
 	}
+	blobAuthVolume = corev1.VolumeMount{
+		Name:      blobAuthDirName,
+		MountPath: "/blobAuth",
+		ReadOnly:  true,
+	}
+	customSourceTokenVolume = corev1.VolumeMount{
+		Name:      customSourceTokenDirName,
+		MountPath: "/customSourceToken",
+		ReadOnly:  true,
+	}
 	notaryV1Volume = corev1.VolumeMount{
 		Name:      notaryDirName,
 		MountPath: "/var/notary/v1",
@@ -141,6 +163,11 @@ var (
 		MountPath: "/var/report",
 		ReadOnly:  false,
 	}
+	cosignOIDCTokenVolume = corev1.VolumeMount{
+		Name:      cosignOIDCTokenDirName,
+		MountPath: "/var/run/sigstore/cosign",
+		ReadOnly:  true,
+	}
 	networkWaitLauncherVolume = corev1.VolumeMount{
 		Name:      networkWaitLauncherDir,
 		MountPath: "/networkWait",
@@ -214,10 +241,11 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 	}
 
 	analyzeContainer := corev1.Container{
-		Name:      "analyze",
-		Image:     b.Spec.Builder.Image,
-		Command:   []string{"/cnb/lifecycle/analyzer"},
-		Resources: b.Spec.Resources,
+		Name:                     "analyze",
+		Image:                    b.Spec.Builder.Image,
+		Command:                  []string{"/cnb/lifecycle/analyzer"},
+		Resources:                b.Spec.Resources,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		Args: args([]string{
 			"-layers=/layers",
 			"-analyzed=/layers/analyzed.toml"},
@@ -316,16 +344,18 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 		},
 		Spec: corev1.PodSpec{
 			// If the build fails, don't restart it.
-			RestartPolicy:     corev1.RestartPolicyNever,
-			PriorityClassName: b.PriorityClassName(),
+			RestartPolicy:         corev1.RestartPolicyNever,
+			PriorityClassName:     b.PriorityClassName(),
+			ActiveDeadlineSeconds: b.ActiveDeadlineSeconds(),
 			Containers: steps(func(step func(corev1.Container, ...stepModifier)) {
 				step(corev1.Container{
 					Name:    "completion",
 					Image:   images.completion(buildContext.os()),
 					Command: []string{"/cnb/process/completion"},
-					Env: []corev1.EnvVar{
-						homeEnv,
-					},
+					Env: append(
+						[]corev1.EnvVar{homeEnv},
+						b.cosignAttestationEnv()...,
+					),
 					Args: args(
 						b.notaryArgs(),
 						secretArgs,
@@ -349,7 +379,7 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 			InitContainers: steps(func(step func(corev1.Container, ...stepModifier)) {
 				step(
 					corev1.Container{
-						Name:      "prepare",
+						Name:      PrepareContainerName,
 						Image:     images.buildInit(buildContext.os()),
 						Args:      append(secretArgs, imagePullArgs...),
 						Resources: b.Spec.Resources,
@@ -391,6 +421,8 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 							imagePullVolumeMounts,
 							[]corev1.VolumeMount{
 								registrySourcePullSecretsVolume,
+								blobAuthVolume,
+								customSourceTokenVolume,
 								platformVolume,
 								sourceVolume,
 								homeVolume,
@@ -430,10 +462,11 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 				)
 				step(
 					corev1.Container{
-						Name:      "restore",
-						Image:     b.Spec.Builder.Image,
-						Command:   []string{"/cnb/lifecycle/restorer"},
-						Resources: b.Spec.Resources,
+						Name:                     "restore",
+						Image:                    b.Spec.Builder.Image,
+						Command:                  []string{"/cnb/lifecycle/restorer"},
+						Resources:                b.Spec.Resources,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 						Args: args([]string{
 							"-group=/layers/group.toml",
 							"-layers=/layers",
@@ -492,10 +525,11 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 				)
 				step(
 					corev1.Container{
-						Name:      "export",
-						Image:     b.Spec.Builder.Image,
-						Command:   []string{"/cnb/lifecycle/exporter"},
-						Resources: b.Spec.Resources,
+						Name:                     "export",
+						Image:                    b.Spec.Builder.Image,
+						Command:                  []string{"/cnb/lifecycle/exporter"},
+						Resources:                b.Spec.Resources,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 						Args: args([]string{
 							"-layers=/layers",
 							"-app=/workspace",
@@ -592,6 +626,8 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 						},
 					},
 					b.Spec.Source.Source().ImagePullSecretsVolume(registrySourcePullSecretsDir),
+					b.blobAuthSecretVolume(),
+					b.customSourceTokenVolume(),
 					b.notarySecretVolume(),
 				},
 				bindingVolumes),
@@ -653,6 +689,57 @@ func noOpModifer(container corev1.Container) corev1.Container {
 	return container
 }
 
+func (b *Build) blobAuthSecretVolume() corev1.Volume {
+	if b.Spec.Source.Blob == nil || b.Spec.Source.Blob.Auth == nil {
+		return corev1.Volume{
+			Name: blobAuthDirName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: blobAuthDirName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: b.Spec.Source.Blob.Auth.SecretRef.Name,
+			},
+		},
+	}
+}
+
+// customSourceTokenVolume projects a token of the build service account that
+// the source resolver of a custom source verifies before serving the source.
+func (b *Build) customSourceTokenVolume() corev1.Volume {
+	if b.Spec.Source.Custom == nil {
+		return corev1.Volume{
+			Name: customSourceTokenDirName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
+	expirationSeconds := int64(customSourceTokenExpirySeconds)
+	return corev1.Volume{
+		Name: customSourceTokenDirName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          corev1alpha1.CustomSourceTokenAudience,
+							ExpirationSeconds: &expirationSeconds,
+							Path:              customSourceTokenPath,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (b *Build) notarySecretVolume() corev1.Volume {
 	config := b.NotaryV1Config()
 	if config == nil {
@@ -693,9 +780,65 @@ func (b *Build) cosignArgs() []string {
 			args = append(args, fmt.Sprintf("-cosign-annotations=%s=%s", annotation.Name, annotation.Value))
 		}
 	}
+
+	if keyless := b.cosignKeylessConfig(); keyless != nil {
+		args = append(args, "-cosign-keyless")
+		if keyless.FulcioURL != "" {
+			args = append(args, "-cosign-fulcio-url="+keyless.FulcioURL)
+		}
+		if keyless.RekorURL != "" {
+			args = append(args, "-cosign-rekor-url="+keyless.RekorURL)
+		}
+		if keyless.OIDCIssuer != "" {
+			args = append(args, "-cosign-oidc-issuer="+keyless.OIDCIssuer)
+		}
+	}
+
+	if attestations := b.cosignAttestations(); attestations != nil {
+		if attestations.Provenance {
+			args = append(args,
+				"-cosign-attest-provenance",
+				"-builder-image="+b.Spec.Builder.Image,
+				"-build-invocation-id="+string(b.UID),
+			)
+		}
+		for _, format := range attestations.SBOM {
+			args = append(args, "-cosign-attest-sbom="+format)
+		}
+	}
 	return args
 }
 
+// cosignAttestationEnv provides the source and build changes to the
+// completion step so they can be recorded in the provenance attestation
+func (b *Build) cosignAttestationEnv() []corev1.EnvVar {
+	if attestations := b.cosignAttestations(); attestations == nil || !attestations.Provenance {
+		return nil
+	}
+
+	return append(
+		b.Spec.Source.Source().BuildEnvVars(),
+		corev1.EnvVar{
+			Name:  buildChangesEnvVar,
+			Value: b.BuildChanges(),
+		},
+	)
+}
+
+func (b *Build) cosignKeylessConfig() *CosignKeylessConfig {
+	if b.Spec.Cosign == nil {
+		return nil
+	}
+	return b.Spec.Cosign.Keyless
+}
+
+func (b *Build) cosignAttestations() *CosignAttestations {
+	if b.Spec.Cosign == nil {
+		return nil
+	}
+	return b.Spec.Cosign.Attestations
+}
+
 func (b *Build) rebasePod(buildContext BuildContext, images BuildPodImages) (*corev1.Pod, error) {
 	secretVolumes, secretVolumeMounts, secretArgs := b.setupSecretVolumesAndArgs(buildContext.Secrets, dockerSecrets)
 	cosignVolumes, cosignVolumeMounts, cosignSecretArgs := b.setupCosignVolumes(buildContext.Secrets)
@@ -715,13 +858,14 @@ func (b *Build) rebasePod(buildContext BuildContext, images BuildPodImages) (*co
 			},
 		},
 		Spec: corev1.PodSpec{
-			ServiceAccountName: b.Spec.ServiceAccountName,
-			NodeSelector:       b.nodeSelector("linux"),
-			Tolerations:        b.Spec.Tolerations,
-			Affinity:           b.Spec.Affinity,
-			RuntimeClassName:   b.Spec.RuntimeClassName,
-			SchedulerName:      b.Spec.SchedulerName,
-			PriorityClassName:  b.PriorityClassName(),
+			ServiceAccountName:    b.Spec.ServiceAccountName,
+			NodeSelector:          b.nodeSelector("linux"),
+			Tolerations:           b.Spec.Tolerations,
+			Affinity:              b.Spec.Affinity,
+			RuntimeClassName:      b.Spec.RuntimeClassName,
+			SchedulerName:         b.Spec.SchedulerName,
+			PriorityClassName:     b.PriorityClassName(),
+			ActiveDeadlineSeconds: b.ActiveDeadlineSeconds(),
 			Volumes: volumes(
 				secretVolumes,
 				cosignVolumes,
@@ -742,6 +886,7 @@ func (b *Build) rebasePod(buildContext BuildContext, images BuildPodImages) (*co
 					Name:    "completion",
 					Image:   images.completion(buildContext.os()),
 					Command: []string{"/cnb/process/completion"},
+					Env:     b.cosignAttestationEnv(),
 					Args: args(
 						b.notaryArgs(),
 						secretArgs,
@@ -927,6 +1072,32 @@ func (b *Build) setupCosignVolumes(secrets []corev1.Secret) ([]corev1.Volume, []
 		})
 	}
 
+	if keyless := b.cosignKeylessConfig(); keyless != nil {
+		audience := keyless.Audience
+		if audience == "" {
+			audience = cosignDefaultAudience
+		}
+		expirationSeconds := int64(cosignOIDCTokenExpirySeconds)
+
+		volumes = append(volumes, corev1.Volume{
+			Name: cosignOIDCTokenDirName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          audience,
+								ExpirationSeconds: &expirationSeconds,
+								Path:              cosignOIDCTokenPath,
+							},
+						},
+					},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, cosignOIDCTokenVolume)
+	}
+
 	return volumes, volumeMounts, args
 }
 
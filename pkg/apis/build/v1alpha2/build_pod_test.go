@@ -347,6 +347,23 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, pod.Spec.NodeSelector)
 		})
 
+		it("sets the active deadline from the build timeout", func() {
+			build.Spec.Timeout = &metav1.Duration{Duration: 90 * time.Second}
+
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			require.NotNil(t, pod.Spec.ActiveDeadlineSeconds)
+			assert.Equal(t, int64(90), *pod.Spec.ActiveDeadlineSeconds)
+		})
+
+		it("does not set an active deadline without a build timeout", func() {
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Nil(t, pod.Spec.ActiveDeadlineSeconds)
+		})
+
 		it("configures the pod security context to match the builder config user and group", func() {
 			pod, err := build.BuildPod(config, buildContext)
 			require.NoError(t, err)
@@ -719,6 +736,36 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 			)
 		})
 
+		it("configures the prepare step for git submodules and lfs", func() {
+			build.Spec.Source.Git.Submodules = true
+			build.Spec.Source.Git.LFS = true
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_SUBMODULES",
+					Value: "true",
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_LFS",
+					Value: "true",
+				})
+		})
+
+		it("configures the prepare step for the git fetch depth", func() {
+			build.Spec.Source.Git.Depth = 1
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_DEPTH",
+					Value: "1",
+				})
+		})
+
 		it("configures prepare with the blob source", func() {
 			build.Spec.Source.Git = nil
 			build.Spec.Source.Blob = &corev1alpha1.Blob{
@@ -736,6 +783,43 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 				})
 		})
 
+		it("configures prepare with the blob digest and auth secret volume", func() {
+			build.Spec.Source.Git = nil
+			build.Spec.Source.Blob = &corev1alpha1.Blob{
+				URL:    "https://some-blobstore.example.com/some-blob",
+				Sha256: "c5f2e8a6c1d9b0a0fbeb2b2b6b8f3a1d7d7bdf0e8d2e6cfa8d0c0d3b4c5e6f70",
+				Auth: &corev1alpha1.BlobAuth{
+					SecretRef: corev1.LocalObjectReference{Name: "blob-secret"},
+				},
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "BLOB_SHA256",
+					Value: "c5f2e8a6c1d9b0a0fbeb2b2b6b8f3a1d7d7bdf0e8d2e6cfa8d0c0d3b4c5e6f70",
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      "blob-auth-dir",
+				MountPath: "/blobAuth",
+				ReadOnly:  true,
+			})
+
+			match := 0
+			for _, v := range pod.Spec.Volumes {
+				switch v.Name {
+				case "blob-auth-dir":
+					require.NotNil(t, v.Secret)
+					assert.Equal(t, "blob-secret", v.Secret.SecretName)
+					match++
+				case "registry-source-pull-secrets-dir":
+					assert.NotNil(t, v.EmptyDir)
+				}
+			}
+			assert.Equal(t, 1, match)
+		})
+
 		it("configures prepare with the registry source and a secret volume when is imagePullSecrets provided", func() {
 			build.Spec.Source.Git = nil
 			build.Spec.Source.Blob = nil
@@ -774,6 +858,84 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 				})
 		})
 
+		it("configures prepare with the s3 source and credentials secret volume", func() {
+			build.Spec.Source.Git = nil
+			build.Spec.Source.S3 = &corev1alpha1.S3{
+				Bucket:   "some-bucket",
+				Prefix:   "builds/",
+				Endpoint: "https://minio.example.com",
+				Credentials: &corev1alpha1.S3Credentials{
+					SecretRef: corev1.LocalObjectReference{Name: "s3-secret"},
+				},
+				Object:  "builds/app.tar.gz",
+				Version: "version-id:some-version-id",
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Equal(t, "prepare", pod.Spec.InitContainers[0].Name)
+			assert.Subset(t, pod.Spec.InitContainers[0].Env, []corev1.EnvVar{
+				{Name: "S3_BUCKET", Value: "some-bucket"},
+				{Name: "S3_KEY", Value: "builds/app.tar.gz"},
+				{Name: "S3_ENDPOINT", Value: "https://minio.example.com"},
+				{Name: "S3_VERSION", Value: "version-id:some-version-id"},
+			})
+
+			match := 0
+			for _, v := range pod.Spec.Volumes {
+				if v.Name == "registry-source-pull-secrets-dir" {
+					require.NotNil(t, v.Secret)
+					assert.Equal(t, "s3-secret", v.Secret.SecretName)
+					match++
+				}
+			}
+			assert.Equal(t, 1, match)
+		})
+
+		it("configures prepare with the custom source", func() {
+			build.Spec.Source.Git = nil
+			build.Spec.Source.Custom = &corev1alpha1.Custom{
+				Kind:     "perforce",
+				Config:   map[string]string{"depot": "//depot/app/..."},
+				Endpoint: "http://perforce-resolver.kpack",
+				Version:  "12345",
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Equal(t, "prepare", pod.Spec.InitContainers[0].Name)
+			assert.Subset(t, pod.Spec.InitContainers[0].Env, []corev1.EnvVar{
+				{Name: "CUSTOM_SOURCE_KIND", Value: "perforce"},
+				{Name: "CUSTOM_SOURCE_CONFIG", Value: `{"depot":"//depot/app/..."}`},
+				{Name: "CUSTOM_SOURCE_ENDPOINT", Value: "http://perforce-resolver.kpack"},
+				{Name: "CUSTOM_SOURCE_VERSION", Value: "12345"},
+			})
+
+			assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      "custom-source-token-dir",
+				MountPath: "/customSourceToken",
+				ReadOnly:  true,
+			})
+
+			expirationSeconds := int64(600)
+			assert.Contains(t, pod.Spec.Volumes, corev1.Volume{
+				Name: "custom-source-token-dir",
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{
+							{
+								ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+									Audience:          "kpack-custom-source-resolver",
+									ExpirationSeconds: &expirationSeconds,
+									Path:              "token",
+								},
+							},
+						},
+					},
+				},
+			})
+		})
+
 		it("configures detect step", func() {
 			pod, err := build.BuildPod(config, buildContext)
 			require.NoError(t, err)
@@ -1566,6 +1728,126 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 					)
 				})
 
+				when("keyless signing is configured", func() {
+					it.Before(func() {
+						build.Spec.Cosign = &buildapi.CosignConfig{
+							Keyless: &buildapi.CosignKeylessConfig{
+								FulcioURL:  "https://fulcio.example.com",
+								RekorURL:   "https://rekor.example.com",
+								OIDCIssuer: "https://kubernetes.default.svc",
+								Audience:   "some-audience",
+							},
+						}
+					})
+
+					it("sets up the completion image to sign with the service account token", func() {
+						pod, err := build.BuildPod(config, buildContext)
+						require.NoError(t, err)
+
+						require.Equal(t,
+							[]string{
+								"-basic-docker=docker-secret-1=acr.io",
+								"-dockerconfig=docker-secret-2",
+								"-dockercfg=docker-secret-3",
+								"-cosign-annotations=buildTimestamp=19440606.133000",
+								"-cosign-annotations=buildNumber=12",
+								"-cosign-keyless",
+								"-cosign-fulcio-url=https://fulcio.example.com",
+								"-cosign-rekor-url=https://rekor.example.com",
+								"-cosign-oidc-issuer=https://kubernetes.default.svc",
+							},
+							pod.Spec.Containers[0].Args,
+						)
+
+						require.Contains(t, pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+							Name:      "cosign-oidc-token-dir",
+							MountPath: "/var/run/sigstore/cosign",
+							ReadOnly:  true,
+						})
+
+						expirationSeconds := int64(600)
+						require.Contains(t, pod.Spec.Volumes, corev1.Volume{
+							Name: "cosign-oidc-token-dir",
+							VolumeSource: corev1.VolumeSource{
+								Projected: &corev1.ProjectedVolumeSource{
+									Sources: []corev1.VolumeProjection{
+										{
+											ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+												Audience:          "some-audience",
+												ExpirationSeconds: &expirationSeconds,
+												Path:              "oidc-token",
+											},
+										},
+									},
+								},
+							},
+						})
+					})
+
+					it("defaults the token audience to sigstore", func() {
+						build.Spec.Cosign.Keyless.Audience = ""
+
+						pod, err := build.BuildPod(config, buildContext)
+						require.NoError(t, err)
+
+						for _, volume := range pod.Spec.Volumes {
+							if volume.Name == "cosign-oidc-token-dir" {
+								assert.Equal(t, "sigstore", volume.Projected.Sources[0].ServiceAccountToken.Audience)
+								return
+							}
+						}
+						t.Fatal("cosign-oidc-token-dir volume not found")
+					})
+				})
+
+				when("attestations are configured", func() {
+					it.Before(func() {
+						build.UID = "some-build-uid"
+						build.Spec.Cosign = &buildapi.CosignConfig{
+							Attestations: &buildapi.CosignAttestations{
+								Provenance: true,
+								SBOM:       []string{"spdx", "cyclonedx"},
+							},
+						}
+					})
+
+					it("sets up the completion image to attest the image", func() {
+						pod, err := build.BuildPod(config, buildContext)
+						require.NoError(t, err)
+
+						require.Equal(t,
+							[]string{
+								"-basic-docker=docker-secret-1=acr.io",
+								"-dockerconfig=docker-secret-2",
+								"-dockercfg=docker-secret-3",
+								"-cosign-annotations=buildTimestamp=19440606.133000",
+								"-cosign-annotations=buildNumber=12",
+								"-cosign-attest-provenance",
+								"-builder-image=" + builderImage,
+								"-build-invocation-id=some-build-uid",
+								"-cosign-attest-sbom=spdx",
+								"-cosign-attest-sbom=cyclonedx",
+							},
+							pod.Spec.Containers[0].Args,
+						)
+
+						assert.Subset(t, pod.Spec.Containers[0].Env, []corev1.EnvVar{
+							{Name: "GIT_URL", Value: "giturl.com/git.git"},
+							{Name: "GIT_REVISION", Value: "gitrev1234"},
+							{Name: "BUILD_CHANGES", Value: "some-stack-change"},
+						})
+					})
+
+					it("does not provide the source without provenance", func() {
+						build.Spec.Cosign.Attestations.Provenance = false
+
+						pod, err := build.BuildPod(config, buildContext)
+						require.NoError(t, err)
+
+						assert.Empty(t, pod.Spec.Containers[0].Env)
+					})
+				})
+
 				when("a notary config is present on the build", func() {
 					it("sets up the completion image to sign the image", func() {
 						build.Spec.Notary = &corev1alpha1.NotaryConfig{
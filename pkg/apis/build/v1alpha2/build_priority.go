@@ -3,19 +3,31 @@ package v1alpha2
 type BuildPriority int
 
 var (
-	BuildPriorityNone      = BuildPriority(0)
-	BuildPriorityLow       = BuildPriority(1)
-	BuildPriorityHigh      = BuildPriority(1000)
-	BuildPriorityClassHigh = "kpack-build-high-priority"
-	BuildPriorityClassLow  = "kpack-build-low-priority"
+	BuildPriorityNone           = BuildPriority(0)
+	BuildPriorityLow            = BuildPriority(1)
+	BuildPriorityScheduled      = BuildPriority(100)
+	BuildPriorityHigh           = BuildPriority(1000)
+	BuildPriorityClassHigh      = "kpack-build-high-priority"
+	BuildPriorityClassScheduled = "kpack-build-scheduled-priority"
+	BuildPriorityClassLow       = "kpack-build-low-priority"
 )
 
 var PriorityClasses = map[BuildPriority]string{
-	BuildPriorityNone: "",
-	BuildPriorityLow:  BuildPriorityClassLow,
-	BuildPriorityHigh: BuildPriorityClassHigh,
+	BuildPriorityNone:      "",
+	BuildPriorityLow:       BuildPriorityClassLow,
+	BuildPriorityScheduled: BuildPriorityClassScheduled,
+	BuildPriorityHigh:      BuildPriorityClassHigh,
 }
 
 func (p BuildPriority) PriorityClass() string {
 	return PriorityClasses[p]
 }
+
+func PriorityForClass(priorityClass string) BuildPriority {
+	for priority, class := range PriorityClasses {
+		if class == priorityClass {
+			return priority
+		}
+	}
+	return BuildPriorityNone
+}
@@ -70,6 +70,8 @@ type BuildSpec struct {
 	RuntimeClassName  *string             `json:"runtimeClassName,omitempty"`
 	SchedulerName     string              `json:"schedulerName,omitempty"`
 	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	Timeout           *metav1.Duration    `json:"timeout,omitempty"`
+	Cancelled         bool                `json:"cancelled,omitempty"`
 }
 
 func (bs *BuildSpec) NeedVolumeCache() bool {
@@ -121,6 +123,7 @@ type BuildStatus struct {
 	LatestImage         string                             `json:"latestImage,omitempty"`
 	LatestCacheImage    string                             `json:"latestCacheImage,omitempty"`
 	PodName             string                             `json:"podName,omitempty"`
+	QueuePosition       int64                              `json:"queuePosition,omitempty"`
 	// +listType
 	StepStates []corev1.ContainerState `json:"stepStates,omitempty"`
 	// +listType
@@ -6,6 +6,7 @@ import (
 	"regexp"
 
 	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
 
@@ -35,6 +36,7 @@ func (bs *BuildSpec) Validate(ctx context.Context) *apis.FieldError {
 		Also(bs.validateImmutableFields(ctx)).
 		Also(validateCnbBindings(ctx, bs.CNBBindings).ViaField("cnbBindings")).
 		Also(bs.validateNodeSelector(ctx)).
+		Also(validateTimeout(bs.Timeout)).
 		Also(validateNotary(ctx, bs.Notary).ViaField("notary"))
 }
 
@@ -52,7 +54,18 @@ func (bs *BuildSpec) validateImmutableFields(ctx context.Context) *apis.FieldErr
 	}
 
 	original := apis.GetBaseline(ctx).(*Build)
-	if diff, err := kmp.ShortDiff(&original.Spec, bs); err != nil {
+	if original.Spec.Cancelled && !bs.Cancelled {
+		return apis.ErrGeneric("a cancelled build cannot be resumed", "cancelled")
+	}
+
+	// cancelled is the only field that may be changed on an existing build,
+	// apart from the default timeout the controller sets on builds without one
+	originalSpec := original.Spec.DeepCopy()
+	originalSpec.Cancelled = bs.Cancelled
+	if originalSpec.Timeout == nil {
+		originalSpec.Timeout = bs.Timeout
+	}
+	if diff, err := kmp.ShortDiff(originalSpec, bs); err != nil {
 		return &apis.FieldError{
 			Message: "Failed to diff Build",
 			Paths:   []string{"spec"},
@@ -68,6 +81,14 @@ func (bs *BuildSpec) validateImmutableFields(ctx context.Context) *apis.FieldErr
 	return nil
 }
 
+func validateTimeout(timeout *metav1.Duration) *apis.FieldError {
+	if timeout == nil || timeout.Duration > 0 {
+		return nil
+	}
+
+	return apis.ErrGeneric("timeout must be greater than 0", "timeout")
+}
+
 func (bs *BuildSpec) validateNodeSelector(_ context.Context) *apis.FieldError {
 	if len(bs.NodeSelector) == 0 {
 		return nil
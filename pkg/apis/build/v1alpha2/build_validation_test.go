@@ -3,6 +3,7 @@ package v1alpha2
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -108,7 +109,7 @@ func testBuildValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			build.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(build, context.TODO(), apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(build, context.TODO(), apis.ErrMissingOneOf("git", "blob", "registry", "s3", "custom").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
@@ -344,9 +345,47 @@ func testBuildValidation(t *testing.T, when spec.G, it spec.S) {
 
 		})
 
+		it("allows a build to be cancelled", func() {
+			original := build.DeepCopy()
+
+			build.Spec.Cancelled = true
+			assert.Nil(t, build.Validate(apis.WithinUpdate(context.TODO(), original)))
+		})
+
+		it("allows the timeout of a build without a timeout to be set", func() {
+			original := build.DeepCopy()
+			original.Spec.Timeout = nil
+
+			build.Spec.Timeout = &metav1.Duration{Duration: 10 * time.Minute}
+			assert.Nil(t, build.Validate(apis.WithinUpdate(context.TODO(), original)))
+		})
+
+		it("validates the timeout is immutable once set", func() {
+			build.Spec.Timeout = &metav1.Duration{Duration: 10 * time.Minute}
+			original := build.DeepCopy()
+
+			build.Spec.Timeout = &metav1.Duration{Duration: 20 * time.Minute}
+			err := build.Validate(apis.WithinUpdate(context.TODO(), original))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "Immutable fields changed")
+		})
+
+		it("validates a cancelled build is not resumed", func() {
+			build.Spec.Cancelled = true
+			original := build.DeepCopy()
+
+			build.Spec.Cancelled = false
+			assertValidationError(build, apis.WithinUpdate(context.TODO(), original), apis.ErrGeneric("a cancelled build cannot be resumed", "spec.cancelled"))
+		})
+
 		it("validates kubernetes.io/os node selector is unset", func() {
 			build.Spec.NodeSelector = map[string]string{k8sOSLabel: "some-os"}
 			assertValidationError(build, context.TODO(), apis.ErrInvalidKeyName(k8sOSLabel, "spec.nodeSelector", "os is determined automatically"))
 		})
+
+		it("validates timeout is positive", func() {
+			build.Spec.Timeout = &metav1.Duration{Duration: 0}
+			assertValidationError(build, context.TODO(), apis.ErrGeneric("timeout must be greater than 0", "spec.timeout"))
+		})
 	})
 }
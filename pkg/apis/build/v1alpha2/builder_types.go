@@ -29,7 +29,22 @@ type BuilderSpec struct {
 	Stack corev1.ObjectReference `json:"stack,omitempty"`
 	Store corev1.ObjectReference `json:"store,omitempty"`
 	// +listType
-	Order []corev1alpha1.OrderEntry `json:"order,omitempty"`
+	Order   []corev1alpha1.OrderEntry `json:"order,omitempty"`
+	Rollout *BuilderRollout           `json:"rollout,omitempty"`
+}
+
+// BuilderRollout staggers the rebuilds and rebases of Images triggered by an
+// update to the builder
+// +k8s:openapi-gen=true
+type BuilderRollout struct {
+	// BatchSize is the number of Images rebuilt at once. All Images are rebuilt in a single batch if unset
+	BatchSize int64 `json:"batchSize,omitempty"`
+	// PauseBetweenBatches is the time waited after a batch finishes before the next batch starts
+	PauseBetweenBatches *metav1.Duration `json:"pauseBetweenBatches,omitempty"`
+	// CanarySelector selects Images that are rebuilt in a first batch of their own
+	CanarySelector *metav1.LabelSelector `json:"canarySelector,omitempty"`
+	// MaxFailurePercentage halts the rollout when the percentage of failed builds exceeds it
+	MaxFailurePercentage *int64 `json:"maxFailurePercentage,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -49,6 +64,30 @@ type BuilderStatus struct {
 	ObservedStackGeneration int64                              `json:"observedStackGeneration,omitempty"`
 	ObservedStoreGeneration int64                              `json:"observedStoreGeneration,omitempty"`
 	OS                      string                             `json:"os,omitempty"`
+	Rollout                 *BuilderRolloutStatus              `json:"rollout,omitempty"`
+}
+
+const (
+	RolloutProgressing = "Progressing"
+	RolloutPaused      = "Paused"
+	RolloutHalted      = "Halted"
+	RolloutCompleted   = "Completed"
+)
+
+// +k8s:openapi-gen=true
+type BuilderRolloutStatus struct {
+	// Image is the builder image being rolled out
+	Image        string `json:"image,omitempty"`
+	Phase        string `json:"phase,omitempty"`
+	CurrentBatch int64  `json:"currentBatch,omitempty"`
+	Batches      int64  `json:"batches,omitempty"`
+	Images       int64  `json:"images,omitempty"`
+	Updated      int64  `json:"updated,omitempty"`
+	Failed       int64  `json:"failed,omitempty"`
+	Message      string `json:"message,omitempty"`
+	// AdmittedImages are the namespace/name of the Images the rollout allows to be rebuilt
+	// +listType
+	AdmittedImages []string `json:"admittedImages,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -4,9 +4,11 @@ import (
 	"context"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"knative.dev/pkg/apis"
 
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/apis/validate"
 )
 
@@ -27,38 +29,86 @@ func (cb *Builder) Validate(ctx context.Context) *apis.FieldError {
 }
 
 func (s *BuilderSpec) Validate(ctx context.Context) *apis.FieldError {
+	return s.validate(ctx).
+		Also(validateStack(s.Stack, ClusterStackKind).ViaField("stack")).
+		Also(validateStore(s.Store, ClusterStoreKind).ViaField("store"))
+}
+
+func (s *BuilderSpec) validate(ctx context.Context) *apis.FieldError {
 	return validate.Tag(s.Tag).
-		Also(validateStack(s.Stack).ViaField("stack")).
-		Also(validateStore(s.Store).ViaField("store"))
+		Also(validateOrder(s.Order)).
+		Also(s.Rollout.Validate(ctx).ViaField("rollout"))
+}
+
+func (r *BuilderRollout) Validate(context.Context) *apis.FieldError {
+	if r == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if r.BatchSize < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.BatchSize, "batchSize"))
+	}
+
+	if r.PauseBetweenBatches != nil && r.PauseBetweenBatches.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.PauseBetweenBatches.Duration.String(), "pauseBetweenBatches"))
+	}
+
+	if r.CanarySelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.CanarySelector); err != nil {
+			errs = errs.Also(apis.ErrGeneric(err.Error(), "canarySelector"))
+		}
+	}
+
+	if r.MaxFailurePercentage != nil && (*r.MaxFailurePercentage < 0 || *r.MaxFailurePercentage > 100) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*r.MaxFailurePercentage, 0, 100, "maxFailurePercentage"))
+	}
+
+	return errs
 }
 
 func (s *NamespacedBuilderSpec) Validate(ctx context.Context) *apis.FieldError {
-	return s.BuilderSpec.Validate(ctx).
+	return s.BuilderSpec.validate(ctx).
+		Also(validateStack(s.Stack, ClusterStackKind, StackKind).ViaField("stack")).
+		Also(validateStore(s.Store, ClusterStoreKind, StoreKind).ViaField("store")).
 		Also(validate.FieldNotEmpty(s.ServiceAccount(), "serviceAccountName"))
 }
 
-func validateStack(stack v1.ObjectReference) *apis.FieldError {
+func validateOrder(order []corev1alpha1.OrderEntry) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, entry := range order {
+		for j, buildpack := range entry.Group {
+			if buildpack.Image == "" {
+				continue
+			}
+
+			errs = errs.Also(validate.Image(buildpack.Image).ViaFieldIndex("group", j).ViaFieldIndex("order", i))
+		}
+	}
+	return errs
+}
+
+func validateStack(stack v1.ObjectReference, kinds ...string) *apis.FieldError {
 	if stack.Name == "" {
 		return apis.ErrMissingField("name")
 	}
 
-	switch stack.Kind {
-	case ClusterStackKind:
-		return nil
-	default:
-		return apis.ErrInvalidValue(stack.Kind, "kind")
-	}
+	return validateKind(stack.Kind, kinds)
 }
 
-func validateStore(store v1.ObjectReference) *apis.FieldError {
+func validateStore(store v1.ObjectReference, kinds ...string) *apis.FieldError {
 	if store.Name == "" {
 		return apis.ErrMissingField("name")
 	}
 
-	switch store.Kind {
-	case ClusterStoreKind:
-		return nil
-	default:
-		return apis.ErrInvalidValue(store.Kind, "kind")
+	return validateKind(store.Kind, kinds)
+}
+
+func validateKind(kind string, kinds []string) *apis.FieldError {
+	for _, k := range kinds {
+		if kind == k {
+			return nil
+		}
 	}
+	return apis.ErrInvalidValue(kind, "kind")
 }
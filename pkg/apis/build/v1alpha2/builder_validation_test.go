@@ -3,12 +3,15 @@ package v1alpha2
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
 func TestBuilderValidation(t *testing.T) {
@@ -103,6 +106,12 @@ func testBuilderValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(builder, apis.ErrInvalidValue("FakeStack", "kind").ViaField("spec", "stack"))
 		})
 
+		it("namespaced stack and store", func() {
+			builder.Spec.Stack.Kind = "Stack"
+			builder.Spec.Store.Kind = "Store"
+			assert.Nil(t, builder.Validate(context.TODO()))
+		})
+
 		it("missing store name", func() {
 			builder.Spec.Store.Name = ""
 			assertValidationError(builder, apis.ErrMissingField("name").ViaField("spec", "store"))
@@ -112,5 +121,70 @@ func testBuilderValidation(t *testing.T, when spec.G, it spec.S) {
 			builder.Spec.Store.Kind = "FakeStore"
 			assertValidationError(builder, apis.ErrInvalidValue("FakeStore", "kind").ViaField("spec", "store"))
 		})
+
+		it("valid buildpackage image in order", func() {
+			builder.Spec.Order = []corev1alpha1.OrderEntry{
+				{
+					Group: []corev1alpha1.BuildpackRef{
+						{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack"}},
+						{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "other-buildpack"}, Image: "some-registry.io/buildpackage:1.0"},
+					},
+				},
+			}
+			assert.Nil(t, builder.Validate(context.TODO()))
+		})
+
+		it("invalid buildpackage image in order", func() {
+			builder.Spec.Order = []corev1alpha1.OrderEntry{
+				{
+					Group: []corev1alpha1.BuildpackRef{
+						{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack"}},
+					},
+				},
+				{
+					Group: []corev1alpha1.BuildpackRef{
+						{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "some-buildpack"}},
+						{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "other-buildpack"}, Image: "ftp//invalid/image@@"},
+					},
+				},
+			}
+			assertValidationError(builder, apis.ErrInvalidValue("ftp//invalid/image@@", "image").ViaFieldIndex("group", 1).ViaFieldIndex("order", 1).ViaField("spec"))
+		})
+
+		it("valid rollout", func() {
+			maxFailures := int64(10)
+			builder.Spec.Rollout = &BuilderRollout{
+				BatchSize:            5,
+				PauseBetweenBatches:  &metav1.Duration{Duration: time.Minute},
+				CanarySelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				MaxFailurePercentage: &maxFailures,
+			}
+			assert.Nil(t, builder.Validate(context.TODO()))
+		})
+
+		it("negative rollout batch size", func() {
+			builder.Spec.Rollout = &BuilderRollout{BatchSize: -1}
+			assertValidationError(builder, apis.ErrInvalidValue(int64(-1), "batchSize").ViaField("spec", "rollout"))
+		})
+
+		it("negative rollout pause", func() {
+			builder.Spec.Rollout = &BuilderRollout{PauseBetweenBatches: &metav1.Duration{Duration: -time.Minute}}
+			assertValidationError(builder, apis.ErrInvalidValue("-1m0s", "pauseBetweenBatches").ViaField("spec", "rollout"))
+		})
+
+		it("invalid rollout canary selector", func() {
+			builder.Spec.Rollout = &BuilderRollout{
+				CanarySelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "canary", Operator: "Unknown"}},
+				},
+			}
+			assertValidationError(builder, apis.ErrGeneric(`"Unknown" is not a valid pod selector operator`, "canarySelector").ViaField("spec", "rollout"))
+		})
+
+		it("rollout failure percentage out of bounds", func() {
+			maxFailures := int64(101)
+			builder.Spec.Rollout = &BuilderRollout{MaxFailurePercentage: &maxFailures}
+			assertValidationError(builder, apis.ErrOutOfBoundsValue(int64(101), 0, 100, "maxFailurePercentage").ViaField("spec", "rollout"))
+		})
 	})
 }
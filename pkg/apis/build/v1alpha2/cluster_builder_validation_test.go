@@ -57,6 +57,16 @@ func testClusterBuilderValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(clusterBuilder, apis.ErrMissingField("name").ViaField("spec", "serviceAccountRef"))
 		})
 
+		it("namespaced stack", func() {
+			clusterBuilder.Spec.Stack.Kind = "Stack"
+			assertValidationError(clusterBuilder, apis.ErrInvalidValue("Stack", "kind").ViaField("stack"))
+		})
+
+		it("namespaced store", func() {
+			clusterBuilder.Spec.Store.Kind = "Store"
+			assertValidationError(clusterBuilder, apis.ErrInvalidValue("Store", "kind").ViaField("store"))
+		})
+
 		it("missing service account namespace", func() {
 			clusterBuilder.Spec.ServiceAccountRef.Namespace = ""
 			assertValidationError(clusterBuilder, apis.ErrMissingField("namespace").ViaField("spec", "serviceAccountRef"))
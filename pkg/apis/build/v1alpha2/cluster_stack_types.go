@@ -29,6 +29,7 @@ type ClusterStackSpec struct {
 	BuildImage        ClusterStackSpecImage   `json:"buildImage,omitempty"`
 	RunImage          ClusterStackSpecImage   `json:"runImage,omitempty"`
 	ServiceAccountRef *corev1.ObjectReference `json:"serviceAccountRef,omitempty"`
+	Verification      *VerificationPolicy     `json:"verification,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -57,6 +58,8 @@ type ResolvedClusterStack struct {
 type ClusterStackStatusImage struct {
 	LatestImage string `json:"latestImage,omitempty"`
 	Image       string `json:"image,omitempty"`
+	// PreviousImage is the digest the image resolved to before the LatestImage
+	PreviousImage string `json:"previousImage,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -25,9 +25,14 @@ func (ss *ClusterStackSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
-	return validate.FieldNotEmpty(ss.Id, "id").
-		Also(ss.BuildImage.Validate(ctx).ViaField("buildImage")).
-		Also(ss.RunImage.Validate(ctx).ViaField("runImage"))
+	return validateStackImages(ctx, ss.Id, ss.BuildImage, ss.RunImage).
+		Also(ss.Verification.Validate(ctx).ViaField("verification"))
+}
+
+func validateStackImages(ctx context.Context, id string, buildImage, runImage ClusterStackSpecImage) *apis.FieldError {
+	return validate.FieldNotEmpty(id, "id").
+		Also(buildImage.Validate(ctx).ViaField("buildImage")).
+		Also(runImage.Validate(ctx).ViaField("runImage"))
 }
 
 func (ssi *ClusterStackSpecImage) Validate(context.Context) *apis.FieldError {
@@ -84,5 +84,38 @@ func testClusterStackValidation(t *testing.T, when spec.G, it spec.S) {
 
 			assertValidationError(clusterStack, apis.ErrMissingField("name").ViaField("serviceAccountRef").ViaField("spec"))
 		})
+
+		it("handles a verification policy", func() {
+			clusterStack.Spec.Verification = &VerificationPolicy{
+				PublicKeys: []string{"-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE\n-----END PUBLIC KEY-----\n"},
+				Keyless: []KeylessIdentity{
+					{Issuer: "https://token.actions.githubusercontent.com", Subject: "https://github.com/some/stack/.github/workflows/release.yml@refs/heads/main"},
+				},
+			}
+
+			assert.Nil(t, clusterStack.Validate(context.TODO()))
+		})
+
+		it("verification policy requires a key or keyless identity", func() {
+			clusterStack.Spec.Verification = &VerificationPolicy{}
+
+			assertValidationError(clusterStack, apis.ErrMissingOneOf("publicKeys", "keyless").ViaField("verification").ViaField("spec"))
+		})
+
+		it("verification policy requires valid keys and identities", func() {
+			clusterStack.Spec.Verification = &VerificationPolicy{
+				PublicKeys: []string{"not-a-key"},
+				Keyless: []KeylessIdentity{
+					{Issuer: "not a url", Subject: "someone@example.com"},
+					{Issuer: "https://accounts.google.com"},
+				},
+			}
+
+			assertValidationError(clusterStack,
+				apis.ErrInvalidArrayValue("not a PEM encoded public key", "publicKeys", 0).
+					Also(apis.ErrInvalidValue("not a url", "issuer").ViaIndex(0).ViaField("keyless")).
+					Also(apis.ErrMissingField("subject").ViaIndex(1).ViaField("keyless")).
+					ViaField("verification").ViaField("spec"))
+		})
 	})
 }
@@ -28,6 +28,7 @@ type ClusterStoreSpec struct {
 	// +listType
 	Sources           []corev1alpha1.StoreImage `json:"sources,omitempty"`
 	ServiceAccountRef *corev1.ObjectReference   `json:"serviceAccountRef,omitempty"`
+	Verification      *VerificationPolicy       `json:"verification,omitempty"`
 }
 
 // +k8s:openapi-gen=true
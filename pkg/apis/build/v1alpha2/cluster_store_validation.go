@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"knative.dev/pkg/apis"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
 func (s *ClusterStore) SetDefaults(context.Context) {
@@ -24,11 +26,16 @@ func (s *ClusterStoreSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
-	if len(s.Sources) == 0 {
+	return validateSources(s.Sources).
+		Also(s.Verification.Validate(ctx).ViaField("verification"))
+}
+
+func validateSources(sources []corev1alpha1.StoreImage) *apis.FieldError {
+	if len(sources) == 0 {
 		return apis.ErrMissingField("sources")
 	}
 	var errors *apis.FieldError = nil
-	for i, source := range s.Sources {
+	for i, source := range sources {
 		_, err := name.ParseReference(source.Image, name.WeakValidation)
 		if err != nil {
 			//noinspection GoNilness
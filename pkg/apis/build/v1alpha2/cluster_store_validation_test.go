@@ -106,5 +106,21 @@ func testClusterStoreValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(clusterStore, apis.ErrMissingField("name").ViaField("serviceAccountRef").ViaField("spec"))
 		})
 
+		it("handles a verification policy", func() {
+			clusterStore.Spec.Verification = &VerificationPolicy{
+				Keyless: []KeylessIdentity{
+					{Issuer: "https://accounts.google.com", Subject: "buildpacks@example.com"},
+				},
+			}
+
+			assert.Nil(t, clusterStore.Validate(context.TODO()))
+		})
+
+		it("verification policy requires a key or keyless identity", func() {
+			clusterStore.Spec.Verification = &VerificationPolicy{}
+
+			assertValidationError(clusterStore, apis.ErrMissingOneOf("publicKeys", "keyless").ViaField("verification").ViaField("spec"))
+		})
+
 	})
 }
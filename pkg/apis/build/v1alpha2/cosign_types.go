@@ -1,9 +1,16 @@
 package v1alpha2
 
+const (
+	CosignSBOMFormatSPDX      = "spdx"
+	CosignSBOMFormatCycloneDX = "cyclonedx"
+)
+
 // +k8s:openapi-gen=true
 type CosignConfig struct {
 	// +listType
-	Annotations []CosignAnnotation `json:"annotations,omitempty"`
+	Annotations  []CosignAnnotation   `json:"annotations,omitempty"`
+	Keyless      *CosignKeylessConfig `json:"keyless,omitempty"`
+	Attestations *CosignAttestations  `json:"attestations,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -11,3 +18,25 @@ type CosignAnnotation struct {
 	Name  string `json:"name,omitempty"`
 	Value string `json:"value,omitempty"`
 }
+
+// CosignKeylessConfig signs images with a short lived certificate issued by
+// Fulcio to the identity of the build service account instead of a cosign.key
+// +k8s:openapi-gen=true
+type CosignKeylessConfig struct {
+	FulcioURL  string `json:"fulcioURL,omitempty"`
+	RekorURL   string `json:"rekorURL,omitempty"`
+	OIDCIssuer string `json:"oidcIssuer,omitempty"`
+	// Audience of the service account token presented to Fulcio
+	Audience string `json:"audience,omitempty"`
+}
+
+// CosignAttestations attaches signed in-toto attestations to the built image
+// with the same cosign keys or keyless identity used to sign it
+// +k8s:openapi-gen=true
+type CosignAttestations struct {
+	// Provenance attaches a SLSA provenance attestation describing the build
+	Provenance bool `json:"provenance,omitempty"`
+	// SBOM formats to attach from the bill of materials written by the lifecycle
+	// +listType
+	SBOM []string `json:"sbom,omitempty"`
+}
@@ -2,6 +2,7 @@ package v1alpha2
 
 import (
 	"context"
+	"net/url"
 
 	"knative.dev/pkg/apis"
 
@@ -19,9 +20,46 @@ func (c *CosignConfig) Validate(ctx context.Context) *apis.FieldError {
 		err = err.Also(item.Validate(ctx).ViaIndex(i).ViaField("annotations"))
 	}
 
+	return err.
+		Also(c.Keyless.Validate(ctx).ViaField("keyless")).
+		Also(c.Attestations.Validate(ctx).ViaField("attestations"))
+}
+
+func (a *CosignAttestations) Validate(ctx context.Context) *apis.FieldError {
+	if a == nil {
+		return nil
+	}
+
+	var err *apis.FieldError
+	for i, format := range a.SBOM {
+		if format != CosignSBOMFormatSPDX && format != CosignSBOMFormatCycloneDX {
+			err = err.Also(apis.ErrInvalidArrayValue(format, "sbom", i))
+		}
+	}
 	return err
 }
 
+func (k *CosignKeylessConfig) Validate(ctx context.Context) *apis.FieldError {
+	if k == nil {
+		return nil
+	}
+
+	return validateURL(k.FulcioURL, "fulcioURL").
+		Also(validateURL(k.RekorURL, "rekorURL")).
+		Also(validateURL(k.OIDCIssuer, "oidcIssuer"))
+}
+
+func validateURL(value, field string) *apis.FieldError {
+	if value == "" {
+		return nil
+	}
+
+	if _, err := url.ParseRequestURI(value); err != nil {
+		return apis.ErrInvalidValue(value, field)
+	}
+	return nil
+}
+
 func (c *CosignAnnotation) Validate(ctx context.Context) *apis.FieldError {
 	if c == nil {
 		return nil
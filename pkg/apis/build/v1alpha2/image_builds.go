@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmeta"
@@ -21,12 +22,19 @@ const (
 	BuildReasonAnnotation  = "image.kpack.io/reason"
 	BuildChangesAnnotation = "image.kpack.io/buildChanges"
 	BuildNeededAnnotation  = "image.kpack.io/additionalBuildNeeded"
+	BuildRetryAnnotation   = "image.kpack.io/retryAttempt"
 
 	BuildReasonConfig    = "CONFIG"
 	BuildReasonCommit    = "COMMIT"
 	BuildReasonBuildpack = "BUILDPACK"
 	BuildReasonStack     = "STACK"
 	BuildReasonTrigger   = "TRIGGER"
+	BuildReasonBlob      = "BLOB"
+	BuildReasonRegistry  = "REGISTRY"
+	BuildReasonS3        = "S3"
+	BuildReasonCustom    = "CUSTOM"
+	BuildReasonRetry     = "RETRY"
+	BuildReasonSchedule  = "SCHEDULE"
 )
 
 type BuildReason string
@@ -71,6 +79,7 @@ func (im *Image) Build(sourceResolver *SourceResolver, builder BuilderResource,
 			RuntimeClassName:      im.RuntimeClassName(),
 			SchedulerName:         im.SchedulerName(),
 			PriorityClassName:     priorityClass,
+			Timeout:               im.Timeout(),
 		},
 	}
 }
@@ -184,6 +193,34 @@ func (im *Image) SchedulerName() string {
 	return im.Spec.Build.SchedulerName
 }
 
+func (im *Image) RetryLimit() int64 {
+	if im.Spec.RetryLimit == nil {
+		return 0
+	}
+	return *im.Spec.RetryLimit
+}
+
+// NextScheduledRebuild returns the first time after the given time that the
+// image is scheduled to be rebuilt, or the zero time without a rebuild schedule
+func (im *Image) NextScheduledRebuild(after time.Time) (time.Time, error) {
+	if im.Spec.RebuildSchedule == "" {
+		return time.Time{}, nil
+	}
+
+	schedule, err := cron.ParseStandard(im.Spec.RebuildSchedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after), nil
+}
+
+func (im *Image) Timeout() *metav1.Duration {
+	if im.Spec.Build == nil {
+		return nil
+	}
+	return im.Spec.Build.Timeout
+}
+
 func (im *Image) CacheName() string {
 	return kmeta.ChildName(im.Name, "-cache")
 }
@@ -226,6 +263,7 @@ func (im *Image) SourceResolver() *SourceResolver {
 		Spec: SourceResolverSpec{
 			ServiceAccountName: im.Spec.ServiceAccountName,
 			Source:             im.Spec.Source,
+			Polling:            im.Spec.SourcePolling,
 		},
 	}
 }
@@ -2,6 +2,7 @@ package v1alpha2
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -125,6 +126,14 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(t, build.PriorityClassName(), "some-class")
 		})
 
+		it("sets the build timeout from the image", func() {
+			image.Spec.Build = &ImageBuild{Timeout: &metav1.Duration{Duration: 45 * time.Minute}}
+
+			build := image.Build(sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, &metav1.Duration{Duration: 45 * time.Minute}, build.Spec.Timeout)
+			assert.Equal(t, int64(2700), *build.ActiveDeadlineSeconds())
+		})
+
 		it("propagates image's annotations onto the build", func() {
 			build := image.Build(sourceResolver, builder, latestBuild, "some-reasons", "some-changes", 27, "")
 			assert.Equal(t, map[string]string{"annotation-key": "annotation-value", "image.kpack.io/buildChanges": "some-changes", "image.kpack.io/reason": "some-reasons"}, build.Annotations)
@@ -12,6 +12,11 @@ import (
 const (
 	BuilderNotFound = "BuilderNotFound"
 	BuilderNotReady = "BuilderNotReady"
+
+	SourceChangesFiltered = "SourceChangesFiltered"
+
+	BuilderRolloutPending = "BuilderRolloutPending"
+	BuildDeferred         = "BuildDeferred"
 )
 
 func (im *Image) BuilderNotFound() corev1alpha1.Conditions {
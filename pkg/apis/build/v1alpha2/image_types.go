@@ -47,12 +47,17 @@ type ImageSpec struct {
 	Cache                    *ImageCacheConfig                 `json:"cache,omitempty"`
 	FailedBuildHistoryLimit  *int64                            `json:"failedBuildHistoryLimit,omitempty"`
 	SuccessBuildHistoryLimit *int64                            `json:"successBuildHistoryLimit,omitempty"`
+	RetryLimit               *int64                            `json:"retryLimit,omitempty"`
 	ImageTaggingStrategy     corev1alpha1.ImageTaggingStrategy `json:"imageTaggingStrategy,omitempty"`
 	ProjectDescriptorPath    string                            `json:"projectDescriptorPath,omitempty"`
 	Build                    *ImageBuild                       `json:"build,omitempty"`
 	Notary                   *corev1alpha1.NotaryConfig        `json:"notary,omitempty"`
 	Cosign                   *CosignConfig                     `json:"cosign,omitempty"`
 	DefaultProcess           string                            `json:"defaultProcess,omitempty"`
+	SourcePolling            *SourcePollingConfig              `json:"sourcePolling,omitempty"`
+	CancelOutdatedBuilds     bool                              `json:"cancelOutdatedBuilds,omitempty"`
+	MaintenanceWindow        *MaintenanceWindow                `json:"maintenanceWindow,omitempty"`
+	RebuildSchedule          string                            `json:"rebuildSchedule,omitempty"`
 	// +listType
 	AdditionalTags []string `json:"additionalTags,omitempty"`
 }
@@ -72,6 +77,7 @@ type ImageBuild struct {
 	Affinity         *corev1.Affinity    `json:"affinity,omitempty"`
 	RuntimeClassName *string             `json:"runtimeClassName,omitempty"`
 	SchedulerName    string              `json:"schedulerName,omitempty"`
+	Timeout          *metav1.Duration    `json:"timeout,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -99,13 +105,21 @@ type ImageBuilder struct {
 // +k8s:openapi-gen=true
 type ImageStatus struct {
 	corev1alpha1.Status        `json:",inline"`
-	LatestBuildRef             string `json:"latestBuildRef,omitempty"`
-	LatestBuildImageGeneration int64  `json:"latestBuildImageGeneration,omitempty"`
-	LatestImage                string `json:"latestImage,omitempty"`
-	LatestStack                string `json:"latestStack,omitempty"`
-	BuildCounter               int64  `json:"buildCounter,omitempty"`
-	BuildCacheName             string `json:"buildCacheName,omitempty"`
-	LatestBuildReason          string `json:"latestBuildReason,omitempty"`
+	LatestBuildRef             string         `json:"latestBuildRef,omitempty"`
+	LatestBuildImageGeneration int64          `json:"latestBuildImageGeneration,omitempty"`
+	LatestImage                string         `json:"latestImage,omitempty"`
+	LatestStack                string         `json:"latestStack,omitempty"`
+	BuildCounter               int64          `json:"buildCounter,omitempty"`
+	BuildCacheName             string         `json:"buildCacheName,omitempty"`
+	LatestBuildReason          string         `json:"latestBuildReason,omitempty"`
+	DeferredBuild              *DeferredBuild `json:"deferredBuild,omitempty"`
+}
+
+// DeferredBuild is a build that is waiting for the next maintenance window
+// +k8s:openapi-gen=true
+type DeferredBuild struct {
+	Reason string      `json:"reason"`
+	Until  metav1.Time `json:"until"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
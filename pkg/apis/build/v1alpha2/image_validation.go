@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/robfig/cron/v3"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -89,7 +90,11 @@ func (is *ImageSpec) ValidateSpec(ctx context.Context) *apis.FieldError {
 		Also(is.validateVolumeCache(ctx)).
 		Also(validateNotary(ctx, is.Notary).ViaField("notary")).
 		Also(is.Cosign.Validate(ctx).ViaField("cosign")).
-		Also(is.validateBuildHistoryLimit())
+		Also(is.SourcePolling.Validate(ctx).ViaField("sourcePolling")).
+		Also(is.MaintenanceWindow.Validate(ctx).ViaField("maintenanceWindow")).
+		Also(is.validateRebuildSchedule()).
+		Also(is.validateBuildHistoryLimit()).
+		Also(is.validateRetryLimit())
 }
 
 func (is *ImageSpec) validateTag(ctx context.Context) *apis.FieldError {
@@ -165,7 +170,8 @@ func (ib *ImageBuild) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	return ib.Services.Validate(ctx).ViaField("services").
-		Also(validateCnbBindings(ctx, ib.CNBBindings).ViaField("cnbBindings"))
+		Also(validateCnbBindings(ctx, ib.CNBBindings).ViaField("cnbBindings")).
+		Also(validateTimeout(ib.Timeout))
 }
 
 func validateBuilder(builder v1.ObjectReference) *apis.FieldError {
@@ -194,6 +200,24 @@ func (is *ImageSpec) validateBuildHistoryLimit() *apis.FieldError {
 	return nil
 }
 
+func (is *ImageSpec) validateRetryLimit() *apis.FieldError {
+	if is.RetryLimit != nil && *is.RetryLimit < 0 {
+		return apis.ErrGeneric("retry limit must not be negative", "retryLimit")
+	}
+	return nil
+}
+
+func (is *ImageSpec) validateRebuildSchedule() *apis.FieldError {
+	if is.RebuildSchedule == "" {
+		return nil
+	}
+
+	if _, err := cron.ParseStandard(is.RebuildSchedule); err != nil {
+		return apis.ErrInvalidValue(is.RebuildSchedule, "rebuildSchedule")
+	}
+	return nil
+}
+
 func (c *ImageCacheConfig) Validate(context context.Context) *apis.FieldError {
 	if c != nil && c.Volume != nil && c.Registry != nil {
 		return apis.ErrGeneric("only one type of cache can be specified", "volume", "registry")
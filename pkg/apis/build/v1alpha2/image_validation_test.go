@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -210,7 +211,7 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			image.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry", "s3", "custom").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
@@ -231,6 +232,41 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(image, ctx, apis.ErrMissingField("revision").ViaField("spec", "source", "git"))
 		})
 
+		it("validates blob sha256 is a hex encoded digest", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.Blob = &corev1alpha1.Blob{
+				URL:    "https://some-blobstore.example.com/some-blob",
+				Sha256: "not-a-digest",
+			}
+
+			assertValidationError(image, ctx, apis.ErrInvalidValue("not-a-digest", "sha256").ViaField("spec", "source", "blob"))
+		})
+
+		it("validates blob auth secret name", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.Blob = &corev1alpha1.Blob{
+				URL:  "https://some-blobstore.example.com/some-blob",
+				Auth: &corev1alpha1.BlobAuth{},
+			}
+
+			assertValidationError(image, ctx, apis.ErrMissingField("name").ViaField("spec", "source", "blob", "auth", "secretRef"))
+		})
+
+		it("validates git path filter patterns are not empty", func() {
+			image.Spec.Source.Git.PathFilter = &corev1alpha1.GitPathFilter{
+				Include: []string{"apps/web/", ""},
+				Exclude: []string{""},
+			}
+
+			assertValidationError(image, ctx, apis.ErrMissingField("include[1]", "exclude[0]").ViaField("spec", "source", "git", "pathFilter"))
+		})
+
+		it("validates git depth is not negative", func() {
+			image.Spec.Source.Git.Depth = -1
+
+			assertValidationError(image, ctx, apis.ErrInvalidValue(int64(-1), "depth").ViaField("spec", "source", "git"))
+		})
+
 		it("validates blob url", func() {
 			image.Spec.Source.Git = nil
 			image.Spec.Source.Blob = &corev1alpha1.Blob{URL: ""}
@@ -238,6 +274,56 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(image, ctx, apis.ErrMissingField("url").ViaField("spec", "source", "blob"))
 		})
 
+		it("validates s3 bucket and object", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.S3 = &corev1alpha1.S3{}
+
+			assertValidationError(image, ctx, apis.ErrMissingField("bucket").
+				Also(apis.ErrMissingOneOf("key", "prefix")).
+				ViaField("spec", "source", "s3"))
+		})
+
+		it("validates s3 key and prefix are not both set", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.S3 = &corev1alpha1.S3{
+				Bucket: "some-bucket",
+				Key:    "some-key.tar.gz",
+				Prefix: "some-prefix/",
+			}
+
+			assertValidationError(image, ctx, apis.ErrMultipleOneOf("key", "prefix").ViaField("spec", "source", "s3"))
+		})
+
+		it("validates s3 endpoint is a url", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.S3 = &corev1alpha1.S3{
+				Bucket:   "some-bucket",
+				Key:      "some-key.tar.gz",
+				Endpoint: "minio.example.com:9000",
+			}
+
+			assertValidationError(image, ctx, apis.ErrInvalidValue("minio.example.com:9000", "endpoint").ViaField("spec", "source", "s3"))
+		})
+
+		it("validates s3 credentials secret name", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.S3 = &corev1alpha1.S3{
+				Bucket:      "some-bucket",
+				Prefix:      "some-prefix/",
+				Endpoint:    "https://minio.example.com:9000",
+				Credentials: &corev1alpha1.S3Credentials{},
+			}
+
+			assertValidationError(image, ctx, apis.ErrMissingField("name").ViaField("spec", "source", "s3", "credentials", "secretRef"))
+		})
+
+		it("validates custom source kind", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.Custom = &corev1alpha1.Custom{}
+
+			assertValidationError(image, ctx, apis.ErrMissingField("kind").ViaField("spec", "source", "custom"))
+		})
+
 		it("validates registry image exists", func() {
 			image.Spec.Source.Git = nil
 			image.Spec.Source.Registry = &corev1alpha1.Registry{Image: ""}
@@ -285,6 +371,13 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 
 		})
 
+		it("retryLimit is negative", func() {
+			invalidLimit := int64(-1)
+
+			image.Spec.RetryLimit = &invalidLimit
+			assertValidationError(image, ctx, apis.ErrGeneric("retry limit must not be negative", "spec.retryLimit"))
+		})
+
 		it("validates cache size is not set when there is no default StorageClass", func() {
 			ctx = context.TODO()
 
@@ -363,6 +456,143 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 				err := image.Validate(ctx)
 				assert.EqualError(t, err, "missing field(s): spec.cosign.annotations[0].name, spec.cosign.annotations[1].value")
 			})
+
+			it("handles keyless signing", func() {
+				image.Spec.Cosign = &CosignConfig{
+					Keyless: &CosignKeylessConfig{
+						FulcioURL:  "https://fulcio.example.com",
+						RekorURL:   "https://rekor.example.com",
+						OIDCIssuer: "https://kubernetes.default.svc",
+						Audience:   "sigstore",
+					},
+				}
+				assert.Nil(t, image.Validate(ctx))
+			})
+
+			it("handles keyless signing with the default endpoints", func() {
+				image.Spec.Cosign = &CosignConfig{
+					Keyless: &CosignKeylessConfig{},
+				}
+				assert.Nil(t, image.Validate(ctx))
+			})
+
+			it("errors on invalid keyless urls", func() {
+				image.Spec.Cosign = &CosignConfig{
+					Keyless: &CosignKeylessConfig{
+						FulcioURL: "not a url",
+						RekorURL:  "https://rekor.example.com",
+					},
+				}
+
+				err := image.Validate(ctx)
+				assert.EqualError(t, err, "invalid value: not a url: spec.cosign.keyless.fulcioURL")
+			})
+
+			it("handles attestations", func() {
+				image.Spec.Cosign = &CosignConfig{
+					Attestations: &CosignAttestations{
+						Provenance: true,
+						SBOM:       []string{"spdx", "cyclonedx"},
+					},
+				}
+				assert.Nil(t, image.Validate(ctx))
+			})
+
+			it("errors on unknown sbom formats", func() {
+				image.Spec.Cosign = &CosignConfig{
+					Attestations: &CosignAttestations{
+						SBOM: []string{"spdx", "syft"},
+					},
+				}
+
+				err := image.Validate(ctx)
+				assert.EqualError(t, err, "invalid value: syft: spec.cosign.attestations.sbom[1]")
+			})
+		})
+
+		when("validating the source polling config", func() {
+			it("handles a polling interval", func() {
+				image.Spec.SourcePolling = &SourcePollingConfig{
+					Interval: &metav1.Duration{Duration: 5 * time.Minute},
+				}
+				assert.Nil(t, image.Validate(ctx))
+			})
+
+			it("handles disabled polling", func() {
+				image.Spec.SourcePolling = &SourcePollingConfig{
+					Disabled: true,
+				}
+				assert.Nil(t, image.Validate(ctx))
+			})
+
+			it("errors on a non positive interval", func() {
+				image.Spec.SourcePolling = &SourcePollingConfig{
+					Interval: &metav1.Duration{Duration: -1 * time.Minute},
+				}
+				assert.EqualError(t, image.Validate(ctx), "invalid value: -1m0s: spec.sourcePolling.interval")
+			})
+
+			it("errors on an interval when polling is disabled", func() {
+				image.Spec.SourcePolling = &SourcePollingConfig{
+					Interval: &metav1.Duration{Duration: 5 * time.Minute},
+					Disabled: true,
+				}
+				assert.EqualError(t, image.Validate(ctx), "interval cannot be set when polling is disabled: spec.sourcePolling.interval")
+			})
+		})
+
+		when("rebuild schedule", func() {
+			it("handles a valid rebuild schedule", func() {
+				image.Spec.RebuildSchedule = "0 3 * * 0"
+				assert.Nil(t, image.Validate(ctx))
+			})
+
+			it("errors on an invalid rebuild schedule", func() {
+				image.Spec.RebuildSchedule = "weekly"
+				assert.EqualError(t, image.Validate(ctx), "invalid value: weekly: spec.rebuildSchedule")
+			})
+		})
+
+		when("maintenance window", func() {
+			it("handles a valid maintenance window", func() {
+				image.Spec.MaintenanceWindow = &MaintenanceWindow{
+					Schedule: "0 2 * * 6",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+					TimeZone: "Europe/Berlin",
+				}
+				assert.Nil(t, image.Validate(ctx))
+			})
+
+			it("errors on a missing schedule", func() {
+				image.Spec.MaintenanceWindow = &MaintenanceWindow{
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+				}
+				assert.EqualError(t, image.Validate(ctx), "missing field(s): spec.maintenanceWindow.schedule")
+			})
+
+			it("errors on an invalid schedule", func() {
+				image.Spec.MaintenanceWindow = &MaintenanceWindow{
+					Schedule: "every saturday",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+				}
+				assert.EqualError(t, image.Validate(ctx), "invalid value: every saturday: spec.maintenanceWindow.schedule")
+			})
+
+			it("errors on a non positive duration", func() {
+				image.Spec.MaintenanceWindow = &MaintenanceWindow{
+					Schedule: "0 2 * * 6",
+				}
+				assert.EqualError(t, image.Validate(ctx), "invalid value: 0s: spec.maintenanceWindow.duration")
+			})
+
+			it("errors on an unknown timezone", func() {
+				image.Spec.MaintenanceWindow = &MaintenanceWindow{
+					Schedule: "0 2 * * 6",
+					Duration: metav1.Duration{Duration: 4 * time.Hour},
+					TimeZone: "Mars/Olympus_Mons",
+				}
+				assert.EqualError(t, image.Validate(ctx), "invalid value: Mars/Olympus_Mons: spec.maintenanceWindow.timeZone")
+			})
 		})
 
 		it("image.cacheSize has not changed when storageclass is not expandable", func() {
@@ -517,5 +747,10 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			image.Spec.Build.NodeSelector = map[string]string{k8sOSLabel: "some-os"}
 			assertValidationError(image, ctx, apis.ErrInvalidKeyName(k8sOSLabel, "spec.build.nodeSelector", "os is determined automatically"))
 		})
+
+		it("validates build timeout is positive", func() {
+			image.Spec.Build.Timeout = &metav1.Duration{Duration: -time.Minute}
+			assertValidationError(image, ctx, apis.ErrGeneric("timeout must be greater than 0", "spec.build.timeout"))
+		})
 	})
 }
@@ -0,0 +1,85 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	MaintenanceWindowScheduleAnnotation = "kpack.io/maintenance-window-schedule"
+	MaintenanceWindowDurationAnnotation = "kpack.io/maintenance-window-duration"
+	MaintenanceWindowTimeZoneAnnotation = "kpack.io/maintenance-window-timezone"
+)
+
+// MaintenanceWindow restricts builds that are not initiated by a user, such as
+// builds for a stack or buildpack update, to recurring periods of time
+// +k8s:openapi-gen=true
+type MaintenanceWindow struct {
+	// Schedule is a standard five field cron expression for the start of each window
+	Schedule string `json:"schedule"`
+	// Duration is how long each window stays open
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone the schedule is evaluated in. Defaults to UTC
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// NextOpening returns the start of the next window, or the zero time if a
+// window is open at the given time or no window is configured
+func (w *MaintenanceWindow) NextOpening(now time.Time) (time.Time, error) {
+	if w == nil {
+		return time.Time{}, nil
+	}
+
+	schedule, err := w.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// the first start after (now - duration) is at or before now if a window is open
+	start := schedule.Next(now.Add(-w.Duration.Duration))
+	if !start.After(now) {
+		return time.Time{}, nil
+	}
+	return start, nil
+}
+
+func (w *MaintenanceWindow) parse() (cron.Schedule, error) {
+	timeZone := w.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, err
+	}
+
+	return cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s", timeZone, w.Schedule))
+}
+
+// MaintenanceWindowFromAnnotations reads the maintenance window configured on
+// a namespace. It returns nil if no schedule is set.
+func MaintenanceWindowFromAnnotations(annotations map[string]string) (*MaintenanceWindow, error) {
+	schedule, ok := annotations[MaintenanceWindowScheduleAnnotation]
+	if !ok || schedule == "" {
+		return nil, nil
+	}
+
+	duration, err := time.ParseDuration(annotations[MaintenanceWindowDurationAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", MaintenanceWindowDurationAnnotation, err)
+	}
+
+	window := &MaintenanceWindow{
+		Schedule: schedule,
+		Duration: metav1.Duration{Duration: duration},
+		TimeZone: annotations[MaintenanceWindowTimeZoneAnnotation],
+	}
+	if err := window.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
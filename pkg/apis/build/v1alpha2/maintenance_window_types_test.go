@@ -0,0 +1,96 @@
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	spec.Run(t, "Maintenance Window", testMaintenanceWindow)
+}
+
+func testMaintenanceWindow(t *testing.T, when spec.G, it spec.S) {
+	// saturdays from 02:00 to 06:00
+	window := &MaintenanceWindow{
+		Schedule: "0 2 * * 6",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}
+
+	when("NextOpening", func() {
+		it("returns the start of the next window when the window is closed", func() {
+			friday := time.Date(2022, time.March, 18, 12, 0, 0, 0, time.UTC)
+
+			opening, err := window.NextOpening(friday)
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2022, time.March, 19, 2, 0, 0, 0, time.UTC), opening.UTC())
+		})
+
+		it("returns the zero time when the window is open", func() {
+			saturday := time.Date(2022, time.March, 19, 3, 0, 0, 0, time.UTC)
+
+			opening, err := window.NextOpening(saturday)
+			require.NoError(t, err)
+			assert.True(t, opening.IsZero())
+		})
+
+		it("returns the next window once the window has closed", func() {
+			saturday := time.Date(2022, time.March, 19, 6, 0, 0, 0, time.UTC)
+
+			opening, err := window.NextOpening(saturday)
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2022, time.March, 26, 2, 0, 0, 0, time.UTC), opening.UTC())
+		})
+
+		it("evaluates the schedule in the timezone", func() {
+			window.TimeZone = "America/New_York"
+			saturday := time.Date(2022, time.March, 19, 3, 0, 0, 0, time.UTC)
+
+			opening, err := window.NextOpening(saturday)
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2022, time.March, 19, 6, 0, 0, 0, time.UTC), opening.UTC())
+		})
+
+		it("returns the zero time without a window", func() {
+			var window *MaintenanceWindow
+
+			opening, err := window.NextOpening(time.Now())
+			require.NoError(t, err)
+			assert.True(t, opening.IsZero())
+		})
+	})
+
+	when("MaintenanceWindowFromAnnotations", func() {
+		it("reads the window from annotations", func() {
+			window, err := MaintenanceWindowFromAnnotations(map[string]string{
+				MaintenanceWindowScheduleAnnotation: "0 2 * * 6",
+				MaintenanceWindowDurationAnnotation: "4h",
+				MaintenanceWindowTimeZoneAnnotation: "Europe/Berlin",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, &MaintenanceWindow{
+				Schedule: "0 2 * * 6",
+				Duration: metav1.Duration{Duration: 4 * time.Hour},
+				TimeZone: "Europe/Berlin",
+			}, window)
+		})
+
+		it("returns nil without a schedule annotation", func() {
+			window, err := MaintenanceWindowFromAnnotations(map[string]string{"some": "annotation"})
+			require.NoError(t, err)
+			assert.Nil(t, window)
+		})
+
+		it("errors on an invalid duration", func() {
+			_, err := MaintenanceWindowFromAnnotations(map[string]string{
+				MaintenanceWindowScheduleAnnotation: "0 2 * * 6",
+				MaintenanceWindowDurationAnnotation: "a while",
+			})
+			assert.EqualError(t, err, `invalid kpack.io/maintenance-window-duration: time: invalid duration "a while"`)
+		})
+	})
+}
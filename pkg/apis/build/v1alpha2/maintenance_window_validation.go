@@ -0,0 +1,33 @@
+package v1alpha2
+
+import (
+	"context"
+	"time"
+
+	"knative.dev/pkg/apis"
+)
+
+func (w *MaintenanceWindow) Validate(ctx context.Context) *apis.FieldError {
+	if w == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if w.Duration.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(w.Duration.Duration.String(), "duration"))
+	}
+
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			return errs.Also(apis.ErrInvalidValue(w.TimeZone, "timeZone"))
+		}
+	}
+
+	if w.Schedule == "" {
+		errs = errs.Also(apis.ErrMissingField("schedule"))
+	} else if _, err := w.parse(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(w.Schedule, "schedule"))
+	}
+
+	return errs
+}
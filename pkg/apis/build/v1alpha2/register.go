@@ -57,6 +57,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterStackList{},
 		&ClusterStore{},
 		&ClusterStoreList{},
+		&Stack{},
+		&StackList{},
+		&Store{},
+		&StoreList{},
 		&ClusterBuilder{},
 		&ClusterBuilderList{},
 		&Builder{},
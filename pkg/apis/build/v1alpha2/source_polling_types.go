@@ -0,0 +1,15 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:openapi-gen=true
+type SourcePollingConfig struct {
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	Disabled bool             `json:"disabled,omitempty"`
+}
+
+func (c *SourcePollingConfig) IsDisabled() bool {
+	return c != nil && c.Disabled
+}
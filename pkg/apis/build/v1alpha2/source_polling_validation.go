@@ -0,0 +1,23 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+func (c *SourcePollingConfig) Validate(ctx context.Context) *apis.FieldError {
+	if c == nil || c.Interval == nil {
+		return nil
+	}
+
+	if c.Disabled {
+		return apis.ErrGeneric("interval cannot be set when polling is disabled", "interval")
+	}
+
+	if c.Interval.Duration <= 0 {
+		return apis.ErrInvalidValue(c.Interval.Duration.String(), "interval")
+	}
+
+	return nil
+}
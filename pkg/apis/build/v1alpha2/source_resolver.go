@@ -1,12 +1,36 @@
 package v1alpha2
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
-const ActivePolling = "ActivePolling"
+const (
+	ActivePolling   = "ActivePolling"
+	PollingDisabled = "PollingDisabled"
+
+	// LatestVersionResolved is false when the latest version of the source could not be
+	// resolved and the source resolver falls back to a previously resolved version.
+	LatestVersionResolved = "LatestVersionResolved"
+	ResolveFailed         = "ResolveFailed"
+)
+
+// ResolveFallbackError is returned by a source resolver together with a fallback resolution of
+// the source, such as its previous version, when the latest version could not be resolved.
+type ResolveFallbackError struct {
+	Err error
+}
+
+func (e *ResolveFallbackError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ResolveFallbackError) Unwrap() error {
+	return e.Err
+}
 
 func (sr *SourceResolver) ResolvedSource(config corev1alpha1.ResolvedSourceConfig) {
 	resolvedSource := config.ResolvedSource()
@@ -22,16 +46,52 @@ func (sr *SourceResolver) ResolvedSource(config corev1alpha1.ResolvedSourceConfi
 		Status: corev1.ConditionTrue,
 	}}
 
-	pollingStatus := corev1.ConditionFalse
+	pollingCondition := corev1alpha1.Condition{
+		Type:   ActivePolling,
+		Status: corev1.ConditionFalse,
+	}
 	if resolvedSource.IsPollable() {
-		pollingStatus = corev1.ConditionTrue
+		if sr.Spec.Polling.IsDisabled() {
+			pollingCondition.Reason = PollingDisabled
+		} else {
+			pollingCondition.Status = corev1.ConditionTrue
+		}
 	}
-	sr.Status.Conditions = append(sr.Status.Conditions, corev1alpha1.Condition{
-		Type:   ActivePolling,
-		Status: pollingStatus,
+	sr.Status.Conditions = append(sr.Status.Conditions, pollingCondition)
+
+	if !pollingCondition.IsTrue() {
+		sr.Status.LastPollTime = nil
+		sr.Status.NextPollTime = nil
+	}
+}
+
+// ResolvedFallbackSource records the fallback resolution of a source and the error that
+// prevented resolving its latest version.
+func (sr *SourceResolver) ResolvedFallbackSource(config corev1alpha1.ResolvedSourceConfig, err error) {
+	sr.ResolvedSource(config)
+
+	var conditions corev1alpha1.Conditions
+	for _, condition := range sr.Status.Conditions {
+		if condition.Type != LatestVersionResolved {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	sr.Status.Conditions = append(conditions, corev1alpha1.Condition{
+		Type:    LatestVersionResolved,
+		Status:  corev1.ConditionFalse,
+		Reason:  ResolveFailed,
+		Message: err.Error(),
 	})
 }
 
+func (sr *SourceResolver) PollingInterval(defaultInterval time.Duration) time.Duration {
+	if sr.Spec.Polling == nil || sr.Spec.Polling.Interval == nil {
+		return defaultInterval
+	}
+	return sr.Spec.Polling.Interval.Duration
+}
+
 func (sr *SourceResolver) PollingReady() bool {
 	return sr.Status.GetCondition(ActivePolling).IsTrue()
 }
@@ -53,6 +113,14 @@ func (sr SourceResolver) IsRegistry() bool {
 	return sr.Spec.Source.Registry != nil
 }
 
+func (sr SourceResolver) IsS3() bool {
+	return sr.Spec.Source.S3 != nil
+}
+
+func (sr SourceResolver) IsCustom() bool {
+	return sr.Spec.Source.Custom != nil
+}
+
 func (st *SourceResolver) SourceConfig() corev1alpha1.SourceConfig {
 	return st.Status.Source.ResolvedSource().SourceConfig()
 }
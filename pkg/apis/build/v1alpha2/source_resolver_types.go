@@ -22,12 +22,18 @@ type SourceResolver struct {
 type SourceResolverSpec struct {
 	ServiceAccountName string                    `json:"serviceAccount,omitempty"`
 	Source             corev1alpha1.SourceConfig `json:"source"`
+	Polling            *SourcePollingConfig      `json:"polling,omitempty"`
+	// BuiltRevision is the git revision of the latest build of the image. Git path
+	// filtering only resolves a new revision when it has changes since this revision.
+	BuiltRevision string `json:"builtRevision,omitempty"`
 }
 
 // +k8s:openapi-gen=true
 type SourceResolverStatus struct {
 	corev1alpha1.Status `json:",inline"`
 	Source              corev1alpha1.ResolvedSourceConfig `json:"source,omitempty"`
+	LastPollTime        *metav1.Time                      `json:"lastPollTime,omitempty"`
+	NextPollTime        *metav1.Time                      `json:"nextPollTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
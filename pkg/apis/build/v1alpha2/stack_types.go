@@ -0,0 +1,55 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const StackKind = "Stack"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object,k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMetaAccessor
+
+// +k8s:openapi-gen=true
+type Stack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StackSpec          `json:"spec"`
+	Status ClusterStackStatus `json:"status"`
+}
+
+// +k8s:openapi-gen=true
+type StackSpec struct {
+	Id                 string                `json:"id,omitempty"`
+	BuildImage         ClusterStackSpecImage `json:"buildImage,omitempty"`
+	RunImage           ClusterStackSpecImage `json:"runImage,omitempty"`
+	ServiceAccountName string                `json:"serviceAccountName,omitempty"`
+	Verification       *VerificationPolicy   `json:"verification,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type StackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// +k8s:listType=atomic
+	Items []Stack `json:"items"`
+}
+
+func (*Stack) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(StackKind)
+}
+
+// ClusterStackSpec returns the images of the stack in the form read by the
+// cluster stack reader.
+func (s *StackSpec) ClusterStackSpec() ClusterStackSpec {
+	return ClusterStackSpec{
+		Id:           s.Id,
+		BuildImage:   s.BuildImage,
+		RunImage:     s.RunImage,
+		Verification: s.Verification,
+	}
+}
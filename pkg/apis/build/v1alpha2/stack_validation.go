@@ -0,0 +1,22 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+func (s *Stack) SetDefaults(context.Context) {
+	if s.Spec.ServiceAccountName == "" {
+		s.Spec.ServiceAccountName = "default"
+	}
+}
+
+func (s *Stack) Validate(ctx context.Context) *apis.FieldError {
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (ss *StackSpec) Validate(ctx context.Context) *apis.FieldError {
+	return validateStackImages(ctx, ss.Id, ss.BuildImage, ss.RunImage).
+		Also(ss.Verification.Validate(ctx).ViaField("verification"))
+}
@@ -0,0 +1,45 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const StoreKind = "Store"
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object,k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMetaAccessor
+
+// +k8s:openapi-gen=true
+type Store struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StoreSpec          `json:"spec"`
+	Status ClusterStoreStatus `json:"status"`
+}
+
+// +k8s:openapi-gen=true
+type StoreSpec struct {
+	// +listType
+	Sources            []corev1alpha1.StoreImage `json:"sources,omitempty"`
+	ServiceAccountName string                    `json:"serviceAccountName,omitempty"`
+	Verification       *VerificationPolicy       `json:"verification,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type StoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// +k8s:listType=atomic
+	Items []Store `json:"items"`
+}
+
+func (*Store) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(StoreKind)
+}
@@ -0,0 +1,22 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+func (s *Store) SetDefaults(context.Context) {
+	if s.Spec.ServiceAccountName == "" {
+		s.Spec.ServiceAccountName = "default"
+	}
+}
+
+func (s *Store) Validate(ctx context.Context) *apis.FieldError {
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (s *StoreSpec) Validate(ctx context.Context) *apis.FieldError {
+	return validateSources(s.Sources).
+		Also(s.Verification.Validate(ctx).ViaField("verification"))
+}
@@ -0,0 +1,65 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestStoreValidation(t *testing.T) {
+	spec.Run(t, "Store Validation", testStoreValidation)
+}
+
+func testStoreValidation(t *testing.T, when spec.G, it spec.S) {
+	store := &Store{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "store-name",
+			Namespace: "store-namespace",
+		},
+		Spec: StoreSpec{
+			Sources: []corev1alpha1.StoreImage{
+				{
+					Image: "some-registry.io/store-image-1@sha256:78c1b9419976227e05be9d243b7fa583bea44a5258e52018b2af4cdfe23d148d",
+				},
+			},
+			ServiceAccountName: "some-service-account",
+		},
+	}
+
+	when("Default", func() {
+		it("does not modify already set fields", func() {
+			oldStore := store.DeepCopy()
+			store.SetDefaults(context.TODO())
+
+			assert.Equal(t, store, oldStore)
+		})
+
+		it("defaults service account to default", func() {
+			store.Spec.ServiceAccountName = ""
+			store.SetDefaults(context.TODO())
+			assert.Equal(t, "default", store.Spec.ServiceAccountName)
+		})
+	})
+
+	when("Validate", func() {
+		it("returns nil on no validation error", func() {
+			assert.Nil(t, store.Validate(context.TODO()))
+		})
+
+		it("missing sources", func() {
+			store.Spec.Sources = nil
+			assert.EqualError(t, store.Validate(context.TODO()), apis.ErrMissingField("sources").ViaField("spec").Error())
+		})
+
+		it("invalid source image", func() {
+			store.Spec.Sources = append(store.Spec.Sources, corev1alpha1.StoreImage{Image: "ftp//invalid/image@@"})
+			assert.EqualError(t, store.Validate(context.TODO()), apis.ErrInvalidArrayValue(store.Spec.Sources[1], "sources", 1).ViaField("spec").Error())
+		})
+	})
+}
@@ -0,0 +1,21 @@
+package v1alpha2
+
+// VerificationPolicy only allows images with a cosign signature from one of
+// the public keys or keyless identities
+// +k8s:openapi-gen=true
+type VerificationPolicy struct {
+	// PEM encoded cosign public keys
+	// +listType
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// +listType
+	Keyless []KeylessIdentity `json:"keyless,omitempty"`
+}
+
+// KeylessIdentity is the identity a Fulcio certificate was issued to
+// +k8s:openapi-gen=true
+type KeylessIdentity struct {
+	// Issuer of the OIDC token used to sign
+	Issuer string `json:"issuer"`
+	// Subject is the email or uri the certificate was issued to
+	Subject string `json:"subject"`
+}
@@ -0,0 +1,38 @@
+package v1alpha2
+
+import (
+	"context"
+	"encoding/pem"
+
+	"knative.dev/pkg/apis"
+
+	"github.com/pivotal/kpack/pkg/apis/validate"
+)
+
+func (p *VerificationPolicy) Validate(ctx context.Context) *apis.FieldError {
+	if p == nil {
+		return nil
+	}
+
+	if len(p.PublicKeys) == 0 && len(p.Keyless) == 0 {
+		return apis.ErrMissingOneOf("publicKeys", "keyless")
+	}
+
+	var err *apis.FieldError
+	for i, publicKey := range p.PublicKeys {
+		if block, _ := pem.Decode([]byte(publicKey)); block == nil {
+			err = err.Also(apis.ErrInvalidArrayValue("not a PEM encoded public key", "publicKeys", i))
+		}
+	}
+
+	for i, identity := range p.Keyless {
+		err = err.Also(identity.Validate(ctx).ViaIndex(i).ViaField("keyless"))
+	}
+	return err
+}
+
+func (k *KeylessIdentity) Validate(context.Context) *apis.FieldError {
+	return validate.FieldNotEmpty(k.Subject, "subject").
+		Also(validate.FieldNotEmpty(k.Issuer, "issuer")).
+		Also(validateURL(k.Issuer, "issuer"))
+}
@@ -258,6 +258,11 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -420,6 +425,58 @@ func (in *BuilderRecord) DeepCopy() *BuilderRecord {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuilderRollout) DeepCopyInto(out *BuilderRollout) {
+	*out = *in
+	if in.PauseBetweenBatches != nil {
+		in, out := &in.PauseBetweenBatches, &out.PauseBetweenBatches
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CanarySelector != nil {
+		in, out := &in.CanarySelector, &out.CanarySelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxFailurePercentage != nil {
+		in, out := &in.MaxFailurePercentage, &out.MaxFailurePercentage
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderRollout.
+func (in *BuilderRollout) DeepCopy() *BuilderRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(BuilderRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuilderRolloutStatus) DeepCopyInto(out *BuilderRolloutStatus) {
+	*out = *in
+	if in.AdmittedImages != nil {
+		in, out := &in.AdmittedImages, &out.AdmittedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuilderRolloutStatus.
+func (in *BuilderRolloutStatus) DeepCopy() *BuilderRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuilderRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuilderSpec) DeepCopyInto(out *BuilderSpec) {
 	*out = *in
@@ -432,6 +489,11 @@ func (in *BuilderSpec) DeepCopyInto(out *BuilderSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(BuilderRollout)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -462,6 +524,11 @@ func (in *BuilderStatus) DeepCopyInto(out *BuilderStatus) {
 		}
 	}
 	out.Stack = in.Stack
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(BuilderRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -641,6 +708,11 @@ func (in *ClusterStackSpec) DeepCopyInto(out *ClusterStackSpec) {
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -786,6 +858,11 @@ func (in *ClusterStoreSpec) DeepCopyInto(out *ClusterStoreSpec) {
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -839,6 +916,27 @@ func (in *CosignAnnotation) DeepCopy() *CosignAnnotation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CosignAttestations) DeepCopyInto(out *CosignAttestations) {
+	*out = *in
+	if in.SBOM != nil {
+		in, out := &in.SBOM, &out.SBOM
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CosignAttestations.
+func (in *CosignAttestations) DeepCopy() *CosignAttestations {
+	if in == nil {
+		return nil
+	}
+	out := new(CosignAttestations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CosignConfig) DeepCopyInto(out *CosignConfig) {
 	*out = *in
@@ -847,6 +945,16 @@ func (in *CosignConfig) DeepCopyInto(out *CosignConfig) {
 		*out = make([]CosignAnnotation, len(*in))
 		copy(*out, *in)
 	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(CosignKeylessConfig)
+		**out = **in
+	}
+	if in.Attestations != nil {
+		in, out := &in.Attestations, &out.Attestations
+		*out = new(CosignAttestations)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -860,6 +968,39 @@ func (in *CosignConfig) DeepCopy() *CosignConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CosignKeylessConfig) DeepCopyInto(out *CosignKeylessConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CosignKeylessConfig.
+func (in *CosignKeylessConfig) DeepCopy() *CosignKeylessConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CosignKeylessConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredBuild) DeepCopyInto(out *DeferredBuild) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredBuild.
+func (in *DeferredBuild) DeepCopy() *DeferredBuild {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredBuild)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
@@ -935,6 +1076,11 @@ func (in *ImageBuild) DeepCopyInto(out *ImageBuild) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1065,6 +1211,11 @@ func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.RetryLimit != nil {
+		in, out := &in.RetryLimit, &out.RetryLimit
+		*out = new(int64)
+		**out = **in
+	}
 	if in.Build != nil {
 		in, out := &in.Build, &out.Build
 		*out = new(ImageBuild)
@@ -1080,6 +1231,16 @@ func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 		*out = new(CosignConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SourcePolling != nil {
+		in, out := &in.SourcePolling, &out.SourcePolling
+		*out = new(SourcePollingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 	if in.AdditionalTags != nil {
 		in, out := &in.AdditionalTags, &out.AdditionalTags
 		*out = make([]string, len(*in))
@@ -1102,6 +1263,11 @@ func (in *ImageSpec) DeepCopy() *ImageSpec {
 func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.DeferredBuild != nil {
+		in, out := &in.DeferredBuild, &out.DeferredBuild
+		*out = new(DeferredBuild)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1115,6 +1281,22 @@ func (in *ImageStatus) DeepCopy() *ImageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeylessIdentity) DeepCopyInto(out *KeylessIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeylessIdentity.
+func (in *KeylessIdentity) DeepCopy() *KeylessIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(KeylessIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LastBuild) DeepCopyInto(out *LastBuild) {
 	*out = *in
@@ -1132,6 +1314,23 @@ func (in *LastBuild) DeepCopy() *LastBuild {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedBuilderSpec) DeepCopyInto(out *NamespacedBuilderSpec) {
 	*out = *in
@@ -1208,6 +1407,27 @@ func (in Services) DeepCopy() Services {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourcePollingConfig) DeepCopyInto(out *SourcePollingConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourcePollingConfig.
+func (in *SourcePollingConfig) DeepCopy() *SourcePollingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SourcePollingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceResolver) DeepCopyInto(out *SourceResolver) {
 	*out = *in
@@ -1273,6 +1493,11 @@ func (in *SourceResolverList) DeepCopyObject() runtime.Object {
 func (in *SourceResolverSpec) DeepCopyInto(out *SourceResolverSpec) {
 	*out = *in
 	in.Source.DeepCopyInto(&out.Source)
+	if in.Polling != nil {
+		in, out := &in.Polling, &out.Polling
+		*out = new(SourcePollingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1291,6 +1516,14 @@ func (in *SourceResolverStatus) DeepCopyInto(out *SourceResolverStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
 	in.Source.DeepCopyInto(&out.Source)
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextPollTime != nil {
+		in, out := &in.NextPollTime, &out.NextPollTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1303,3 +1536,216 @@ func (in *SourceResolverStatus) DeepCopy() *SourceResolverStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Stack) DeepCopyInto(out *Stack) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Stack.
+func (in *Stack) DeepCopy() *Stack {
+	if in == nil {
+		return nil
+	}
+	out := new(Stack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObjectMetaAccessor is an autogenerated deepcopy function, copying the receiver, creating a new metav1.ObjectMetaAccessor.
+func (in *Stack) DeepCopyObjectMetaAccessor() metav1.ObjectMetaAccessor {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Stack) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackList) DeepCopyInto(out *StackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Stack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackList.
+func (in *StackList) DeepCopy() *StackList {
+	if in == nil {
+		return nil
+	}
+	out := new(StackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StackSpec) DeepCopyInto(out *StackSpec) {
+	*out = *in
+	out.BuildImage = in.BuildImage
+	out.RunImage = in.RunImage
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StackSpec.
+func (in *StackSpec) DeepCopy() *StackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Store) DeepCopyInto(out *Store) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Store.
+func (in *Store) DeepCopy() *Store {
+	if in == nil {
+		return nil
+	}
+	out := new(Store)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObjectMetaAccessor is an autogenerated deepcopy function, copying the receiver, creating a new metav1.ObjectMetaAccessor.
+func (in *Store) DeepCopyObjectMetaAccessor() metav1.ObjectMetaAccessor {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Store) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreList) DeepCopyInto(out *StoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Store, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreList.
+func (in *StoreList) DeepCopy() *StoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoreSpec) DeepCopyInto(out *StoreSpec) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]v1alpha1.StoreImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoreSpec.
+func (in *StoreSpec) DeepCopy() *StoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationPolicy) DeepCopyInto(out *VerificationPolicy) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = make([]KeylessIdentity, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationPolicy.
+func (in *VerificationPolicy) DeepCopy() *VerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -39,6 +39,8 @@ type OrderEntry struct {
 type BuildpackRef struct {
 	BuildpackInfo `json:",inline"`
 	Optional      bool `json:"optional,omitempty"`
+	// Image is a buildpackage to read the buildpack from instead of the ClusterStore
+	Image string `json:"image,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -1,6 +1,11 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -10,6 +15,8 @@ type SourceConfig struct {
 	Git      *Git      `json:"git,omitempty"`
 	Blob     *Blob     `json:"blob,omitempty"`
 	Registry *Registry `json:"registry,omitempty"`
+	S3       *S3       `json:"s3,omitempty"`
+	Custom   *Custom   `json:"custom,omitempty"`
 	SubPath  string    `json:"subPath,omitempty"`
 }
 
@@ -20,10 +27,20 @@ func (sc *SourceConfig) Source() Source {
 		return sc.Blob
 	} else if sc.Registry != nil {
 		return sc.Registry
+	} else if sc.S3 != nil {
+		return sc.S3
+	} else if sc.Custom != nil {
+		return sc.Custom
 	}
 	return nil
 }
 
+// GitPathFiltered reports whether new git revisions are only a source change when they touch
+// the path filter.
+func (sc *SourceConfig) GitPathFiltered() bool {
+	return sc.Git != nil && sc.Git.PathFilter != nil
+}
+
 type Source interface {
 	BuildEnvVars() []corev1.EnvVar
 	ImagePullSecretsVolume(name string) corev1.Volume
@@ -32,12 +49,33 @@ type Source interface {
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type Git struct {
-	URL      string `json:"url"`
-	Revision string `json:"revision"`
+	URL        string         `json:"url"`
+	Revision   string         `json:"revision"`
+	PathFilter *GitPathFilter `json:"pathFilter,omitempty"`
+	// Submodules recursively initializes and checks out the submodules of the repository.
+	Submodules bool `json:"submodules,omitempty"`
+	// LFS downloads the Git LFS objects referenced by the checked out revision.
+	LFS bool `json:"lfs,omitempty"`
+	// Depth limits the history fetched by builds to the given number of commits. The complete
+	// history is fetched when it is not set.
+	Depth int64 `json:"depth,omitempty"`
+}
+
+// GitPathFilter limits the commits that are considered a source change to
+// those that touch the included paths. Patterns use gitignore syntax and are
+// relative to the repository root. If no include patterns are provided the
+// subPath of the source is included.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type GitPathFilter struct {
+	// +listType
+	Include []string `json:"include,omitempty"`
+	// +listType
+	Exclude []string `json:"exclude,omitempty"`
 }
 
 func (g *Git) BuildEnvVars() []corev1.EnvVar {
-	return []corev1.EnvVar{
+	envVars := []corev1.EnvVar{
 		{
 			Name:  "GIT_URL",
 			Value: g.URL,
@@ -47,6 +85,29 @@ func (g *Git) BuildEnvVars() []corev1.EnvVar {
 			Value: g.Revision,
 		},
 	}
+
+	if g.Submodules {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GIT_SUBMODULES",
+			Value: "true",
+		})
+	}
+
+	if g.LFS {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GIT_LFS",
+			Value: "true",
+		})
+	}
+
+	if g.Depth > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GIT_DEPTH",
+			Value: strconv.FormatInt(g.Depth, 10),
+		})
+	}
+
+	return envVars
 }
 
 func (in *Git) ImagePullSecretsVolume(name string) corev1.Volume {
@@ -62,6 +123,20 @@ func (in *Git) ImagePullSecretsVolume(name string) corev1.Volume {
 // +k8s:deepcopy-gen=true
 type Blob struct {
 	URL string `json:"url"`
+	// Sha256 is the expected hex encoded sha256 digest of the blob.
+	Sha256 string    `json:"sha256,omitempty"`
+	Auth   *BlobAuth `json:"auth,omitempty"`
+	// Version is the resolved version of the blob the build was created from.
+	Version string `json:"version,omitempty"`
+}
+
+// BlobAuth references a secret used to authenticate blob downloads. The
+// secret may contain a bearer `token`, a `username` and `password` for basic
+// auth, or custom headers in keys prefixed with `header.`.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type BlobAuth struct {
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
 }
 
 func (b *Blob) ImagePullSecretsVolume(name string) corev1.Volume {
@@ -74,12 +149,21 @@ func (b *Blob) ImagePullSecretsVolume(name string) corev1.Volume {
 }
 
 func (b *Blob) BuildEnvVars() []corev1.EnvVar {
-	return []corev1.EnvVar{
+	envVars := []corev1.EnvVar{
 		{
 			Name:  "BLOB_URL",
 			Value: b.URL,
 		},
 	}
+
+	if b.Sha256 != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "BLOB_SHA256",
+			Value: b.Sha256,
+		})
+	}
+
+	return envVars
 }
 
 // +k8s:openapi-gen=true
@@ -90,6 +174,8 @@ type Registry struct {
 	// +patchStrategy=merge
 	// +listType
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,15,rep,name=imagePullSecrets"`
+	// Digest is the resolved digest of the image the build was created from.
+	Digest string `json:"digest,omitempty"`
 }
 
 func (r *Registry) ImagePullSecretsVolume(name string) corev1.Volume {
@@ -116,7 +202,167 @@ func (r *Registry) BuildEnvVars() []corev1.EnvVar {
 	return []corev1.EnvVar{
 		{
 			Name:  "REGISTRY_IMAGE",
-			Value: r.Image,
+			Value: r.resolvedImage(),
+		},
+	}
+}
+
+// resolvedImage pins the image to the resolved digest so that the build
+// fetches the same image that was resolved even if the tag has moved since.
+func (r *Registry) resolvedImage() string {
+	if r.Digest == "" {
+		return r.Image
+	}
+
+	ref, err := name.ParseReference(r.Image, name.WeakValidation)
+	if err != nil {
+		return r.Image
+	}
+
+	if _, ok := ref.(name.Digest); ok {
+		return r.Image
+	}
+
+	return ref.Context().Digest(r.Digest).Name()
+}
+
+// S3 is a source code archive in an S3 compatible object store.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type S3 struct {
+	Bucket string `json:"bucket"`
+	// Key is the key of the archive. Exactly one of key or prefix must be set.
+	Key string `json:"key,omitempty"`
+	// Prefix selects the most recently modified archive with a key starting
+	// with the prefix.
+	Prefix string `json:"prefix,omitempty"`
+	// Endpoint is the url of an S3 compatible object store. Defaults to AWS S3.
+	Endpoint    string         `json:"endpoint,omitempty"`
+	Region      string         `json:"region,omitempty"`
+	Credentials *S3Credentials `json:"credentials,omitempty"`
+	// Object is the resolved key of the archive the build was created from.
+	Object string `json:"object,omitempty"`
+	// Version is the resolved version of the archive the build was created from.
+	Version string `json:"version,omitempty"`
+}
+
+// S3Credentials references a secret containing an `accessKeyId`, a
+// `secretAccessKey`, and optionally a `sessionToken`.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type S3Credentials struct {
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+func (s *S3) ImagePullSecretsVolume(name string) corev1.Volume {
+	if s.Credentials != nil {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: s.Credentials.SecretRef.Name,
+				},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func (s *S3) BuildEnvVars() []corev1.EnvVar {
+	key := s.Object
+	if key == "" {
+		key = s.Key
+	}
+
+	envVars := []corev1.EnvVar{
+		{
+			Name:  "S3_BUCKET",
+			Value: s.Bucket,
+		},
+		{
+			Name:  "S3_KEY",
+			Value: key,
+		},
+	}
+
+	if s.Endpoint != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "S3_ENDPOINT",
+			Value: s.Endpoint,
+		})
+	}
+
+	if s.Region != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "S3_REGION",
+			Value: s.Region,
+		})
+	}
+
+	if s.Version != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "S3_VERSION",
+			Value: s.Version,
+		})
+	}
+
+	return envVars
+}
+
+// CustomSourceTokenAudience is the audience of the service account tokens
+// presented to the source resolvers of custom sources.
+const CustomSourceTokenAudience = "kpack-custom-source-resolver"
+
+// Custom is a source that is resolved and fetched by an external source
+// resolver registered for its kind.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type Custom struct {
+	// Kind selects the registered source resolver.
+	Kind string `json:"kind"`
+	// Config is passed to the source resolver unmodified.
+	Config map[string]string `json:"config,omitempty"`
+	// Endpoint is the url of the source resolver the build was resolved with.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Version is the resolved version of the source the build was created from.
+	Version string `json:"version,omitempty"`
+}
+
+func (c *Custom) ImagePullSecretsVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func (c *Custom) BuildEnvVars() []corev1.EnvVar {
+	// the config is a map of strings and cannot fail to marshal
+	config, _ := json.Marshal(c.Config)
+
+	return []corev1.EnvVar{
+		{
+			Name:  "CUSTOM_SOURCE_KIND",
+			Value: c.Kind,
+		},
+		{
+			Name:  "CUSTOM_SOURCE_CONFIG",
+			Value: string(config),
+		},
+		{
+			Name:  "CUSTOM_SOURCE_ENDPOINT",
+			Value: c.Endpoint,
+		},
+		{
+			Name:  "CUSTOM_SOURCE_VERSION",
+			Value: c.Version,
 		},
 	}
 }
@@ -127,6 +373,8 @@ type ResolvedSourceConfig struct {
 	Git      *ResolvedGitSource      `json:"git,omitempty"`
 	Blob     *ResolvedBlobSource     `json:"blob,omitempty"`
 	Registry *ResolvedRegistrySource `json:"registry,omitempty"`
+	S3       *ResolvedS3Source       `json:"s3,omitempty"`
+	Custom   *ResolvedCustomSource   `json:"custom,omitempty"`
 }
 
 func (sc ResolvedSourceConfig) ResolvedSource() ResolvedSource {
@@ -136,6 +384,10 @@ func (sc ResolvedSourceConfig) ResolvedSource() ResolvedSource {
 		return sc.Blob
 	} else if sc.Registry != nil {
 		return sc.Registry
+	} else if sc.S3 != nil {
+		return sc.S3
+	} else if sc.Custom != nil {
+		return sc.Custom
 	}
 	return nil
 }
@@ -162,13 +414,23 @@ type ResolvedGitSource struct {
 	Revision string        `json:"revision"`
 	SubPath  string        `json:"subPath,omitempty"`
 	Type     GitSourceKind `json:"type"`
+	// LatestRevision is the most recent revision of the branch or tag when
+	// it only contains changes outside the path filter of the source since
+	// the revision of the latest build
+	LatestRevision string `json:"latestRevision,omitempty"`
+	Submodules     bool   `json:"submodules,omitempty"`
+	LFS            bool   `json:"lfs,omitempty"`
+	Depth          int64  `json:"depth,omitempty"`
 }
 
 func (gs *ResolvedGitSource) SourceConfig() SourceConfig {
 	return SourceConfig{
 		Git: &Git{
-			URL:      gs.URL,
-			Revision: gs.Revision,
+			URL:        gs.URL,
+			Revision:   gs.Revision,
+			Submodules: gs.Submodules,
+			LFS:        gs.LFS,
+			Depth:      gs.Depth,
 		},
 		SubPath: gs.SubPath,
 	}
@@ -182,17 +444,29 @@ func (gs *ResolvedGitSource) IsPollable() bool {
 	return gs.Type != Commit && gs.Type != Unknown
 }
 
+func (gs *ResolvedGitSource) IsFiltered() bool {
+	return gs.LatestRevision != "" && gs.LatestRevision != gs.Revision
+}
+
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedBlobSource struct {
-	URL     string `json:"url"`
+	URL    string    `json:"url"`
+	Sha256 string    `json:"sha256,omitempty"`
+	Auth   *BlobAuth `json:"auth,omitempty"`
+	// Version identifies the content of the blob by its ETag, Last-Modified
+	// time, or sha256 digest.
+	Version string `json:"version,omitempty"`
 	SubPath string `json:"subPath,omitempty"`
 }
 
 func (bs *ResolvedBlobSource) SourceConfig() SourceConfig {
 	return SourceConfig{
 		Blob: &Blob{
-			URL: bs.URL,
+			URL:     bs.URL,
+			Sha256:  bs.Sha256,
+			Auth:    bs.Auth,
+			Version: bs.Version,
 		},
 		SubPath: bs.SubPath,
 	}
@@ -203,13 +477,15 @@ func (bs *ResolvedBlobSource) IsUnknown() bool {
 }
 
 func (bs *ResolvedBlobSource) IsPollable() bool {
-	return false
+	return bs.Version != "" && bs.Sha256 == ""
 }
 
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedRegistrySource struct {
-	Image   string `json:"image"`
+	Image string `json:"image"`
+	// Digest is the digest the image resolved to.
+	Digest  string `json:"digest,omitempty"`
 	SubPath string `json:"subPath,omitempty"`
 	// +patchMergeKey=name
 	// +patchStrategy=merge
@@ -222,6 +498,7 @@ func (rs *ResolvedRegistrySource) SourceConfig() SourceConfig {
 		Registry: &Registry{
 			Image:            rs.Image,
 			ImagePullSecrets: rs.ImagePullSecrets,
+			Digest:           rs.Digest,
 		},
 		SubPath: rs.SubPath,
 	}
@@ -231,6 +508,83 @@ func (rs *ResolvedRegistrySource) IsUnknown() bool {
 	return false
 }
 
+// IsPollable is true when the image is referenced by a tag that may be moved
+// to a different digest.
 func (rs *ResolvedRegistrySource) IsPollable() bool {
+	return rs.Digest != "" && !strings.Contains(rs.Image, "@")
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type ResolvedS3Source struct {
+	Bucket      string         `json:"bucket"`
+	Key         string         `json:"key,omitempty"`
+	Prefix      string         `json:"prefix,omitempty"`
+	Endpoint    string         `json:"endpoint,omitempty"`
+	Region      string         `json:"region,omitempty"`
+	Credentials *S3Credentials `json:"credentials,omitempty"`
+	// Object is the key of the archive the source resolved to.
+	Object string `json:"object,omitempty"`
+	// Version identifies the content of the archive by its version id, or
+	// its ETag in buckets without versioning.
+	Version string `json:"version,omitempty"`
+	SubPath string `json:"subPath,omitempty"`
+}
+
+func (ss *ResolvedS3Source) SourceConfig() SourceConfig {
+	return SourceConfig{
+		S3: &S3{
+			Bucket:      ss.Bucket,
+			Key:         ss.Key,
+			Prefix:      ss.Prefix,
+			Endpoint:    ss.Endpoint,
+			Region:      ss.Region,
+			Credentials: ss.Credentials,
+			Object:      ss.Object,
+			Version:     ss.Version,
+		},
+		SubPath: ss.SubPath,
+	}
+}
+
+func (ss *ResolvedS3Source) IsUnknown() bool {
 	return false
 }
+
+func (ss *ResolvedS3Source) IsPollable() bool {
+	return ss.Version != ""
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type ResolvedCustomSource struct {
+	Kind     string            `json:"kind"`
+	Config   map[string]string `json:"config,omitempty"`
+	Endpoint string            `json:"endpoint,omitempty"`
+	// Version is the opaque version of the source reported by the source resolver.
+	Version string `json:"version,omitempty"`
+	// Pollable is reported by the source resolver when the version of the
+	// source may change.
+	Pollable bool   `json:"pollable,omitempty"`
+	SubPath  string `json:"subPath,omitempty"`
+}
+
+func (cs *ResolvedCustomSource) SourceConfig() SourceConfig {
+	return SourceConfig{
+		Custom: &Custom{
+			Kind:     cs.Kind,
+			Config:   cs.Config,
+			Endpoint: cs.Endpoint,
+			Version:  cs.Version,
+		},
+		SubPath: cs.SubPath,
+	}
+}
+
+func (cs *ResolvedCustomSource) IsUnknown() bool {
+	return false
+}
+
+func (cs *ResolvedCustomSource) IsPollable() bool {
+	return cs.Pollable
+}
@@ -2,6 +2,9 @@ package v1alpha1
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
 
 	"knative.dev/pkg/apis"
 
@@ -9,7 +12,7 @@ import (
 )
 
 func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
-	sources := make([]string, 0, 3)
+	sources := make([]string, 0, 5)
 	if s.Git != nil {
 		sources = append(sources, "git")
 	}
@@ -19,9 +22,15 @@ func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
 	if s.Registry != nil {
 		sources = append(sources, "registry")
 	}
+	if s.S3 != nil {
+		sources = append(sources, "s3")
+	}
+	if s.Custom != nil {
+		sources = append(sources, "custom")
+	}
 
 	if len(sources) == 0 {
-		return apis.ErrMissingOneOf("git", "blob", "registry")
+		return apis.ErrMissingOneOf("git", "blob", "registry", "s3", "custom")
 	}
 
 	if len(sources) != 1 {
@@ -30,7 +39,9 @@ func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
 
 	return (s.Git.Validate(ctx).ViaField("git")).
 		Also(s.Blob.Validate(ctx).ViaField("blob")).
-		Also(s.Registry.Validate(ctx).ViaField("registry"))
+		Also(s.Registry.Validate(ctx).ViaField("registry")).
+		Also(s.S3.Validate(ctx).ViaField("s3")).
+		Also(s.Custom.Validate(ctx).ViaField("custom"))
 }
 
 func (g *Git) Validate(ctx context.Context) *apis.FieldError {
@@ -39,7 +50,31 @@ func (g *Git) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	return validate.FieldNotEmpty(g.URL, "url").
-		Also(validate.FieldNotEmpty(g.Revision, "revision"))
+		Also(validate.FieldNotEmpty(g.Revision, "revision")).
+		Also(g.PathFilter.Validate(ctx).ViaField("pathFilter")).
+		Also(validateGitDepth(g.Depth))
+}
+
+func validateGitDepth(depth int64) *apis.FieldError {
+	if depth < 0 {
+		return apis.ErrInvalidValue(depth, "depth")
+	}
+	return nil
+}
+
+func (f *GitPathFilter) Validate(ctx context.Context) *apis.FieldError {
+	if f == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	for i, pattern := range f.Include {
+		errs = errs.Also(validate.FieldNotEmpty(pattern, apis.CurrentField).ViaFieldIndex("include", i))
+	}
+	for i, pattern := range f.Exclude {
+		errs = errs.Also(validate.FieldNotEmpty(pattern, apis.CurrentField).ViaFieldIndex("exclude", i))
+	}
+	return errs
 }
 
 func (b *Blob) Validate(ctx context.Context) *apis.FieldError {
@@ -47,7 +82,28 @@ func (b *Blob) Validate(ctx context.Context) *apis.FieldError {
 		return nil
 	}
 
-	return validate.FieldNotEmpty(b.URL, "url")
+	return validate.FieldNotEmpty(b.URL, "url").
+		Also(validateSha256(b.Sha256).ViaField("sha256")).
+		Also(b.Auth.Validate(ctx).ViaField("auth"))
+}
+
+func (a *BlobAuth) Validate(ctx context.Context) *apis.FieldError {
+	if a == nil {
+		return nil
+	}
+
+	return validate.FieldNotEmpty(a.SecretRef.Name, "name").ViaField("secretRef")
+}
+
+func validateSha256(digest string) *apis.FieldError {
+	if digest == "" {
+		return nil
+	}
+
+	if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha256.Size*2 {
+		return apis.ErrInvalidValue(digest, apis.CurrentField)
+	}
+	return nil
 }
 
 func (r *Registry) Validate(ctx context.Context) *apis.FieldError {
@@ -57,3 +113,53 @@ func (r *Registry) Validate(ctx context.Context) *apis.FieldError {
 
 	return validate.Image(r.Image)
 }
+
+func (s *S3) Validate(ctx context.Context) *apis.FieldError {
+	if s == nil {
+		return nil
+	}
+
+	return validate.FieldNotEmpty(s.Bucket, "bucket").
+		Also(validateS3Object(s.Key, s.Prefix)).
+		Also(validateEndpoint(s.Endpoint).ViaField("endpoint")).
+		Also(s.Credentials.Validate(ctx).ViaField("credentials"))
+}
+
+func (c *S3Credentials) Validate(ctx context.Context) *apis.FieldError {
+	if c == nil {
+		return nil
+	}
+
+	return validate.FieldNotEmpty(c.SecretRef.Name, "name").ViaField("secretRef")
+}
+
+func validateS3Object(key, prefix string) *apis.FieldError {
+	if key == "" && prefix == "" {
+		return apis.ErrMissingOneOf("key", "prefix")
+	}
+
+	if key != "" && prefix != "" {
+		return apis.ErrMultipleOneOf("key", "prefix")
+	}
+	return nil
+}
+
+func validateEndpoint(endpoint string) *apis.FieldError {
+	if endpoint == "" {
+		return nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apis.ErrInvalidValue(endpoint, apis.CurrentField)
+	}
+	return nil
+}
+
+func (c *Custom) Validate(ctx context.Context) *apis.FieldError {
+	if c == nil {
+		return nil
+	}
+
+	return validate.FieldNotEmpty(c.Kind, "kind")
+}
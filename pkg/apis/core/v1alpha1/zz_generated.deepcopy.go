@@ -28,6 +28,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Blob) DeepCopyInto(out *Blob) {
 	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(BlobAuth)
+		**out = **in
+	}
 	return
 }
 
@@ -41,6 +46,23 @@ func (in *Blob) DeepCopy() *Blob {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlobAuth) DeepCopyInto(out *BlobAuth) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobAuth.
+func (in *BlobAuth) DeepCopy() *BlobAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildBuilderSpec) DeepCopyInto(out *BuildBuilderSpec) {
 	*out = *in
@@ -229,9 +251,37 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Custom) DeepCopyInto(out *Custom) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Custom.
+func (in *Custom) DeepCopy() *Custom {
+	if in == nil {
+		return nil
+	}
+	out := new(Custom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Git) DeepCopyInto(out *Git) {
 	*out = *in
+	if in.PathFilter != nil {
+		in, out := &in.PathFilter, &out.PathFilter
+		*out = new(GitPathFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -245,6 +295,32 @@ func (in *Git) DeepCopy() *Git {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPathFilter) DeepCopyInto(out *GitPathFilter) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPathFilter.
+func (in *GitPathFilter) DeepCopy() *GitPathFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPathFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotaryConfig) DeepCopyInto(out *NotaryConfig) {
 	*out = *in
@@ -344,6 +420,11 @@ func (in *Registry) DeepCopy() *Registry {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedBlobSource) DeepCopyInto(out *ResolvedBlobSource) {
 	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(BlobAuth)
+		**out = **in
+	}
 	return
 }
 
@@ -357,6 +438,29 @@ func (in *ResolvedBlobSource) DeepCopy() *ResolvedBlobSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedCustomSource) DeepCopyInto(out *ResolvedCustomSource) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedCustomSource.
+func (in *ResolvedCustomSource) DeepCopy() *ResolvedCustomSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedCustomSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedGitSource) DeepCopyInto(out *ResolvedGitSource) {
 	*out = *in
@@ -394,6 +498,27 @@ func (in *ResolvedRegistrySource) DeepCopy() *ResolvedRegistrySource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedS3Source) DeepCopyInto(out *ResolvedS3Source) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(S3Credentials)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedS3Source.
+func (in *ResolvedS3Source) DeepCopy() *ResolvedS3Source {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedS3Source)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedSourceConfig) DeepCopyInto(out *ResolvedSourceConfig) {
 	*out = *in
@@ -405,13 +530,23 @@ func (in *ResolvedSourceConfig) DeepCopyInto(out *ResolvedSourceConfig) {
 	if in.Blob != nil {
 		in, out := &in.Blob, &out.Blob
 		*out = new(ResolvedBlobSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Registry != nil {
 		in, out := &in.Registry, &out.Registry
 		*out = new(ResolvedRegistrySource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(ResolvedS3Source)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(ResolvedCustomSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -425,24 +560,72 @@ func (in *ResolvedSourceConfig) DeepCopy() *ResolvedSourceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3) DeepCopyInto(out *S3) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(S3Credentials)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3.
+func (in *S3) DeepCopy() *S3 {
+	if in == nil {
+		return nil
+	}
+	out := new(S3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Credentials) DeepCopyInto(out *S3Credentials) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Credentials.
+func (in *S3Credentials) DeepCopy() *S3Credentials {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Credentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
 	*out = *in
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(Git)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Blob != nil {
 		in, out := &in.Blob, &out.Blob
 		*out = new(Blob)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Registry != nil {
 		in, out := &in.Registry, &out.Registry
 		*out = new(Registry)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(Custom)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3,13 +3,58 @@ package archive
 import (
 	"archive/tar"
 	"archive/zip"
+	"bufio"
 	"compress/gzip"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
+
+	"github.com/pkg/errors"
 )
 
+var UnexpectedFileTypeError = errors.New("unexpected file type, must be one of .zip, .tar.gz, .tar, .jar")
+
+// Extract extracts a zip, tar, or gzipped tar archive of unknown type read from a stream.
+func Extract(reader io.Reader, dir string) error {
+	bufReader := bufio.NewReader(reader)
+
+	// http://golang.org/pkg/net/http/#DetectContentType
+	buf, err := bufReader.Peek(512)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	switch http.DetectContentType(buf) {
+	case "application/zip":
+		return extractZipStream(bufReader, dir)
+	case "application/x-gzip":
+		return ExtractTarGZ(bufReader, dir)
+	case "application/octet-stream":
+		return errors.Wrap(ExtractTar(bufReader, dir), "extracting tar")
+	default:
+		return UnexpectedFileTypeError
+	}
+}
+
+// extractZipStream buffers the archive in a temporary file because the zip directory is at the end of the archive
+func extractZipStream(reader io.Reader, dir string) error {
+	file, err := ioutil.TempFile("", "")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	size, err := io.Copy(file, reader)
+	if err != nil {
+		return err
+	}
+
+	return ExtractZip(file, size, dir)
+}
+
 func IsTar(fileName string) bool {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -0,0 +1,91 @@
+package blob
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	TokenKey     = "token"
+	HeaderPrefix = "header."
+)
+
+// ReadAuthSecret returns the headers to authenticate blob downloads with from a mounted secret
+// containing a bearer token, basic auth credentials, or custom headers.
+func ReadAuthSecret(secretDir string) (http.Header, error) {
+	files, err := ioutil.ReadDir(secretDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return http.Header{}, nil
+		}
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), "..") {
+			continue
+		}
+
+		value, err := ioutil.ReadFile(filepath.Join(secretDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		values[file.Name()] = strings.TrimSpace(string(value))
+	}
+
+	return authHeaders(values), nil
+}
+
+// AuthSecretHeaders returns the headers to authenticate blob downloads with from a secret
+// containing a bearer token, basic auth credentials, or custom headers.
+func AuthSecretHeaders(secret *corev1.Secret) http.Header {
+	values := map[string]string{}
+	for key, value := range secret.Data {
+		values[key] = strings.TrimSpace(string(value))
+	}
+	return authHeaders(values)
+}
+
+func authHeaders(values map[string]string) http.Header {
+	headers := http.Header{}
+	for key, value := range values {
+		if strings.HasPrefix(key, HeaderPrefix) {
+			headers.Set(strings.TrimPrefix(key, HeaderPrefix), value)
+		}
+	}
+
+	if token, ok := values[TokenKey]; ok {
+		headers.Set("Authorization", "Bearer "+token)
+	} else if username, ok := values[corev1.BasicAuthUsernameKey]; ok {
+		credentials := username + ":" + values[corev1.BasicAuthPasswordKey]
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+
+	return headers
+}
+
+// authRedirectPolicy removes the auth headers from requests redirected to a host other than
+// the host of the original request. net/http only removes the Authorization header on those
+// redirects, not the custom headers of the auth secret.
+func authRedirectPolicy(headers http.Header) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		if req.URL.Host != via[0].URL.Host {
+			for key := range headers {
+				req.Header.Del(key)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+package blob_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/pivotal/kpack/pkg/blob"
+)
+
+func TestReadAuthSecret(t *testing.T) {
+	spec.Run(t, "testReadAuthSecret", testReadAuthSecret)
+}
+
+func testReadAuthSecret(t *testing.T, when spec.G, it spec.S) {
+	var secretDir string
+
+	it.Before(func() {
+		var err error
+		secretDir, err = ioutil.TempDir("", "blob-auth")
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(secretDir))
+	})
+
+	writeKey := func(key, value string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, key), []byte(value), 0600))
+	}
+
+	it("returns a bearer token header", func() {
+		writeKey(blob.TokenKey, "some-token\n")
+
+		headers, err := blob.ReadAuthSecret(secretDir)
+		require.NoError(t, err)
+		require.Equal(t, http.Header{"Authorization": []string{"Bearer some-token"}}, headers)
+	})
+
+	it("returns a basic auth header", func() {
+		writeKey(corev1.BasicAuthUsernameKey, "some-user")
+		writeKey(corev1.BasicAuthPasswordKey, "some-password")
+
+		headers, err := blob.ReadAuthSecret(secretDir)
+		require.NoError(t, err)
+		require.Equal(t, http.Header{"Authorization": []string{"Basic c29tZS11c2VyOnNvbWUtcGFzc3dvcmQ="}}, headers)
+	})
+
+	it("returns custom headers", func() {
+		writeKey(blob.HeaderPrefix+"X-Api-Key", "some-key")
+		writeKey(blob.HeaderPrefix+"X-Other", "other")
+
+		headers, err := blob.ReadAuthSecret(secretDir)
+		require.NoError(t, err)
+		require.Equal(t, http.Header{"X-Api-Key": []string{"some-key"}, "X-Other": []string{"other"}}, headers)
+	})
+
+	it("returns no headers for an empty or missing secret", func() {
+		headers, err := blob.ReadAuthSecret(secretDir)
+		require.NoError(t, err)
+		require.Empty(t, headers)
+
+		headers, err = blob.ReadAuthSecret(filepath.Join(secretDir, "does-not-exist"))
+		require.NoError(t, err)
+		require.Empty(t, headers)
+	})
+}
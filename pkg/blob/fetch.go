@@ -1,12 +1,16 @@
 package blob
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -15,8 +19,24 @@ import (
 
 var unexpectedBlobTypeError = errors.New("unexpected blob file type, must be one of .zip, .tar.gz, .tar, .jar")
 
+// DigestMismatchExitCode is the exit code of build-init when a blob does not match its expected digest
+const DigestMismatchExitCode = 65
+
+type DigestMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e DigestMismatchError) Error() string {
+	return fmt.Sprintf("blob digest mismatch: expected sha256:%s but got sha256:%s", e.Expected, e.Actual)
+}
+
 type Fetcher struct {
 	Logger *log.Logger
+	// Sha256 is the expected hex encoded sha256 digest of the blob, if any.
+	Sha256 string
+	// Headers are added to the download request to authenticate with the blobstore.
+	Headers http.Header
 }
 
 func (f *Fetcher) Fetch(dir string, blobURL string) error {
@@ -26,12 +46,16 @@ func (f *Fetcher) Fetch(dir string, blobURL string) error {
 	}
 	f.Logger.Printf("Downloading %s%s...", u.Host, u.Path)
 
-	file, err := downloadBlob(blobURL)
+	file, digest, err := f.downloadBlob(blobURL)
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(file.Name())
 
+	if f.Sha256 != "" && !strings.EqualFold(f.Sha256, digest) {
+		return DigestMismatchError{Expected: strings.ToLower(f.Sha256), Actual: digest}
+	}
+
 	mediaType, err := classifyFile(file)
 	if err != nil {
 		return err
@@ -63,33 +87,43 @@ func (f *Fetcher) Fetch(dir string, blobURL string) error {
 	return nil
 }
 
-func downloadBlob(blobURL string) (*os.File, error) {
-	resp, err := http.Get(blobURL)
+func (f *Fetcher) downloadBlob(blobURL string) (*os.File, string, error) {
+	req, err := http.NewRequest(http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	for k, v := range f.Headers {
+		req.Header[k] = v
+	}
+
+	client := &http.Client{CheckRedirect: authRedirectPolicy(f.Headers)}
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.Errorf("failed to get blob %s", blobURL)
+		return nil, "", errors.Errorf("failed to get blob %s", blobURL)
 	}
 
 	file, err := ioutil.TempFile("", "")
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	_, err = io.Copy(file, resp.Body)
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	_, err = file.Seek(0, 0)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return file, nil
+	return file, hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 func classifyFile(reader io.ReadSeeker) (string, error) {
@@ -2,6 +2,7 @@ package blob_test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -115,6 +116,96 @@ func testBlobFetcher(t *testing.T, when spec.G, it spec.S) {
 		require.Equal(t, 0755, int(info.Mode()))
 	})
 
+	when("sha256 is provided", func() {
+		testZipSha := func() string {
+			contents, err := ioutil.ReadFile("./testdata/test.zip")
+			require.NoError(t, err)
+			return fmt.Sprintf("%x", sha256.Sum256(contents))
+		}
+
+		it("unpacks blobs matching the digest", func() {
+			fetcher := &blob.Fetcher{
+				Logger: log.New(output, "", 0),
+				Sha256: testZipSha(),
+			}
+
+			err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.zip"))
+			require.NoError(t, err)
+
+			require.FileExists(t, filepath.Join(dir, "testdir", "testfile"))
+		})
+
+		it("errors without unpacking when the digest does not match", func() {
+			fetcher := &blob.Fetcher{
+				Logger: log.New(output, "", 0),
+				Sha256: testZipSha(),
+			}
+
+			err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.tar"))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), fmt.Sprintf("blob digest mismatch: expected sha256:%s but got sha256:", testZipSha()))
+
+			files, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			require.Empty(t, files)
+		})
+	})
+
+	it("adds the headers to the download request", func() {
+		authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer some-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer authServer.Close()
+
+		fetcher := &blob.Fetcher{
+			Logger:  log.New(output, "", 0),
+			Headers: http.Header{"Authorization": []string{"Bearer some-token"}},
+		}
+
+		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", authServer.URL, "test.zip"))
+		require.NoError(t, err)
+
+		require.FileExists(t, filepath.Join(dir, "testdir", "testfile"))
+	})
+
+	it("does not send the headers to another host on redirect", func() {
+		var redirectedHeaders http.Header
+		otherServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirectedHeaders = r.Header.Clone()
+			handler.ServeHTTP(w, r)
+		}))
+		defer otherServer.Close()
+
+		authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Api-Key") != "some-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.Redirect(w, r, otherServer.URL+r.URL.Path, http.StatusFound)
+		}))
+		defer authServer.Close()
+
+		fetcher := &blob.Fetcher{
+			Logger: log.New(output, "", 0),
+			Headers: http.Header{
+				"Authorization": []string{"Bearer some-token"},
+				"X-Api-Key":     []string{"some-key"},
+			},
+		}
+
+		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", authServer.URL, "test.zip"))
+		require.NoError(t, err)
+
+		require.FileExists(t, filepath.Join(dir, "testdir", "testfile"))
+		require.NotNil(t, redirectedHeaders)
+		require.Empty(t, redirectedHeaders.Get("X-Api-Key"))
+		require.Empty(t, redirectedHeaders.Get("Authorization"))
+	})
+
 	it("errors when url is inaccessible", func() {
 		url := fmt.Sprintf("%s/%s", server.URL, "invalid.zip")
 		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "invalid.zip"))
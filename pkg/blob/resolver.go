@@ -2,23 +2,139 @@ package blob
 
 import (
 	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
+const (
+	etagVersionPrefix         = "etag:"
+	lastModifiedVersionPrefix = "last-modified:"
+)
+
 type Resolver struct {
+	K8sClient k8sclient.Interface
+	Client    *http.Client
 }
 
-func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
-	return corev1alpha1.ResolvedSourceConfig{
-		Blob: &corev1alpha1.ResolvedBlobSource{
-			URL:     sourceResolver.Spec.Source.Blob.URL,
-			SubPath: sourceResolver.Spec.Source.SubPath,
-		},
-	}, nil
+func NewResolver(k8sClient k8sclient.Interface) *Resolver {
+	return &Resolver{
+		K8sClient: k8sClient,
+		Client:    &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	blob := sourceResolver.Spec.Source.Blob
+
+	resolved := &corev1alpha1.ResolvedBlobSource{
+		URL:     blob.URL,
+		Sha256:  blob.Sha256,
+		Auth:    blob.Auth,
+		SubPath: sourceResolver.Spec.Source.SubPath,
+	}
+
+	// a blob pinned to a digest cannot change
+	if blob.Sha256 != "" {
+		return corev1alpha1.ResolvedSourceConfig{Blob: resolved}, nil
+	}
+
+	headers, err := r.authHeaders(ctx, sourceResolver.Namespace, blob.Auth)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	var previousVersion string
+	if previous := sourceResolver.Status.Source.Blob; previous != nil && previous.URL == blob.URL {
+		previousVersion = previous.Version
+	}
+
+	// blobs the controller cannot reach, such as those on a network only available to build pods,
+	// are built without polling. A blob that was polled before keeps its version until it is reachable
+	// again. The error is reported on the source resolver in both cases.
+	version, err := r.version(ctx, blob.URL, headers)
+	if err != nil {
+		resolved.Version = previousVersion
+		return corev1alpha1.ResolvedSourceConfig{Blob: resolved}, &buildapi.ResolveFallbackError{Err: err}
+	}
+	resolved.Version = version
+
+	return corev1alpha1.ResolvedSourceConfig{Blob: resolved}, nil
 }
 
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
 	return sourceResolver.IsBlob()
 }
+
+func (r *Resolver) authHeaders(ctx context.Context, namespace string, auth *corev1alpha1.BlobAuth) (http.Header, error) {
+	if auth == nil {
+		return http.Header{}, nil
+	}
+
+	secret, err := r.K8sClient.CoreV1().Secrets(namespace).Get(ctx, auth.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting blob auth secret %s", auth.SecretRef.Name)
+	}
+
+	return AuthSecretHeaders(secret), nil
+}
+
+// version identifies the content of the blob by its ETag or Last-Modified header. Servers that
+// do not support HEAD requests are asked for the first byte of the blob instead. Blobs without
+// these headers have no version and are not polled.
+func (r *Resolver) version(ctx context.Context, blobURL string, headers http.Header) (string, error) {
+	resp, err := r.do(ctx, http.MethodHead, blobURL, headers)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+		rangeHeaders := headers.Clone()
+		rangeHeaders.Set("Range", "bytes=0-0")
+
+		resp, err = r.do(ctx, http.MethodGet, blobURL, rangeHeaders)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return "", errors.Errorf("failed to get blob %s: unexpected status code %d", blobURL, resp.StatusCode)
+	}
+
+	return validatorVersion(resp), nil
+}
+
+func (r *Resolver) do(ctx context.Context, method, blobURL string, headers http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, blobURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers.Clone()
+
+	client := *r.Client
+	client.CheckRedirect = authRedirectPolicy(headers)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "requesting blob %s", blobURL)
+	}
+	return resp, nil
+}
+
+func validatorVersion(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		return etagVersionPrefix + etag
+	}
+	if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+		return lastModifiedVersionPrefix + lastModified
+	}
+	return ""
+}
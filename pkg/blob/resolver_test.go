@@ -0,0 +1,215 @@
+package blob_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/blob"
+)
+
+func TestBlobResolver(t *testing.T) {
+	spec.Run(t, "testBlobResolver", testBlobResolver)
+}
+
+func testBlobResolver(t *testing.T, when spec.G, it spec.S) {
+	const namespace = "some-namespace"
+
+	var (
+		handler  http.HandlerFunc
+		server   *httptest.Server
+		requests []*http.Request
+		resolver *blob.Resolver
+	)
+
+	it.Before(func() {
+		requests = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			handler(w, r)
+		}))
+
+		resolver = blob.NewResolver(fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "blob-secret",
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				blob.TokenKey: []byte("some-token"),
+			},
+		}))
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	sourceResolver := func(blobSource *corev1alpha1.Blob) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-source-resolver",
+				Namespace: namespace,
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Blob:    blobSource,
+					SubPath: "some-sub-path",
+				},
+			},
+		}
+	}
+
+	it("resolves the blob to its etag", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"some-etag"`)
+			w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{URL: server.URL + "/some-blob"}))
+		require.NoError(t, err)
+
+		require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+			Blob: &corev1alpha1.ResolvedBlobSource{
+				URL:     server.URL + "/some-blob",
+				Version: `etag:"some-etag"`,
+				SubPath: "some-sub-path",
+			},
+		}, resolved)
+		require.True(t, resolved.Blob.IsPollable())
+		require.Len(t, requests, 1)
+		require.Equal(t, http.MethodHead, requests[0].Method)
+	})
+
+	it("resolves the blob to its last modified time without an etag", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{URL: server.URL + "/some-blob"}))
+		require.NoError(t, err)
+
+		require.Equal(t, "last-modified:Wed, 21 Oct 2015 07:28:00 GMT", resolved.Blob.Version)
+	})
+
+	it("requests the first byte of the blob when the server does not support head requests", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("ETag", `"some-etag"`)
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte("s"))
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{URL: server.URL + "/some-blob"}))
+		require.NoError(t, err)
+
+		require.Equal(t, `etag:"some-etag"`, resolved.Blob.Version)
+		require.Len(t, requests, 2)
+		require.Equal(t, "bytes=0-0", requests[1].Header.Get("Range"))
+	})
+
+	it("resolves the blob without a version when the server does not provide a validator", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{URL: server.URL + "/some-blob"}))
+		require.NoError(t, err)
+
+		require.Equal(t, "", resolved.Blob.Version)
+		require.False(t, resolved.Blob.IsPollable())
+		require.Len(t, requests, 1)
+	})
+
+	it("uses the auth secret", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer some-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("ETag", `"some-etag"`)
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{
+			URL: server.URL + "/some-blob",
+			Auth: &corev1alpha1.BlobAuth{
+				SecretRef: corev1.LocalObjectReference{Name: "blob-secret"},
+			},
+		}))
+		require.NoError(t, err)
+
+		require.Equal(t, `etag:"some-etag"`, resolved.Blob.Version)
+	})
+
+	it("does not resolve a version for blobs with a sha256", func() {
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{
+			URL:    server.URL + "/some-blob",
+			Sha256: "some-sha",
+		}))
+		require.NoError(t, err)
+
+		require.Equal(t, "", resolved.Blob.Version)
+		require.False(t, resolved.Blob.IsPollable())
+		require.Empty(t, requests)
+	})
+
+	it("resolves the blob without a version and reports the error when it is not accessible", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{URL: server.URL + "/some-blob"}))
+		var fallbackErr *buildapi.ResolveFallbackError
+		require.True(t, errors.As(err, &fallbackErr))
+		require.EqualError(t, err, fmt.Sprintf("failed to get blob %s/some-blob: unexpected status code 404", server.URL))
+
+		require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+			Blob: &corev1alpha1.ResolvedBlobSource{
+				URL:     server.URL + "/some-blob",
+				SubPath: "some-sub-path",
+			},
+		}, resolved)
+		require.False(t, resolved.Blob.IsPollable())
+	})
+
+	it("resolves the blob without a version and reports the error when the server cannot be reached", func() {
+		server.Close()
+
+		resolved, err := resolver.Resolve(context.TODO(), sourceResolver(&corev1alpha1.Blob{URL: server.URL + "/some-blob"}))
+		var fallbackErr *buildapi.ResolveFallbackError
+		require.True(t, errors.As(err, &fallbackErr))
+
+		require.Equal(t, "", resolved.Blob.Version)
+	})
+
+	it("keeps the previous version when the blob is temporarily not accessible", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		sr := sourceResolver(&corev1alpha1.Blob{URL: server.URL + "/some-blob"})
+		sr.Status.Source.Blob = &corev1alpha1.ResolvedBlobSource{
+			URL:     server.URL + "/some-blob",
+			Version: `etag:"some-etag"`,
+		}
+
+		resolved, err := resolver.Resolve(context.TODO(), sr)
+		var fallbackErr *buildapi.ResolveFallbackError
+		require.True(t, errors.As(err, &fallbackErr))
+
+		require.Equal(t, `etag:"some-etag"`, resolved.Blob.Version)
+	})
+}
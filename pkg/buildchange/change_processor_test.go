@@ -2,6 +2,7 @@ package buildchange_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/sclevine/spec"
@@ -109,6 +110,130 @@ func testChangeProcessor(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		for _, reason := range []buildapi.BuildReason{
+			buildapi.BuildReasonBlob,
+			buildapi.BuildReasonRegistry,
+			buildapi.BuildReasonS3,
+			buildapi.BuildReasonCustom,
+		} {
+			reason := reason
+
+			when(string(reason), func() {
+				when("has no difference", func() {
+					change := buildchange.NewSourceVersionChange(reason, "same-version", "same-version")
+
+					it("returns the correct ChangeSummary and does not error", func() {
+						summary, err := cp.Process(change).Summarize()
+						assert.NoError(t, err)
+						assert.False(t, summary.HasChanges)
+						assert.Empty(t, summary.ReasonsStr)
+						assert.Empty(t, summary.ChangesStr)
+						assert.Equal(t, buildapi.BuildPriorityNone, summary.Priority)
+					})
+				})
+
+				when("has no previous version", func() {
+					change := buildchange.NewSourceVersionChange(reason, "", "new-version")
+
+					it("does not require a build", func() {
+						summary, err := cp.Process(change).Summarize()
+						assert.NoError(t, err)
+						assert.False(t, summary.HasChanges)
+					})
+				})
+
+				when("has difference", func() {
+					change := buildchange.NewSourceVersionChange(reason, "old-version", "new-version")
+					expectedReasonsStr := string(reason)
+					expectedChangesStr := testhelpers.CompactJSON(fmt.Sprintf(`
+[
+  {
+    "reason": "%s",
+    "old": "old-version",
+    "new": "new-version"
+  }
+]`, reason))
+
+					it("returns the correct ChangeSummary and does not error", func() {
+						summary, err := cp.Process(change).Summarize()
+						assert.NoError(t, err)
+						assert.True(t, summary.HasChanges)
+						assert.Equal(t, expectedReasonsStr, summary.ReasonsStr)
+						assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+						assert.Equal(t, buildapi.BuildPriorityHigh, summary.Priority)
+					})
+				})
+			})
+		}
+
+		when("RETRY", func() {
+			when("the retry limit has been reached", func() {
+				change := buildchange.NewRetryChange("Pod was evicted", 3, 2, buildapi.BuildPriorityLow)
+
+				it("does not require a build", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.False(t, summary.HasChanges)
+				})
+			})
+
+			when("the build can be retried", func() {
+				change := buildchange.NewRetryChange("Pod was evicted", 1, 2, buildapi.BuildPriorityLow)
+				expectedReasonsStr := "RETRY"
+				expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": "Pod was evicted",
+    "new": "Retry 1 of 2"
+  }
+]`)
+
+				it("returns the correct ChangeSummary with the priority of the failed build", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.True(t, summary.HasChanges)
+					assert.Equal(t, expectedReasonsStr, summary.ReasonsStr)
+					assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+					assert.Equal(t, buildapi.BuildPriorityLow, summary.Priority)
+				})
+			})
+		})
+
+		when("SCHEDULE", func() {
+			when("no rebuild is due", func() {
+				change := buildchange.NewScheduleChange("2022-03-01T00:00:00Z", "")
+
+				it("does not require a build", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.False(t, summary.HasChanges)
+				})
+			})
+
+			when("a scheduled rebuild is due", func() {
+				change := buildchange.NewScheduleChange("2022-03-01T00:00:00Z", "2022-03-06T00:00:00Z")
+				expectedReasonsStr := "SCHEDULE"
+				expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "SCHEDULE",
+    "old": "2022-03-01T00:00:00Z",
+    "new": "2022-03-06T00:00:00Z"
+  }
+]`)
+
+				it("returns the correct ChangeSummary with a scheduled priority", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.True(t, summary.HasChanges)
+					assert.Equal(t, expectedReasonsStr, summary.ReasonsStr)
+					assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+					assert.Equal(t, buildapi.BuildPriorityScheduled, summary.Priority)
+				})
+			})
+		})
+
 		when("CONFIG", func() {
 			when("has no difference", func() {
 				oldConfig := buildchange.Config{
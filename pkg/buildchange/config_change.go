@@ -31,28 +31,32 @@ type Config struct {
 func (c configChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonConfig }
 
 func (c configChange) IsBuildRequired() (bool, error) {
-	// Git revision changes are considered as COMMIT change
-	// Ignore them as part of CONFIG Change
-	var oldGitRevision, newGitRevision string
+	c.old.Source = unversionedSource(c.old.Source)
+	c.new.Source = unversionedSource(c.new.Source)
 
-	if c.old.Source.Git != nil {
-		oldGitRevision = c.old.Source.Git.Revision
-		c.old.Source.Git.Revision = ""
+	return !equality.Semantic.DeepEqual(c.old, c.new), nil
+}
+
+// unversionedSource removes the resolved revision or version of a source.
+// Changes to them are considered as COMMIT or source version changes, not as CONFIG changes.
+func unversionedSource(source corev1alpha1.SourceConfig) corev1alpha1.SourceConfig {
+	source = *source.DeepCopy()
+	if source.Git != nil {
+		source.Git.Revision = ""
 	}
-	if c.new.Source.Git != nil {
-		newGitRevision = c.new.Source.Git.Revision
-		c.new.Source.Git.Revision = ""
+	if source.Blob != nil {
+		source.Blob.Version = ""
 	}
-
-	valid := !equality.Semantic.DeepEqual(c.old, c.new)
-
-	if c.old.Source.Git != nil {
-		c.old.Source.Git.Revision = oldGitRevision
+	if source.Registry != nil {
+		source.Registry.Digest = ""
+	}
+	if source.S3 != nil {
+		source.S3.Object, source.S3.Version = "", ""
 	}
-	if c.new.Source.Git != nil {
-		c.new.Source.Git.Revision = newGitRevision
+	if source.Custom != nil {
+		source.Custom.Endpoint, source.Custom.Version = "", ""
 	}
-	return valid, nil
+	return source
 }
 
 func (c configChange) Old() interface{} { return c.old }
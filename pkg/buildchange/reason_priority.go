@@ -0,0 +1,35 @@
+package buildchange
+
+import (
+	"strings"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// reasonPriorities are the priorities of the changes with each build reason. A retry has the
+// priority of the build it retries, which its reason does not record, so retries are low priority.
+var reasonPriorities = map[buildapi.BuildReason]buildapi.BuildPriority{
+	buildapi.BuildReasonConfig:    buildapi.BuildPriorityHigh,
+	buildapi.BuildReasonCommit:    buildapi.BuildPriorityHigh,
+	buildapi.BuildReasonTrigger:   buildapi.BuildPriorityHigh,
+	buildapi.BuildReasonBlob:      buildapi.BuildPriorityHigh,
+	buildapi.BuildReasonRegistry:  buildapi.BuildPriorityHigh,
+	buildapi.BuildReasonS3:        buildapi.BuildPriorityHigh,
+	buildapi.BuildReasonCustom:    buildapi.BuildPriorityHigh,
+	buildapi.BuildReasonSchedule:  buildapi.BuildPriorityScheduled,
+	buildapi.BuildReasonBuildpack: buildapi.BuildPriorityLow,
+	buildapi.BuildReasonStack:     buildapi.BuildPriorityLow,
+	buildapi.BuildReasonRetry:     buildapi.BuildPriorityLow,
+}
+
+// PriorityForReasons returns the priority of the changes a build was created for from the
+// reasons recorded in its reason annotation.
+func PriorityForReasons(reasonsStr string) buildapi.BuildPriority {
+	priority := buildapi.BuildPriorityNone
+	for _, reason := range strings.Split(reasonsStr, reasonsSeparator) {
+		if p := reasonPriorities[buildapi.BuildReason(reason)]; p > priority {
+			priority = p
+		}
+	}
+	return priority
+}
@@ -0,0 +1,44 @@
+package buildchange_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/buildchange"
+)
+
+func TestPriorityForReasons(t *testing.T) {
+	spec.Run(t, "PriorityForReasons", testPriorityForReasons)
+}
+
+func testPriorityForReasons(t *testing.T, when spec.G, it spec.S) {
+	it("matches the priority of the change with each reason", func() {
+		for _, change := range []buildchange.Change{
+			buildchange.NewCommitChange("old", "new"),
+			buildchange.NewConfigChange(buildchange.Config{}, buildchange.Config{}),
+			buildchange.NewTriggerChange("some-date"),
+			buildchange.NewSourceVersionChange(buildapi.BuildReasonBlob, "old", "new"),
+			buildchange.NewSourceVersionChange(buildapi.BuildReasonRegistry, "old", "new"),
+			buildchange.NewSourceVersionChange(buildapi.BuildReasonS3, "old", "new"),
+			buildchange.NewSourceVersionChange(buildapi.BuildReasonCustom, "old", "new"),
+			buildchange.NewScheduleChange("old", "new"),
+			buildchange.NewBuildpackChange(nil, nil),
+			buildchange.NewStackChange("old", "new"),
+			buildchange.NewRetryChange("some-failure", 1, 3, buildapi.BuildPriorityLow),
+		} {
+			assert.Equal(t, change.Priority(), buildchange.PriorityForReasons(string(change.Reason())), change.Reason())
+		}
+	})
+
+	it("returns the highest priority of multiple reasons", func() {
+		assert.Equal(t, buildapi.BuildPriorityHigh, buildchange.PriorityForReasons("STACK,COMMIT"))
+		assert.Equal(t, buildapi.BuildPriorityLow, buildchange.PriorityForReasons("BUILDPACK,STACK"))
+	})
+
+	it("returns no priority without known reasons", func() {
+		assert.Equal(t, buildapi.BuildPriorityNone, buildchange.PriorityForReasons(""))
+	})
+}
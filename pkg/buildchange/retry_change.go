@@ -0,0 +1,36 @@
+package buildchange
+
+import (
+	"fmt"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func NewRetryChange(failure string, attempt, limit int64, priority buildapi.BuildPriority) Change {
+	return retryChange{
+		failure:  failure,
+		attempt:  attempt,
+		limit:    limit,
+		priority: priority,
+	}
+}
+
+type retryChange struct {
+	failure  string
+	attempt  int64
+	limit    int64
+	priority buildapi.BuildPriority
+}
+
+func (r retryChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonRetry }
+
+func (r retryChange) IsBuildRequired() (bool, error) {
+	return r.attempt > 0 && r.attempt <= r.limit, nil
+}
+
+func (r retryChange) Old() interface{} { return r.failure }
+
+func (r retryChange) New() interface{} { return fmt.Sprintf("Retry %d of %d", r.attempt, r.limit) }
+
+// Retries keep the priority of the build that failed
+func (r retryChange) Priority() buildapi.BuildPriority { return r.priority }
@@ -0,0 +1,29 @@
+package buildchange
+
+import (
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func NewScheduleChange(lastBuildTime, scheduledTime string) Change {
+	return scheduleChange{
+		lastBuildTime: lastBuildTime,
+		scheduledTime: scheduledTime,
+	}
+}
+
+type scheduleChange struct {
+	lastBuildTime string
+	scheduledTime string
+}
+
+func (s scheduleChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonSchedule }
+
+func (s scheduleChange) IsBuildRequired() (bool, error) {
+	return s.scheduledTime != "", nil
+}
+
+func (s scheduleChange) Old() interface{} { return s.lastBuildTime }
+
+func (s scheduleChange) New() interface{} { return s.scheduledTime }
+
+func (s scheduleChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityScheduled }
@@ -0,0 +1,32 @@
+package buildchange
+
+import buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+
+// NewSourceVersionChange is a change in the resolved version of a polled source,
+// such as the digest of a registry image or the version of a blob.
+func NewSourceVersionChange(reason buildapi.BuildReason, oldVersion, newVersion string) Change {
+	return sourceVersionChange{
+		reason:     reason,
+		oldVersion: oldVersion,
+		newVersion: newVersion,
+	}
+}
+
+type sourceVersionChange struct {
+	reason     buildapi.BuildReason
+	newVersion string
+	oldVersion string
+}
+
+func (s sourceVersionChange) Reason() buildapi.BuildReason { return s.reason }
+
+// Builds created before the source was versioned do not have a version to compare against
+func (s sourceVersionChange) IsBuildRequired() (bool, error) {
+	return s.oldVersion != "" && s.oldVersion != s.newVersion, nil
+}
+
+func (s sourceVersionChange) Old() interface{} { return s.oldVersion }
+
+func (s sourceVersionChange) New() interface{} { return s.newVersion }
+
+func (s sourceVersionChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
@@ -0,0 +1,205 @@
+package buildqueue
+
+import (
+	"sort"
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/buildchange"
+)
+
+const (
+	unfinishedIndex = "buildqueue.unfinished"
+	// allNamespaces is the unfinished index value of every unfinished build
+	allNamespaces = ""
+)
+
+// Indexers must be added to the build informer used by the queue. They index
+// unfinished builds by namespace so that finished builds are never listed.
+var Indexers = cache.Indexers{
+	unfinishedIndex: func(obj interface{}) ([]string, error) {
+		build, ok := obj.(*buildapi.Build)
+		if !ok || build.Finished() {
+			return nil, nil
+		}
+		return []string{build.Namespace, allNamespaces}, nil
+	},
+}
+
+// Queue admits builds so that no more than MaxConcurrentBuilds build pods
+// run in the cluster and no more than MaxConcurrentBuildsPerNamespace run in
+// a namespace. A limit of 0 is unlimited. Waiting builds are admitted in order
+// of priority and then creation time.
+//
+// A build is running once its pod exists, so admission is recovered from the
+// cluster after a controller restart or a change of leader.
+type Queue struct {
+	Indexer                         cache.Indexer
+	PodLister                       corev1listers.PodLister
+	MaxConcurrentBuilds             int
+	MaxConcurrentBuildsPerNamespace int
+
+	mutex sync.Mutex
+	// builds that have been admitted but whose pod is not yet in the lister cache
+	admitted map[string]struct{}
+}
+
+func New(indexer cache.Indexer, podLister corev1listers.PodLister, maxConcurrentBuilds, maxConcurrentBuildsPerNamespace int) *Queue {
+	return &Queue{
+		Indexer:                         indexer,
+		PodLister:                       podLister,
+		MaxConcurrentBuilds:             maxConcurrentBuilds,
+		MaxConcurrentBuildsPerNamespace: maxConcurrentBuildsPerNamespace,
+		admitted:                        map[string]struct{}{},
+	}
+}
+
+// Admit returns 0 if the build may create its pod. Otherwise it returns the
+// build's position in the queue, starting at 1.
+func (q *Queue) Admit(build *buildapi.Build) (int64, error) {
+	if q.MaxConcurrentBuilds <= 0 && q.MaxConcurrentBuildsPerNamespace <= 0 {
+		return 0, nil
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, ok := q.admitted[key(build)]; ok {
+		return 0, nil
+	}
+
+	// without a cluster limit only the builds in the namespace of the build compete with it
+	namespace := allNamespaces
+	if q.MaxConcurrentBuilds <= 0 {
+		namespace = build.Namespace
+	}
+
+	objs, err := q.Indexer.ByIndex(unfinishedIndex, namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		running   = 0
+		perNs     = map[string]int{}
+		waiting   []*buildapi.Build
+		listed    = map[string]struct{}{}
+		requested = key(build)
+	)
+	for _, obj := range objs {
+		b, ok := obj.(*buildapi.Build)
+		if !ok {
+			continue
+		}
+		listed[key(b)] = struct{}{}
+
+		hasPod, err := q.hasPod(b)
+		if err != nil {
+			return 0, err
+		}
+		if hasPod {
+			delete(q.admitted, key(b))
+		}
+
+		if hasPod || q.isAdmitted(b) {
+			running++
+			perNs[b.Namespace]++
+		} else if !b.Spec.Cancelled {
+			waiting = append(waiting, b)
+		}
+	}
+
+	for k := range q.admitted {
+		if _, ok := listed[k]; !ok {
+			delete(q.admitted, k)
+		}
+	}
+
+	if _, ok := listed[requested]; !ok {
+		waiting = append(waiting, build)
+	}
+	sortQueue(waiting)
+
+	var position int64
+	for _, b := range waiting {
+		if q.hasCapacity(running, perNs[b.Namespace]) {
+			running++
+			perNs[b.Namespace]++
+
+			if key(b) == requested {
+				q.admitted[requested] = struct{}{}
+				return 0, nil
+			}
+			continue
+		}
+
+		position++
+		if key(b) == requested {
+			return position, nil
+		}
+	}
+
+	return position + 1, nil
+}
+
+func (q *Queue) hasPod(build *buildapi.Build) (bool, error) {
+	if build.Status.PodName != "" {
+		return true, nil
+	}
+
+	_, err := q.PodLister.Pods(build.Namespace).Get(build.PodName())
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (q *Queue) isAdmitted(build *buildapi.Build) bool {
+	_, ok := q.admitted[key(build)]
+	return ok
+}
+
+func (q *Queue) hasCapacity(running, runningInNamespace int) bool {
+	if q.MaxConcurrentBuilds > 0 && running >= q.MaxConcurrentBuilds {
+		return false
+	}
+	if q.MaxConcurrentBuildsPerNamespace > 0 && runningInNamespace >= q.MaxConcurrentBuildsPerNamespace {
+		return false
+	}
+	return true
+}
+
+func sortQueue(builds []*buildapi.Build) {
+	sort.SliceStable(builds, func(i, j int) bool {
+		pi := priority(builds[i])
+		pj := priority(builds[j])
+		if pi != pj {
+			return pi > pj
+		}
+
+		ti := builds[i].CreationTimestamp
+		tj := builds[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+
+		return key(builds[i]) < key(builds[j])
+	})
+}
+
+// priority is the priority of the build's priority class or, when priority
+// classes are not enabled, the priority of the changes the build was created for.
+func priority(build *buildapi.Build) buildapi.BuildPriority {
+	if build.Spec.PriorityClassName != "" {
+		return buildapi.PriorityForClass(build.Spec.PriorityClassName)
+	}
+	return buildchange.PriorityForReasons(build.BuildReason())
+}
+
+func key(build *buildapi.Build) string {
+	return build.Namespace + "/" + build.Name
+}
@@ -0,0 +1,190 @@
+package buildqueue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildqueue"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+)
+
+func TestQueue(t *testing.T) {
+	spec.Run(t, "Queue", testQueue)
+}
+
+func testQueue(t *testing.T, when spec.G, it spec.S) {
+	var (
+		now     = time.Now()
+		objects []runtime.Object
+	)
+
+	newBuild := func(name, namespace string, age time.Duration) *buildapi.Build {
+		b := &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+		}
+		objects = append(objects, b)
+		return b
+	}
+
+	running := func(b *buildapi.Build) *buildapi.Build {
+		b.Status.PodName = b.Name + "-build-pod"
+		b.Status.Conditions = corev1alpha1.Conditions{
+			{Type: corev1alpha1.ConditionSucceeded, Status: corev1.ConditionUnknown},
+		}
+		return b
+	}
+
+	finished := func(b *buildapi.Build) *buildapi.Build {
+		b.Status.PodName = b.Name + "-build-pod"
+		b.Status.Conditions = corev1alpha1.Conditions{
+			{Type: corev1alpha1.ConditionSucceeded, Status: corev1.ConditionTrue},
+		}
+		return b
+	}
+
+	queue := func(maxBuilds, maxBuildsPerNamespace int) *buildqueue.Queue {
+		listers := testhelpers.NewListers(objects)
+		return buildqueue.New(listers.GetBuildIndexer(buildqueue.Indexers), listers.GetPodLister(), maxBuilds, maxBuildsPerNamespace)
+	}
+
+	it("admits every build when unlimited", func() {
+		running(newBuild("running-1", "ns", time.Hour))
+		running(newBuild("running-2", "ns", time.Hour))
+		b := newBuild("pending", "ns", 0)
+
+		position, err := queue(0, 0).Admit(b)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), position)
+	})
+
+	it("queues builds beyond the cluster limit in creation order", func() {
+		running(newBuild("running", "ns-a", time.Hour))
+		finished(newBuild("finished", "ns-a", time.Hour))
+		first := newBuild("first", "ns-b", 2*time.Minute)
+		second := newBuild("second", "ns-c", time.Minute)
+		third := newBuild("third", "ns-a", 0)
+
+		q := queue(2, 0)
+
+		position, err := q.Admit(third)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), position)
+
+		position, err = q.Admit(second)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), position)
+
+		position, err = q.Admit(first)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), position)
+	})
+
+	it("counts admitted builds until their pod is recorded", func() {
+		first := newBuild("first", "ns", time.Minute)
+		second := newBuild("second", "ns", 0)
+
+		q := queue(1, 0)
+
+		position, err := q.Admit(first)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), position)
+
+		position, err = q.Admit(first)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), position)
+
+		position, err = q.Admit(second)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), position)
+	})
+
+	it("counts builds whose pod exists before their status records it", func() {
+		withPod := newBuild("with-pod", "ns", time.Hour)
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      withPod.PodName(),
+				Namespace: "ns",
+			},
+		})
+		b := newBuild("pending", "ns", 0)
+
+		position, err := queue(1, 0).Admit(b)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), position)
+	})
+
+	it("limits builds per namespace independently", func() {
+		running(newBuild("running", "ns-a", time.Hour))
+		queued := newBuild("queued", "ns-a", time.Minute)
+		other := newBuild("other", "ns-b", 0)
+
+		q := queue(0, 1)
+
+		position, err := q.Admit(queued)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), position)
+
+		position, err = q.Admit(other)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), position)
+	})
+
+	it("admits higher priority builds first", func() {
+		running(newBuild("running", "ns", time.Hour))
+		low := newBuild("low", "ns", 2*time.Minute)
+		low.Spec.PriorityClassName = buildapi.BuildPriorityClassLow
+		none := newBuild("none", "ns", 3*time.Minute)
+		high := newBuild("high", "ns", 0)
+		high.Spec.PriorityClassName = buildapi.BuildPriorityClassHigh
+
+		q := queue(1, 0)
+
+		for b, expected := range map[*buildapi.Build]int64{high: 1, low: 2, none: 3} {
+			position, err := q.Admit(b)
+			require.NoError(t, err)
+			assert.Equal(t, expected, position, b.Name)
+		}
+	})
+
+	it("admits builds by the priority of their build reasons without priority classes", func() {
+		running(newBuild("running", "ns", time.Hour))
+		stack := newBuild("stack", "ns", 3*time.Minute)
+		stack.Annotations = map[string]string{buildapi.BuildReasonAnnotation: "STACK"}
+		scheduled := newBuild("scheduled", "ns", 2*time.Minute)
+		scheduled.Annotations = map[string]string{buildapi.BuildReasonAnnotation: "SCHEDULE"}
+		commit := newBuild("commit", "ns", 0)
+		commit.Annotations = map[string]string{buildapi.BuildReasonAnnotation: "STACK,COMMIT"}
+
+		q := queue(1, 0)
+
+		for b, expected := range map[*buildapi.Build]int64{commit: 1, scheduled: 2, stack: 3} {
+			position, err := q.Admit(b)
+			require.NoError(t, err)
+			assert.Equal(t, expected, position, b.Name)
+		}
+	})
+
+	it("does not queue behind cancelled builds", func() {
+		running(newBuild("running", "ns", time.Hour))
+		cancelled := newBuild("cancelled", "ns", time.Minute)
+		cancelled.Spec.Cancelled = true
+		b := newBuild("pending", "ns", 0)
+
+		position, err := queue(1, 0).Admit(b)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), position)
+	})
+}
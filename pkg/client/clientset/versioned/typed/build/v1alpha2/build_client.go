@@ -33,6 +33,8 @@ type KpackV1alpha2Interface interface {
 	ClusterStoresGetter
 	ImagesGetter
 	SourceResolversGetter
+	StacksGetter
+	StoresGetter
 }
 
 // KpackV1alpha2Client is used to interact with features provided by the kpack.io group.
@@ -68,6 +70,14 @@ func (c *KpackV1alpha2Client) SourceResolvers(namespace string) SourceResolverIn
 	return newSourceResolvers(c, namespace)
 }
 
+func (c *KpackV1alpha2Client) Stacks(namespace string) StackInterface {
+	return newStacks(c, namespace)
+}
+
+func (c *KpackV1alpha2Client) Stores(namespace string) StoreInterface {
+	return newStores(c, namespace)
+}
+
 // NewForConfig creates a new KpackV1alpha2Client for the given config.
 func NewForConfig(c *rest.Config) (*KpackV1alpha2Client, error) {
 	config := *c
@@ -56,6 +56,14 @@ func (c *FakeKpackV1alpha2) SourceResolvers(namespace string) v1alpha2.SourceRes
 	return &FakeSourceResolvers{c, namespace}
 }
 
+func (c *FakeKpackV1alpha2) Stacks(namespace string) v1alpha2.StackInterface {
+	return &FakeStacks{c, namespace}
+}
+
+func (c *FakeKpackV1alpha2) Stores(namespace string) v1alpha2.StoreInterface {
+	return &FakeStores{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKpackV1alpha2) RESTClient() rest.Interface {
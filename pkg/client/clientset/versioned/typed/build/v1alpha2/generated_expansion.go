@@ -31,3 +31,7 @@ type ClusterStoreExpansion interface{}
 type ImageExpansion interface{}
 
 type SourceResolverExpansion interface{}
+
+type StackExpansion interface{}
+
+type StoreExpansion interface{}
@@ -38,6 +38,10 @@ type Interface interface {
 	Images() ImageInformer
 	// SourceResolvers returns a SourceResolverInformer.
 	SourceResolvers() SourceResolverInformer
+	// Stacks returns a StackInformer.
+	Stacks() StackInformer
+	// Stores returns a StoreInformer.
+	Stores() StoreInformer
 }
 
 type version struct {
@@ -85,3 +89,13 @@ func (v *version) Images() ImageInformer {
 func (v *version) SourceResolvers() SourceResolverInformer {
 	return &sourceResolverInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// Stacks returns a StackInformer.
+func (v *version) Stacks() StackInformer {
+	return &stackInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Stores returns a StoreInformer.
+func (v *version) Stores() StoreInformer {
+	return &storeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -84,6 +84,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().Images().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("sourceresolvers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().SourceResolvers().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("stacks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().Stacks().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("stores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().Stores().Informer()}, nil
 
 	}
 
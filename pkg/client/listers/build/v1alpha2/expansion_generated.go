@@ -61,3 +61,19 @@ type SourceResolverListerExpansion interface{}
 // SourceResolverNamespaceListerExpansion allows custom methods to be added to
 // SourceResolverNamespaceLister.
 type SourceResolverNamespaceListerExpansion interface{}
+
+// StackListerExpansion allows custom methods to be added to
+// StackLister.
+type StackListerExpansion interface{}
+
+// StackNamespaceListerExpansion allows custom methods to be added to
+// StackNamespaceLister.
+type StackNamespaceListerExpansion interface{}
+
+// StoreListerExpansion allows custom methods to be added to
+// StoreLister.
+type StoreListerExpansion interface{}
+
+// StoreNamespaceListerExpansion allows custom methods to be added to
+// StoreNamespaceLister.
+type StoreNamespaceListerExpansion interface{}
@@ -174,10 +174,19 @@ func (bb *builderBlder) WriteableImage() (v1.Image, error) {
 }
 
 func (bb *builderBlder) validateBuilder(sortedBuildpacks []DescriptiveBuildpackInfo) error {
+	if errs := bb.validationErrors(sortedBuildpacks); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func (bb *builderBlder) validationErrors(sortedBuildpacks []DescriptiveBuildpackInfo) []error {
+	var errs []error
+
 	platformApis := append(bb.LifecycleMetadata.APIs.Platform.Deprecated, bb.LifecycleMetadata.APIs.Platform.Supported...)
 	err := validatePlatformApis(platformApis)
 	if err != nil {
-		return err
+		errs = append(errs, err)
 	}
 	buildpackApis := append(bb.LifecycleMetadata.APIs.Buildpack.Deprecated, bb.LifecycleMetadata.APIs.Buildpack.Supported...)
 	for _, bpInfo := range sortedBuildpacks {
@@ -185,10 +194,10 @@ func (bb *builderBlder) validateBuilder(sortedBuildpacks []DescriptiveBuildpackI
 		bpLayerInfo := bb.buildpackLayers[bpInfo].BuildpackLayerInfo
 		err := bpLayerInfo.supports(buildpackApis, bb.stackId, bb.mixins, relaxedMixinContract(platformApis))
 		if err != nil {
-			return errors.Wrapf(err, "validating buildpack %s", bpInfo)
+			errs = append(errs, errors.Wrapf(err, "validating buildpack %s", bpInfo))
 		}
 	}
-	return nil
+	return errs
 }
 
 func validatePlatformApis(builderSupportedApis []string) error {
@@ -3,6 +3,7 @@ package cnb
 import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
@@ -13,6 +14,24 @@ type RegistryClient interface {
 	Save(keychain authn.Keychain, tag string, image v1.Image) (string, error)
 }
 
+type ImageVerifier interface {
+	Verify(keychain authn.Keychain, image string, policy buildapi.VerificationPolicy) error
+}
+
+// VerificationError is returned by the store and stack readers when an image
+// does not satisfy the verification policy.
+type VerificationError struct {
+	Err error
+}
+
+func (e *VerificationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
 type BuildpackRepository interface {
 	FindByIdAndVersion(id, version string) (RemoteBuildpackInfo, error)
 }
@@ -27,72 +46,161 @@ type RemoteBuilderCreator struct {
 	RegistryClient         RegistryClient
 	NewBuildpackRepository NewBuildpackRepository
 	LifecycleProvider      LifecycleProvider
+	ImageVerifier          ImageVerifier
 	KpackVersion           string
 }
 
 func (r *RemoteBuilderCreator) CreateBuilder(keychain authn.Keychain, clusterStore *buildapi.ClusterStore, clusterStack *buildapi.ClusterStack, spec buildapi.BuilderSpec) (buildapi.BuilderRecord, error) {
-	buildpackRepo := r.NewBuildpackRepository(clusterStore)
+	builderBldr, errs := r.assemble(keychain, clusterStore, clusterStack, spec, true)
+	if len(errs) > 0 {
+		return buildapi.BuilderRecord{}, errs[0]
+	}
 
-	buildImage, _, err := r.RegistryClient.Fetch(keychain, clusterStack.Status.BuildImage.LatestImage)
+	writeableImage, err := builderBldr.WriteableImage()
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
 	}
 
+	identifier, err := r.RegistryClient.Save(keychain, spec.Tag, writeableImage)
+	if err != nil {
+		return buildapi.BuilderRecord{}, err
+	}
+
+	config, err := writeableImage.ConfigFile()
+	if err != nil {
+		return buildapi.BuilderRecord{}, err
+	}
+
+	return buildapi.BuilderRecord{
+		Image: identifier,
+		Stack: corev1alpha1.BuildStack{
+			RunImage: clusterStack.Status.RunImage.LatestImage,
+			ID:       clusterStack.Status.Id,
+		},
+		Buildpacks:              buildpackMetadata(builderBldr.buildpacks()),
+		Order:                   builderBldr.order,
+		ObservedStackGeneration: clusterStack.Status.ObservedGeneration,
+		ObservedStoreGeneration: clusterStore.Status.ObservedGeneration,
+		OS:                      config.OS,
+	}, nil
+}
+
+// ValidateBuilder assembles the builder in memory and returns every problem
+// that would prevent it from being created. Nothing is written to a registry.
+func (r *RemoteBuilderCreator) ValidateBuilder(keychain authn.Keychain, clusterStore *buildapi.ClusterStore, clusterStack *buildapi.ClusterStack, spec buildapi.BuilderSpec) []error {
+	builderBldr, errs := r.assemble(keychain, clusterStore, clusterStack, spec, false)
+	if builderBldr == nil {
+		return errs
+	}
+
+	errs = append(errs, builderBldr.validationErrors(builderBldr.buildpacks())...)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if _, err := builderBldr.WriteableImage(); err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
+// assemble adds the stack, lifecycle and buildpack order of the spec to a new
+// builder. Buildpacks missing from the store stop the assembly when failFast
+// is set and are otherwise collected while the rest of the order is added.
+// The returned builder is nil if the stack or lifecycle cannot be added.
+func (r *RemoteBuilderCreator) assemble(keychain authn.Keychain, clusterStore *buildapi.ClusterStore, clusterStack *buildapi.ClusterStack, spec buildapi.BuilderSpec, failFast bool) (*builderBlder, []error) {
+	buildpackRepo := r.buildpackRepository(keychain, clusterStore)
+
+	buildImage, _, err := r.RegistryClient.Fetch(keychain, clusterStack.Status.BuildImage.LatestImage)
+	if err != nil {
+		return nil, []error{err}
+	}
+
 	builderBldr := newBuilderBldr(r.KpackVersion)
 
 	err = builderBldr.AddStack(buildImage, clusterStack)
 	if err != nil {
-		return buildapi.BuilderRecord{}, err
+		return nil, []error{err}
 	}
 
 	lifecycleLayer, lifecycleMetadata, err := r.LifecycleProvider.LayerForOS(builderBldr.os)
 	if err != nil {
-		return buildapi.BuilderRecord{}, err
+		return nil, []error{err}
 	}
 
 	builderBldr.AddLifecycle(lifecycleLayer, lifecycleMetadata)
 
+	var errs []error
+	buildpackageRepos := map[string]BuildpackRepository{}
 	for _, group := range spec.Order {
 		buildpacks := make([]RemoteBuildpackRef, 0, len(group.Group))
 
 		for _, buildpack := range group.Group {
-			remoteBuildpack, err := buildpackRepo.FindByIdAndVersion(buildpack.Id, buildpack.Version)
+			repo := buildpackRepo
+			if buildpack.Image != "" {
+				repo, err = r.buildpackageRepository(keychain, clusterStore, buildpack.Image, buildpackageRepos)
+				if err != nil {
+					if failFast {
+						return builderBldr, []error{err}
+					}
+					errs = append(errs, err)
+					continue
+				}
+			}
+
+			remoteBuildpack, err := repo.FindByIdAndVersion(buildpack.Id, buildpack.Version)
 			if err != nil {
-				return buildapi.BuilderRecord{}, err
+				if failFast {
+					return builderBldr, []error{err}
+				}
+				errs = append(errs, err)
+				continue
 			}
 
 			buildpacks = append(buildpacks, remoteBuildpack.Optional(buildpack.Optional))
 		}
 		builderBldr.AddGroup(buildpacks...)
 	}
+	return builderBldr, errs
+}
 
-	writeableImage, err := builderBldr.WriteableImage()
-	if err != nil {
-		return buildapi.BuilderRecord{}, err
+// buildpackRepository returns the repository of the store buildpacks. The
+// layers of a namespaced Store are read with the builder keychain as the
+// controller is not expected to have access to them.
+func (r *RemoteBuilderCreator) buildpackRepository(keychain authn.Keychain, clusterStore *buildapi.ClusterStore) BuildpackRepository {
+	if clusterStore.Namespace != "" {
+		return &StoreBuildpackRepository{
+			Keychain:     keychain,
+			ClusterStore: clusterStore,
+		}
 	}
+	return r.NewBuildpackRepository(clusterStore)
+}
 
-	identifier, err := r.RegistryClient.Save(keychain, spec.Tag, writeableImage)
-	if err != nil {
-		return buildapi.BuilderRecord{}, err
+// buildpackageRepository reads the buildpacks of a buildpackage referenced
+// directly in the order. Each buildpackage is only read once per builder and
+// must satisfy the verification policy of the store.
+func (r *RemoteBuilderCreator) buildpackageRepository(keychain authn.Keychain, clusterStore *buildapi.ClusterStore, image string, repos map[string]BuildpackRepository) (BuildpackRepository, error) {
+	if repo, ok := repos[image]; ok {
+		return repo, nil
 	}
 
-	config, err := writeableImage.ConfigFile()
+	storeReader := &RemoteStoreReader{RegistryClient: r.RegistryClient, Verifier: r.ImageVerifier}
+	buildpacks, err := storeReader.Read(keychain, []corev1alpha1.StoreImage{{Image: image}}, clusterStore.Spec.Verification)
 	if err != nil {
-		return buildapi.BuilderRecord{}, err
+		return nil, errors.Wrapf(err, "reading buildpackage %s", image)
 	}
 
-	return buildapi.BuilderRecord{
-		Image: identifier,
-		Stack: corev1alpha1.BuildStack{
-			RunImage: clusterStack.Status.RunImage.LatestImage,
-			ID:       clusterStack.Status.Id,
+	repo := &StoreBuildpackRepository{
+		Keychain: keychain,
+		ClusterStore: &buildapi.ClusterStore{
+			Status: buildapi.ClusterStoreStatus{
+				Buildpacks: buildpacks,
+			},
 		},
-		Buildpacks:              buildpackMetadata(builderBldr.buildpacks()),
-		Order:                   builderBldr.order,
-		ObservedStackGeneration: clusterStack.Status.ObservedGeneration,
-		ObservedStoreGeneration: clusterStore.Status.ObservedGeneration,
-		OS:                      config.OS,
-	}, nil
+	}
+	repos[image] = repo
+	return repo, nil
 }
 
 func buildpackMetadata(buildpacks []DescriptiveBuildpackInfo) corev1alpha1.BuildpackMetadataList {
@@ -883,6 +883,226 @@ func testCreateBuilderOs(os string, t *testing.T, when spec.G, it spec.S) {
 				require.EqualError(t, err, "unsupported platform apis in kpack lifecycle: 0.1, 0.2, 0.999, expecting one of: 0.3, 0.4, 0.5, 0.6, 0.7, 0.8")
 			})
 		})
+
+		it("reads the buildpacks of a namespaced store with the builder keychain", func() {
+			namespacedStore := &buildapi.ClusterStore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "namespaced-store",
+					Namespace: "some-namespace",
+				},
+				Status: buildapi.ClusterStoreStatus{
+					Buildpacks: []corev1alpha1.StoreBuildpack{
+						{
+							BuildpackInfo: corev1alpha1.BuildpackInfo{
+								Id:      "io.buildpack.namespaced",
+								Version: "v1",
+							},
+							StoreImage: corev1alpha1.StoreImage{
+								Image: "some-registry.io/namespaced-buildpackage",
+							},
+							DiffId: buildpack1Layer.diffID,
+							Digest: buildpack1Layer.digest,
+							Size:   buildpack1Layer.size,
+							API:    "0.3",
+							Stacks: []corev1alpha1.BuildpackStack{{ID: stackID}},
+						},
+					},
+				},
+			}
+
+			subject.NewBuildpackRepository = func(store *buildapi.ClusterStore) BuildpackRepository {
+				t.Fatal("the cluster buildpack repository should not be used for a namespaced store")
+				return nil
+			}
+
+			clusterBuilderSpec.Order = []corev1alpha1.OrderEntry{
+				{
+					Group: []corev1alpha1.BuildpackRef{
+						{
+							BuildpackInfo: corev1alpha1.BuildpackInfo{
+								Id: "io.buildpack.namespaced",
+							},
+						},
+					},
+				},
+			}
+
+			builderRecord, err := subject.CreateBuilder(keychain, namespacedStore, stack, clusterBuilderSpec)
+			require.NoError(t, err)
+
+			assert.Equal(t, corev1alpha1.BuildpackMetadataList{{Id: "io.buildpack.namespaced", Version: "v1"}}, builderRecord.Buildpacks)
+		})
+
+		when("buildpacks are referenced by image", func() {
+			const buildpackageImage = "some-registry.io/buildpackage:1.0"
+
+			var imageBuildpackLayer = &fakeLayer{
+				digest: "sha256:4bd8899667b8d1e6b124f663faca32903b470831e5e4e99265c839ab34628838",
+				diffID: "sha256:4bf8899667b8d1e6b124f663faca32903b470831e5e4e992644ac5c839ab3462",
+				size:   50,
+			}
+
+			it.Before(func() {
+				buildpackage, err := random.Image(0, 0)
+				require.NoError(t, err)
+
+				buildpackage, err = mutate.AppendLayers(buildpackage, imageBuildpackLayer)
+				require.NoError(t, err)
+
+				buildpackage, err = imagehelpers.SetStringLabels(buildpackage, map[string]string{
+					"io.buildpacks.buildpack.layers": fmt.Sprintf(`{
+  "io.buildpack.image": {
+    "v5": {
+      "layerDiffID": "%s",
+      "api": "0.3",
+      "homepage": "buildpack.image.com",
+      "stacks": [{"id": "%s"}]
+    }
+  }
+}`, imageBuildpackLayer.diffID, stackID),
+				})
+				require.NoError(t, err)
+
+				registryClient.AddImage(buildpackageImage, buildpackage, keychain)
+
+				clusterBuilderSpec.Order = append(clusterBuilderSpec.Order, corev1alpha1.OrderEntry{
+					Group: []corev1alpha1.BuildpackRef{
+						{
+							BuildpackInfo: corev1alpha1.BuildpackInfo{
+								Id: "io.buildpack.image",
+							},
+							Image: buildpackageImage,
+						},
+					},
+				})
+			})
+
+			it("adds the buildpack from the buildpackage", func() {
+				builderRecord, err := subject.CreateBuilder(keychain, store, stack, clusterBuilderSpec)
+				require.NoError(t, err)
+
+				assert.Contains(t, builderRecord.Buildpacks, corev1alpha1.BuildpackMetadata{Id: "io.buildpack.image", Version: "v5", Homepage: "buildpack.image.com"})
+				assert.Contains(t, builderRecord.Order, corev1alpha1.OrderEntry{
+					Group: []corev1alpha1.BuildpackRef{
+						{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "io.buildpack.image", Version: "v5"}},
+					},
+				})
+
+				layers, err := registryClient.SavedImages()[tag].Layers()
+				require.NoError(t, err)
+
+				imageLayerDigests := make([]string, 0, len(layers))
+				for _, layer := range layers {
+					digest, err := layer.Digest()
+					require.NoError(t, err)
+					imageLayerDigests = append(imageLayerDigests, digest.String())
+				}
+				assert.Contains(t, imageLayerDigests, imageBuildpackLayer.digest)
+			})
+
+			it("errors when the buildpack is not in the buildpackage", func() {
+				clusterBuilderSpec.Order[1].Group[0].Id = "io.buildpack.other"
+
+				_, err := subject.CreateBuilder(keychain, store, stack, clusterBuilderSpec)
+				require.EqualError(t, err, "could not find buildpack with id 'io.buildpack.other'")
+			})
+
+			it("verifies the buildpackage with the policy of the store", func() {
+				verifier := &fakeImageVerifier{}
+				subject.ImageVerifier = verifier
+				store.Spec.Verification = &buildapi.VerificationPolicy{PublicKeys: []string{"some-public-key"}}
+
+				_, err := subject.CreateBuilder(keychain, store, stack, clusterBuilderSpec)
+				require.NoError(t, err)
+
+				require.Len(t, verifier.verified, 1)
+				assert.Contains(t, verifier.verified[0], "some-registry.io/buildpackage@sha256:")
+			})
+
+			it("errors when the buildpackage is not signed as the store requires", func() {
+				_, identifier, err := registryClient.Fetch(keychain, buildpackageImage)
+				require.NoError(t, err)
+
+				subject.ImageVerifier = &fakeImageVerifier{unsigned: map[string]bool{identifier: true}}
+				store.Spec.Verification = &buildapi.VerificationPolicy{PublicKeys: []string{"some-public-key"}}
+
+				_, err = subject.CreateBuilder(keychain, store, stack, clusterBuilderSpec)
+				require.EqualError(t, err, "reading buildpackage "+buildpackageImage+": image "+identifier+" is not signed by a trusted key or identity")
+			})
+
+			it("validates the buildpack like a store buildpack", func() {
+				stack.Status.Id = "io.buildpacks.stacks.other-stack"
+
+				var problems []string
+				for _, err := range subject.ValidateBuilder(keychain, store, stack, clusterBuilderSpec) {
+					problems = append(problems, err.Error())
+				}
+				assert.Contains(t, problems, "validating buildpack io.buildpack.image@v5: stack io.buildpacks.stacks.other-stack is not supported")
+			})
+		})
+
+		when("ValidateBuilder", func() {
+			it("validates a builder without saving it", func() {
+				errs := subject.ValidateBuilder(keychain, store, stack, clusterBuilderSpec)
+				assert.Empty(t, errs)
+
+				assert.Empty(t, registryClient.SavedImages())
+			})
+
+			it("reports every problem with the builder", func() {
+				lifecycleProvider.metadata.APIs.Platform = APIVersions{
+					Supported: []string{"0.999"},
+				}
+
+				buildpackRepository.AddBP("io.buildpack.unsupported.stack", "v4", []buildpackLayer{
+					{
+						v1Layer: buildpack1Layer,
+						BuildpackInfo: DescriptiveBuildpackInfo{
+							BuildpackInfo: corev1alpha1.BuildpackInfo{
+								Id:      "io.buildpack.unsupported.stack",
+								Version: "v4",
+							},
+						},
+						BuildpackLayerInfo: BuildpackLayerInfo{
+							API:         "0.2",
+							LayerDiffID: buildpack1Layer.diffID,
+							Stacks: []corev1alpha1.BuildpackStack{
+								{
+									ID: "io.buildpacks.stacks.unsupported",
+								},
+							},
+						},
+					},
+				})
+
+				clusterBuilderSpec.Order = []corev1alpha1.OrderEntry{
+					{
+						Group: []corev1alpha1.BuildpackRef{
+							{
+								BuildpackInfo: corev1alpha1.BuildpackInfo{
+									Id:      "io.buildpack.missing",
+									Version: "v1",
+								},
+							},
+							{
+								BuildpackInfo: corev1alpha1.BuildpackInfo{
+									Id:      "io.buildpack.unsupported.stack",
+									Version: "v4",
+								},
+							},
+						},
+					},
+				}
+
+				errs := subject.ValidateBuilder(keychain, store, stack, clusterBuilderSpec)
+				require.Len(t, errs, 3)
+				assert.EqualError(t, errs[0], "buildpack not found")
+				assert.EqualError(t, errs[1], "unsupported platform apis in kpack lifecycle: 0.999, expecting one of: 0.3, 0.4, 0.5, 0.6, 0.7, 0.8")
+				assert.EqualError(t, errs[2], "validating buildpack io.buildpack.unsupported.stack@v4: stack io.buildpacks.stacks.some-stack is not supported")
+
+				assert.Empty(t, registryClient.SavedImages())
+			})
+		})
 	})
 }
 
@@ -0,0 +1,27 @@
+package cnb
+
+import (
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+type fakeImageVerifier struct {
+	mu       sync.Mutex
+	verified []string
+	unsigned map[string]bool
+}
+
+func (f *fakeImageVerifier) Verify(keychain authn.Keychain, image string, policy buildapi.VerificationPolicy) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.verified = append(f.verified, image)
+	if f.unsigned[image] {
+		return errors.Errorf("image %s is not signed by a trusted key or identity", image)
+	}
+	return nil
+}
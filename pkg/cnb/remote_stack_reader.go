@@ -22,6 +22,7 @@ const (
 
 type RemoteStackReader struct {
 	RegistryClient RegistryClient
+	Verifier       ImageVerifier
 }
 
 func (r *RemoteStackReader) Read(keychain authn.Keychain, clusterStackSpec buildapi.ClusterStackSpec) (buildapi.ResolvedClusterStack, error) {
@@ -35,6 +36,14 @@ func (r *RemoteStackReader) Read(keychain authn.Keychain, clusterStackSpec build
 		return buildapi.ResolvedClusterStack{}, err
 	}
 
+	if clusterStackSpec.Verification != nil {
+		for _, identifier := range []string{buildIdentifier, runIdentifier} {
+			if err := r.Verifier.Verify(keychain, identifier, *clusterStackSpec.Verification); err != nil {
+				return buildapi.ResolvedClusterStack{}, &VerificationError{Err: err}
+			}
+		}
+	}
+
 	err = validateStackId(clusterStackSpec.Id, buildImage, runImage)
 	if err != nil {
 		return buildapi.ResolvedClusterStack{}, err
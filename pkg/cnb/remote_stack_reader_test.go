@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -79,6 +80,67 @@ func testRemoteStackReader(t *testing.T, when spec.G, it spec.S) {
 
 		})
 
+		when("a verification policy is provided", func() {
+			var (
+				verified []string
+				unsigned string
+				policy   = &buildapi.VerificationPolicy{
+					PublicKeys: []string{"some-public-key"},
+				}
+			)
+
+			it.Before(func() {
+				remoteStackReader.Verifier = verifierFunc(func(keychain authn.Keychain, image string, p buildapi.VerificationPolicy) error {
+					require.Equal(t, expectedKeychain, keychain)
+					require.Equal(t, *policy, p)
+
+					verified = append(verified, image)
+					if image == unsigned {
+						return errors.Errorf("image %s is not signed by a trusted key or identity", image)
+					}
+					return nil
+				})
+
+				fakeClient.AddImage(runTag, runImage(t, stackId, nil), expectedKeychain)
+				fakeClient.AddImage(buildTag, buildImage(t, stackId, nil), expectedKeychain)
+			})
+
+			it("verifies the resolved build and run images", func() {
+				resolvedStack, err := remoteStackReader.Read(expectedKeychain, buildapi.ClusterStackSpec{
+					Id: stackId,
+					BuildImage: buildapi.ClusterStackSpecImage{
+						Image: buildTag,
+					},
+					RunImage: buildapi.ClusterStackSpecImage{
+						Image: runTag,
+					},
+					Verification: policy,
+				})
+				require.NoError(t, err)
+
+				assert.Equal(t, []string{resolvedStack.BuildImage.LatestImage, resolvedStack.RunImage.LatestImage}, verified)
+			})
+
+			it("returns an error if the run image is not signed", func() {
+				_, runIdentifier, err := fakeClient.Fetch(expectedKeychain, runTag)
+				require.NoError(t, err)
+				unsigned = runIdentifier
+
+				_, err = remoteStackReader.Read(expectedKeychain, buildapi.ClusterStackSpec{
+					Id: stackId,
+					BuildImage: buildapi.ClusterStackSpecImage{
+						Image: buildTag,
+					},
+					RunImage: buildapi.ClusterStackSpecImage{
+						Image: runTag,
+					},
+					Verification: policy,
+				})
+				require.EqualError(t, err, "image "+runIdentifier+" is not signed by a trusted key or identity")
+				require.IsType(t, &cnb.VerificationError{}, err)
+			})
+		})
+
 		when("invalid", func() {
 			it("returns error if stack id does not match run image", func() {
 				runImage := runImage(t, "something.else", nil)
@@ -233,3 +295,9 @@ func buildImage(t *testing.T, stackId string, mixins []string) v1.Image {
 
 	return runImage
 }
+
+type verifierFunc func(keychain authn.Keychain, image string, policy buildapi.VerificationPolicy) error
+
+func (f verifierFunc) Verify(keychain authn.Keychain, image string, policy buildapi.VerificationPolicy) error {
+	return f(keychain, image, policy)
+}
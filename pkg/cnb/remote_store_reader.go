@@ -8,26 +8,36 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
 )
 
 type RemoteStoreReader struct {
 	RegistryClient RegistryClient
+	Verifier       ImageVerifier
 }
 
-func (r *RemoteStoreReader) Read(keychain authn.Keychain, storeImages []corev1alpha1.StoreImage) ([]corev1alpha1.StoreBuildpack, error) {
+// Read reads the buildpacks in the store images. When a verification policy is
+// provided every store image must be signed by a trusted key or identity.
+func (r *RemoteStoreReader) Read(keychain authn.Keychain, storeImages []corev1alpha1.StoreImage, policy *buildapi.VerificationPolicy) ([]corev1alpha1.StoreBuildpack, error) {
 	var g errgroup.Group
 
 	c := make(chan corev1alpha1.StoreBuildpack)
 	for _, storeImage := range storeImages {
 		storeImageCopy := storeImage
 		g.Go(func() error {
-			image, _, err := r.RegistryClient.Fetch(keychain, storeImageCopy.Image)
+			image, identifier, err := r.RegistryClient.Fetch(keychain, storeImageCopy.Image)
 			if err != nil {
 				return err
 			}
 
+			if policy != nil {
+				if err := r.Verifier.Verify(keychain, identifier, *policy); err != nil {
+					return &VerificationError{Err: err}
+				}
+			}
+
 			bpMetadata := BuildpackageMetadata{}
 			if ok, err := imagehelpers.HasLabel(image, buildpackageMetadataLabel); err != nil {
 				return err
@@ -9,6 +9,7 @@ import (
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
 
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
 	"github.com/pivotal/kpack/pkg/registry/registryfakes"
@@ -28,8 +29,10 @@ func testRemoteStoreReader(t *testing.T, when spec.G, it spec.S) {
 		var (
 			expectedKeychain  = authn.NewMultiKeychain(authn.DefaultKeychain)
 			fakeClient        = registryfakes.NewFakeClient()
+			fakeVerifier      = &fakeImageVerifier{}
 			remoteStoreReader = &RemoteStoreReader{
 				RegistryClient: fakeClient,
+				Verifier:       fakeVerifier,
 			}
 		)
 
@@ -212,7 +215,7 @@ func testRemoteStoreReader(t *testing.T, when spec.G, it spec.S) {
 				{
 					Image: buildpackageB,
 				},
-			})
+			}, nil)
 			require.NoError(t, err)
 
 			require.Len(t, storeBuildpacks, 4)
@@ -363,7 +366,7 @@ func testRemoteStoreReader(t *testing.T, when spec.G, it spec.S) {
 				{
 					Image: buildpackageB,
 				},
-			})
+			}, nil)
 			require.NoError(t, err)
 
 			for i := 1; i <= 50; i++ {
@@ -374,7 +377,7 @@ func testRemoteStoreReader(t *testing.T, when spec.G, it spec.S) {
 					{
 						Image: buildpackageB,
 					},
-				})
+				}, nil)
 				require.NoError(t, err)
 
 				require.Equal(t, expectedBuildpackOrder, subsequentOrder)
@@ -445,15 +448,68 @@ func testRemoteStoreReader(t *testing.T, when spec.G, it spec.S) {
 					Image: "image/with_duplicates",
 				},
 			}
-			expectedBuildpackOrder, err := remoteStoreReader.Read(expectedKeychain, images)
+			expectedBuildpackOrder, err := remoteStoreReader.Read(expectedKeychain, images, nil)
 			require.NoError(t, err)
 
 			for i := 1; i <= 50; i++ {
-				subsequentOrder, err := remoteStoreReader.Read(expectedKeychain, images)
+				subsequentOrder, err := remoteStoreReader.Read(expectedKeychain, images, nil)
 				require.NoError(t, err)
 
 				require.Equal(t, expectedBuildpackOrder, subsequentOrder)
 			}
 		})
+
+		when("a verification policy is provided", func() {
+			policy := &buildapi.VerificationPolicy{
+				PublicKeys: []string{"some-public-key"},
+			}
+
+			it("verifies the resolved store images", func() {
+				_, buildpackageAIdentifier, err := fakeClient.Fetch(expectedKeychain, buildpackageA)
+				require.NoError(t, err)
+				_, buildpackageBIdentifier, err := fakeClient.Fetch(expectedKeychain, buildpackageB)
+				require.NoError(t, err)
+
+				_, err = remoteStoreReader.Read(expectedKeychain, []corev1alpha1.StoreImage{
+					{
+						Image: buildpackageA,
+					},
+					{
+						Image: buildpackageB,
+					},
+				}, policy)
+				require.NoError(t, err)
+
+				require.ElementsMatch(t, []string{buildpackageAIdentifier, buildpackageBIdentifier}, fakeVerifier.verified)
+			})
+
+			it("returns an error if a store image is not signed", func() {
+				_, buildpackageBIdentifier, err := fakeClient.Fetch(expectedKeychain, buildpackageB)
+				require.NoError(t, err)
+				fakeVerifier.unsigned = map[string]bool{buildpackageBIdentifier: true}
+
+				_, err = remoteStoreReader.Read(expectedKeychain, []corev1alpha1.StoreImage{
+					{
+						Image: buildpackageA,
+					},
+					{
+						Image: buildpackageB,
+					},
+				}, policy)
+				require.EqualError(t, err, "image "+buildpackageBIdentifier+" is not signed by a trusted key or identity")
+				require.IsType(t, &VerificationError{}, err)
+			})
+		})
+
+		it("does not verify store images without a verification policy", func() {
+			_, err := remoteStoreReader.Read(expectedKeychain, []corev1alpha1.StoreImage{
+				{
+					Image: buildpackageA,
+				},
+			}, nil)
+			require.NoError(t, err)
+
+			require.Empty(t, fakeVerifier.verified)
+		})
 	})
 }
@@ -4,11 +4,13 @@ import (
 	"context"
 	"sync/atomic"
 
+	"github.com/ghodss/yaml"
 	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/cnb"
 	"github.com/pivotal/kpack/pkg/registry"
 	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
@@ -17,6 +19,7 @@ import (
 const (
 	LifecycleConfigName        = "lifecycle-image"
 	LifecycleConfigKey         = "image"
+	VerificationConfigKey      = "verification"
 	serviceAccountNameKey      = "serviceAccountRef.name"
 	serviceAccountNamespaceKey = "serviceAccountRef.namespace"
 	lifecycleMetadataLabel     = "io.buildpacks.lifecycle.metadata"
@@ -26,17 +29,23 @@ type RegistryClient interface {
 	Fetch(keychain authn.Keychain, repoName string) (v1.Image, string, error)
 }
 
+type ImageVerifier interface {
+	Verify(keychain authn.Keychain, image string, policy buildapi.VerificationPolicy) error
+}
+
 type LifecycleProvider struct {
 	registryClient  RegistryClient
 	keychainFactory registry.KeychainFactory
+	imageVerifier   ImageVerifier
 	lifecycleData   atomic.Value
 	handlers        []func()
 }
 
-func NewLifecycleProvider(client RegistryClient, keychainFactory registry.KeychainFactory) *LifecycleProvider {
+func NewLifecycleProvider(client RegistryClient, keychainFactory registry.KeychainFactory, imageVerifier ImageVerifier) *LifecycleProvider {
 	return &LifecycleProvider{
 		registryClient:  client,
 		keychainFactory: keychainFactory,
+		imageVerifier:   imageVerifier,
 	}
 }
 
@@ -89,11 +98,22 @@ func (l *LifecycleProvider) read(ctx context.Context, cm *corev1.ConfigMap) (*li
 		return nil, errors.Wrapf(err, "fetching keychain to read lifecycle")
 	}
 
-	img, _, err := l.registryClient.Fetch(keychain, imageRef)
+	policy, err := verificationPolicy(ctx, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	img, identifier, err := l.registryClient.Fetch(keychain, imageRef)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to fetch lifecycle image")
 	}
 
+	if policy != nil {
+		if err := l.imageVerifier.Verify(keychain, identifier, *policy); err != nil {
+			return nil, errors.Wrap(err, "failed to verify lifecycle image")
+		}
+	}
+
 	lifecycleMd := cnb.LifecycleMetadata{}
 	err = imagehelpers.GetLabel(img, lifecycleMetadataLabel, &lifecycleMd)
 	if err != nil {
@@ -124,6 +144,23 @@ func (l *LifecycleProvider) read(ctx context.Context, cm *corev1.ConfigMap) (*li
 	}, nil
 }
 
+func verificationPolicy(ctx context.Context, cm *corev1.ConfigMap) (*buildapi.VerificationPolicy, error) {
+	data, ok := cm.Data[VerificationConfigKey]
+	if !ok {
+		return nil, nil
+	}
+
+	policy := &buildapi.VerificationPolicy{}
+	if err := yaml.Unmarshal([]byte(data), policy); err != nil {
+		return nil, errors.Wrapf(err, "%s config invalid", LifecycleConfigName)
+	}
+
+	if err := policy.Validate(ctx).ViaField(VerificationConfigKey); err != nil {
+		return nil, errors.Wrapf(err, "%s config invalid", LifecycleConfigName)
+	}
+	return policy, nil
+}
+
 func lifecycleLayerForOS(imageRef string, image v1.Image, os string) (*lifecycleLayer, error) {
 	diffId, err := imagehelpers.GetStringLabel(image, os)
 	if err != nil {
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/cnb"
 	"github.com/pivotal/kpack/pkg/registry"
 	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
@@ -52,6 +54,7 @@ func testProvider(t *testing.T, when spec.G, it spec.S) {
 		windowsLayer    v1.Layer
 		callBack        *fakeCallback
 		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		imageVerifier   = &fakeImageVerifier{}
 		p               *LifecycleProvider
 	)
 
@@ -64,7 +67,7 @@ func testProvider(t *testing.T, when spec.G, it spec.S) {
 		client.AddImage(lifecycleImgRef, lifecycleImg, keychain)
 		client.AddImage("some-other-lifecycle-image", generateLifecycleImage(t, lifecycleMetadata, testLayer(t), testLayer(t)), keychain)
 
-		p = NewLifecycleProvider(client, keychainFactory)
+		p = NewLifecycleProvider(client, keychainFactory, imageVerifier)
 		callBack = &fakeCallback{}
 		p.AddEventHandler(callBack.callBack)
 	})
@@ -116,6 +119,58 @@ func testProvider(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("the ConfigMap has a verification policy", func() {
+		const verification = `keyless:
+- issuer: https://token.actions.githubusercontent.com
+  subject: https://github.com/buildpacks/lifecycle/.github/workflows/build.yml@refs/heads/main
+`
+
+		it("verifies the resolved lifecycle image", func() {
+			p.UpdateImage(&corev1.ConfigMap{
+				Data: map[string]string{"image": lifecycleImgRef, "verification": verification, "serviceAccountRef.name": "some-service-account", "serviceAccountRef.namespace": "some-service-account-namespace"},
+			})
+
+			_, _, err := p.LayerForOS("linux")
+			require.NoError(t, err)
+
+			_, identifier, err := client.Fetch(keychain, lifecycleImgRef)
+			require.NoError(t, err)
+
+			require.Equal(t, []string{identifier}, imageVerifier.verified)
+			require.Equal(t, keychain, imageVerifier.keychain)
+			require.Equal(t, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{
+						Issuer:  "https://token.actions.githubusercontent.com",
+						Subject: "https://github.com/buildpacks/lifecycle/.github/workflows/build.yml@refs/heads/main",
+					},
+				},
+			}, imageVerifier.policy)
+		})
+
+		it("returns an error if the lifecycle image is not signed", func() {
+			imageVerifier.err = errors.New("image some-image is not signed by a trusted key or identity")
+
+			p.UpdateImage(&corev1.ConfigMap{
+				Data: map[string]string{"image": lifecycleImgRef, "verification": verification, "serviceAccountRef.name": "some-service-account", "serviceAccountRef.namespace": "some-service-account-namespace"},
+			})
+			require.Equal(t, callBack.called, 0)
+
+			_, _, err := p.LayerForOS("linux")
+			require.EqualError(t, err, "failed to verify lifecycle image: image some-image is not signed by a trusted key or identity")
+		})
+
+		it("returns an error if the verification policy is invalid", func() {
+			p.UpdateImage(&corev1.ConfigMap{
+				Data: map[string]string{"image": lifecycleImgRef, "verification": "publicKeys: []", "serviceAccountRef.name": "some-service-account", "serviceAccountRef.namespace": "some-service-account-namespace"},
+			})
+
+			_, _, err := p.LayerForOS("linux")
+			require.EqualError(t, err, "lifecycle-image config invalid: expected exactly one, got neither: verification.keyless, verification.publicKeys")
+			require.Empty(t, imageVerifier.verified)
+		})
+	})
+
 	when("LayerForOS()", func() {
 		it.Before(func() {
 			p.UpdateImage(&corev1.ConfigMap{
@@ -183,6 +238,20 @@ func testProvider(t *testing.T, when spec.G, it spec.S) {
 	})
 }
 
+type fakeImageVerifier struct {
+	verified []string
+	keychain authn.Keychain
+	policy   buildapi.VerificationPolicy
+	err      error
+}
+
+func (f *fakeImageVerifier) Verify(keychain authn.Keychain, image string, policy buildapi.VerificationPolicy) error {
+	f.verified = append(f.verified, image)
+	f.keychain = keychain
+	f.policy = policy
+	return f.err
+}
+
 type fakeCallback struct {
 	called int
 }
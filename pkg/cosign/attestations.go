@@ -0,0 +1,320 @@
+package cosign
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	slsa "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v0.2"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+
+	"github.com/pivotal/kpack/pkg/registry/imagehelpers"
+)
+
+const (
+	ProvenanceBuilderID = "https://kpack.io"
+	ProvenanceBuildType = "https://kpack.io/Build@v1alpha2"
+
+	CycloneDXPredicateType = "https://cyclonedx.org/bom"
+
+	SBOMFormatSPDX      = "spdx"
+	SBOMFormatCycloneDX = "cyclonedx"
+)
+
+// sbomFiles are the names the lifecycle gives bill of materials documents
+// in the sbom layer of an image
+var sbomFiles = map[string]string{
+	SBOMFormatSPDX:      "sbom.spdx.json",
+	SBOMFormatCycloneDX: "sbom.cdx.json",
+}
+
+var sbomPredicateTypes = map[string]string{
+	SBOMFormatSPDX:      options.PredicateSPDX,
+	SBOMFormatCycloneDX: CycloneDXPredicateType,
+}
+
+// ProvenanceSource is the resolved source of a build. The provenance may be
+// published to a public transparency log so urls are recorded without
+// credentials.
+type ProvenanceSource struct {
+	GitURL         string
+	GitRevision    string
+	BlobURL        string
+	BlobSHA256     string
+	RegistryImage  string
+	S3Bucket       string
+	S3Key          string
+	S3Endpoint     string
+	S3Version      string
+	CustomKind     string
+	CustomEndpoint string
+	CustomVersion  string
+}
+
+// BuildProvenance is what kpack knows about a build outside of the built image
+type BuildProvenance struct {
+	BuildInvocationID string
+	BuilderImage      string
+	BuildChanges      string
+	Source            ProvenanceSource
+	FinishedOn        time.Time
+}
+
+type provenanceBuildConfig struct {
+	Buildpacks []provenanceBuildpack `json:"buildpacks"`
+	Stack      string                `json:"stack,omitempty"`
+	RunImage   string                `json:"runImage,omitempty"`
+}
+
+type provenanceBuildpack struct {
+	ID      string `json:"id"`
+	Version string `json:"version,omitempty"`
+}
+
+// Attestation creates a SLSA provenance predicate for the built image
+func (p BuildProvenance) Attestation(image ggcrv1.Image) (Attestation, error) {
+	var buildMetadata platform.BuildMetadata
+	if err := imagehelpers.GetLabel(image, platform.BuildMetadataLabel, &buildMetadata); err != nil {
+		return Attestation{}, err
+	}
+
+	var layersMetadata platform.LayersMetadata
+	if err := imagehelpers.GetLabel(image, platform.LayerMetadataLabel, &layersMetadata); err != nil {
+		return Attestation{}, err
+	}
+
+	stackID, err := imagehelpers.GetStringLabel(image, platform.StackIDLabel)
+	if err != nil {
+		return Attestation{}, err
+	}
+
+	buildConfig := provenanceBuildConfig{
+		Buildpacks: make([]provenanceBuildpack, 0, len(buildMetadata.Buildpacks)),
+		Stack:      stackID,
+		RunImage:   layersMetadata.RunImage.Reference,
+	}
+	for _, bp := range buildMetadata.Buildpacks {
+		buildConfig.Buildpacks = append(buildConfig.Buildpacks, provenanceBuildpack{ID: bp.ID, Version: bp.Version})
+	}
+
+	materials := p.Source.materials()
+	for _, ref := range []string{p.BuilderImage, layersMetadata.RunImage.Reference} {
+		if material, ok := imageMaterial(ref); ok {
+			materials = append(materials, material)
+		}
+	}
+
+	finishedOn := p.FinishedOn.UTC()
+	predicate, err := json.Marshal(slsa.ProvenancePredicate{
+		Builder: slsa.ProvenanceBuilder{
+			ID: ProvenanceBuilderID,
+		},
+		BuildType: ProvenanceBuildType,
+		Invocation: slsa.ProvenanceInvocation{
+			Parameters: map[string]interface{}{
+				"buildChanges": buildChanges(p.BuildChanges),
+			},
+		},
+		BuildConfig: buildConfig,
+		Metadata: &slsa.ProvenanceMetadata{
+			BuildInvocationID: p.BuildInvocationID,
+			BuildFinishedOn:   &finishedOn,
+		},
+		Materials: materials,
+	})
+	if err != nil {
+		return Attestation{}, err
+	}
+
+	return Attestation{
+		PredicateType: options.PredicateSLSA,
+		Predicate:     predicate,
+	}, nil
+}
+
+func (s ProvenanceSource) materials() []slsa.ProvenanceMaterial {
+	switch {
+	case s.GitURL != "":
+		return []slsa.ProvenanceMaterial{{
+			URI:    "git+" + redactURL(s.GitURL),
+			Digest: digestSet("sha1", s.GitRevision),
+		}}
+	case s.BlobURL != "":
+		return []slsa.ProvenanceMaterial{{
+			URI:    redactURL(s.BlobURL),
+			Digest: digestSet("sha256", s.BlobSHA256),
+		}}
+	case s.S3Bucket != "":
+		uri := "s3://" + s.S3Bucket + "/" + s.S3Key
+		if s.S3Endpoint != "" {
+			uri = strings.TrimSuffix(redactURL(s.S3Endpoint), "/") + "/" + s.S3Bucket + "/" + s.S3Key
+		}
+		// the version id of the object, or its etag in buckets without versioning
+		return []slsa.ProvenanceMaterial{{
+			URI:    uri,
+			Digest: digestSet("s3version", s.S3Version),
+		}}
+	case s.CustomKind != "":
+		// the opaque version reported by the source resolver of the kind
+		return []slsa.ProvenanceMaterial{{
+			URI:    redactURL(s.CustomEndpoint) + "#" + s.CustomKind,
+			Digest: digestSet("customversion", s.CustomVersion),
+		}}
+	case s.RegistryImage != "":
+		if material, ok := imageMaterial(s.RegistryImage); ok {
+			return []slsa.ProvenanceMaterial{material}
+		}
+		return []slsa.ProvenanceMaterial{{
+			URI: s.RegistryImage,
+		}}
+	default:
+		return nil
+	}
+}
+
+func digestSet(algorithm, digest string) slsa.DigestSet {
+	if digest == "" {
+		return nil
+	}
+	return slsa.DigestSet{algorithm: digest}
+}
+
+// redactURL removes the userinfo, query and fragment of a url which may hold
+// credentials such as tokens or presigned signatures
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Opaque != "" {
+		// scp like git urls such as git@github.com:org/repo.git
+		rawURL = rawURL[strings.LastIndex(rawURL, "@")+1:]
+		if i := strings.IndexAny(rawURL, "?#"); i >= 0 {
+			rawURL = rawURL[:i]
+		}
+		return rawURL
+	}
+
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+func imageMaterial(ref string) (slsa.ProvenanceMaterial, bool) {
+	digest, err := name.NewDigest(ref, name.WeakValidation)
+	if err != nil {
+		return slsa.ProvenanceMaterial{}, false
+	}
+
+	hash, err := ggcrv1.NewHash(digest.DigestStr())
+	if err != nil {
+		return slsa.ProvenanceMaterial{}, false
+	}
+
+	return slsa.ProvenanceMaterial{
+		URI:    digest.Context().Name(),
+		Digest: slsa.DigestSet{hash.Algorithm: hash.Hex},
+	}, true
+}
+
+// buildChanges keeps the build changes as structured json when possible
+func buildChanges(changes string) interface{} {
+	if json.Valid([]byte(changes)) {
+		return json.RawMessage(changes)
+	}
+	return changes
+}
+
+// SBOMAttestations reads the bill of materials documents of the requested
+// formats from the sbom layer exported by the lifecycle
+func SBOMAttestations(image ggcrv1.Image, formats []string) ([]Attestation, error) {
+	if len(formats) == 0 {
+		return nil, nil
+	}
+
+	var layersMetadata platform.LayersMetadata
+	if err := imagehelpers.GetLabel(image, platform.LayerMetadataLabel, &layersMetadata); err != nil {
+		return nil, err
+	}
+
+	if layersMetadata.BOM == nil || layersMetadata.BOM.SHA == "" {
+		return nil, errors.New("image has no sbom layer, the builder lifecycle must support platform api 0.8 or later")
+	}
+
+	diffID, err := ggcrv1.NewHash(layersMetadata.BOM.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := image.LayerByDiffID(diffID)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading sbom layer")
+	}
+
+	documents, err := sbomDocuments(layer)
+	if err != nil {
+		return nil, err
+	}
+
+	var attestations []Attestation
+	for _, format := range formats {
+		fileName, ok := sbomFiles[format]
+		if !ok {
+			return nil, errors.Errorf("unsupported sbom format %q", format)
+		}
+
+		for _, document := range documents[fileName] {
+			attestations = append(attestations, Attestation{
+				PredicateType: sbomPredicateTypes[format],
+				Predicate:     document,
+			})
+		}
+	}
+
+	return attestations, nil
+}
+
+func sbomDocuments(layer ggcrv1.Layer) (map[string][][]byte, error) {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	documents := map[string][][]byte{}
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return documents, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "reading sbom layer")
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		fileName := path.Base(header.Name)
+		for _, sbomFile := range sbomFiles {
+			if fileName != sbomFile {
+				continue
+			}
+
+			document, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			documents[fileName] = append(documents[fileName], document)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package cosign
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"time"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/cmd/cosign/cli/sign"
+)
+
+type AttestFunc func(
+	ctx context.Context, ko sign.KeyOpts, regOpts options.RegistryOptions, imageRef string, certPath string,
+	noUpload bool, predicatePath string, force bool, predicateType string, replace bool, timeout time.Duration,
+) error
+
+// Attestation is an in-toto predicate to attach to an image. PredicateType is
+// either a cosign predicate type such as slsaprovenance or a predicate uri.
+type Attestation struct {
+	PredicateType string
+	Predicate     []byte
+}
+
+type ImageAttester struct {
+	Logger     *log.Logger
+	attestFunc AttestFunc
+}
+
+func NewImageAttester(logger *log.Logger, attestFunc AttestFunc) *ImageAttester {
+	return &ImageAttester{
+		Logger:     logger,
+		attestFunc: attestFunc,
+	}
+}
+
+func (a *ImageAttester) Attest(ctx context.Context, report platform.ExportReport, secretLocation string, attestations []Attestation, cosignRepositories, cosignDockerMediaTypes map[string]interface{}) error {
+	cosignSecrets, err := findCosignSecrets(secretLocation)
+	if err != nil {
+		return errors.Errorf("no keys found for cosign attestation: %v", err)
+	}
+
+	if len(cosignSecrets) == 0 {
+		return errors.New("no keys found for cosign attestation")
+	}
+
+	if len(report.Image.Tags) == 0 {
+		return errors.New("no image found in report to attest")
+	}
+
+	for _, cosignSecret := range cosignSecrets {
+		ko := secretKeyOpts(secretLocation, cosignSecret)
+
+		if err := a.attestWithSecret(ctx, ko, report.Image.Tags[0], cosignSecret, attestations, cosignRepositories, cosignDockerMediaTypes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *ImageAttester) AttestKeyless(ctx context.Context, report platform.ExportReport, keyless KeylessOptions, attestations []Attestation) error {
+	if len(report.Image.Tags) == 0 {
+		return errors.New("no image found in report to attest")
+	}
+
+	ko, err := keylessKeyOpts(keyless)
+	if err != nil {
+		return err
+	}
+
+	unsetEnv, err := setKeylessEnv()
+	if err != nil {
+		return err
+	}
+	defer unsetEnv()
+
+	for _, attestation := range attestations {
+		if err := a.attest(ctx, ko, report.Image.Tags[0], attestation, true); err != nil {
+			return errors.Errorf("unable to attest image with keyless signing: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *ImageAttester) attestWithSecret(ctx context.Context, ko sign.KeyOpts, refImage, cosignSecret string, attestations []Attestation, cosignRepositories, cosignDockerMediaTypes map[string]interface{}) error {
+	unsetEnv, err := setSecretEnv(cosignSecret, cosignRepositories, cosignDockerMediaTypes)
+	if err != nil {
+		return err
+	}
+	defer unsetEnv()
+
+	for _, attestation := range attestations {
+		if err := a.attest(ctx, ko, refImage, attestation, false); err != nil {
+			return errors.Errorf("unable to attest image with %s: %v", ko.KeyRef, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *ImageAttester) attest(ctx context.Context, ko sign.KeyOpts, refImage string, attestation Attestation, force bool) error {
+	predicateFile, err := ioutil.TempFile("", "predicate")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(predicateFile.Name())
+
+	if _, err := predicateFile.Write(attestation.Predicate); err != nil {
+		predicateFile.Close()
+		return err
+	}
+	if err := predicateFile.Close(); err != nil {
+		return err
+	}
+
+	a.Logger.Printf("Attaching %s attestation to %s", attestation.PredicateType, refImage)
+
+	return a.attestFunc(
+		ctx,
+		ko,
+		options.RegistryOptions{},
+		refImage,
+		"",
+		false,
+		predicateFile.Name(),
+		force,
+		attestation.PredicateType,
+		false,
+		0)
+}
@@ -0,0 +1,216 @@
+package cosign
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sclevine/spec"
+	"github.com/sigstore/cosign/cmd/cosign/cli/attest"
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/cmd/cosign/cli/sign"
+	verifypkg "github.com/sigstore/cosign/cmd/cosign/cli/verify"
+	ociremote "github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageAttester(t *testing.T) {
+	spec.Run(t, "Test Cosign Image Attester", testImageAttester)
+}
+
+func testImageAttester(t *testing.T, when spec.G, it spec.S) {
+	var (
+		report            platform.ExportReport
+		reader            *os.File
+		writer            *os.File
+		expectedImageName string
+		stopRegistry      func()
+		imageCleanup      func()
+		repo              string
+		secretLocation    string
+		testCtx           context.Context
+		testCtxCancel     context.CancelFunc
+	)
+
+	provenance := Attestation{
+		PredicateType: options.PredicateSLSA,
+		Predicate:     []byte(`{"builder":{"id":"https://kpack.io"},"buildType":"https://kpack.io/Build@v1alpha2"}`),
+	}
+
+	cycloneDX := Attestation{
+		PredicateType: CycloneDXPredicateType,
+		Predicate:     []byte(`{"bomFormat":"CycloneDX","specVersion":"1.3"}`),
+	}
+
+	it.Before(func() {
+		testCtx, testCtxCancel = context.WithTimeout(context.Background(), time.Minute)
+		_, reader, writer = mockLogger(t)
+		repo, stopRegistry = reg(t)
+
+		expectedImageName = path.Join(repo, "test-cosign-image")
+		imageCleanup = pushRandomImage(t, expectedImageName)
+
+		secretLocation = createCosignKeyFiles(t)
+		report = createReportToml(t, expectedImageName)
+
+		os.Unsetenv(cosignRepositoryEnv)
+		os.Unsetenv(cosignDockerMediaTypesEnv)
+	})
+
+	it.After(func() {
+		testCtxCancel()
+		stopRegistry()
+		imageCleanup()
+		resetLogger(reader, writer)
+	})
+
+	when("#Attest", func() {
+		it("attaches the attestations with every key", func() {
+			var predicateTypes []string
+			attestCmd := func(
+				ctx context.Context, ko sign.KeyOpts, regOpts options.RegistryOptions, imageRef string, certPath string,
+				noUpload bool, predicatePath string, force bool, predicateType string, replace bool, timeout time.Duration,
+			) error {
+				t.Helper()
+				assert.Equal(t, testCtx, ctx)
+				assert.Equal(t, expectedImageName, imageRef)
+				assert.Contains(t, ko.KeyRef, secretLocation)
+				assert.False(t, noUpload)
+				assert.False(t, force)
+
+				predicateTypes = append(predicateTypes, predicateType)
+				return attest.AttestCmd(ctx, ko, regOpts, imageRef, certPath, noUpload, predicatePath, force, predicateType, replace, timeout)
+			}
+
+			attester := NewImageAttester(log.New(writer, "", 0), attestCmd)
+			err := attester.Attest(testCtx, report, secretLocation, []Attestation{provenance, cycloneDX}, nil, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{options.PredicateSLSA, CycloneDXPredicateType, options.PredicateSLSA, CycloneDXPredicateType}, predicateTypes)
+
+			for _, secret := range []string{"secret-name-1", "secret-name-2"} {
+				verifyCmd := verifypkg.VerifyAttestationCommand{
+					KeyRef:        path.Join(secretLocation, secret, "cosign.pub"),
+					PredicateType: options.PredicateSLSA,
+					CheckClaims:   true,
+				}
+				require.NoError(t, verifyCmd.Exec(testCtx, []string{expectedImageName}))
+			}
+
+			ref, err := name.ParseReference(expectedImageName)
+			require.NoError(t, err)
+
+			signedEntity, err := ociremote.SignedEntity(ref, ociremote.WithRemoteOptions(registryClientOpts(testCtx)...))
+			require.NoError(t, err)
+
+			attestations, err := signedEntity.Attestations()
+			require.NoError(t, err)
+
+			signatures, err := attestations.Get()
+			require.NoError(t, err)
+			assert.Len(t, signatures, 4)
+		})
+
+		it("sets COSIGN_REPOSITORY environment variable", func() {
+			attestCmd := func(
+				ctx context.Context, ko sign.KeyOpts, regOpts options.RegistryOptions, imageRef string, certPath string,
+				noUpload bool, predicatePath string, force bool, predicateType string, replace bool, timeout time.Duration,
+			) error {
+				if ko.KeyRef == path.Join(secretLocation, "secret-name-1", "cosign.key") {
+					assert.Equal(t, "registry.example.com/fakeProject", os.Getenv(cosignRepositoryEnv))
+				} else {
+					assertUnset(t, cosignRepositoryEnv)
+				}
+				return nil
+			}
+
+			cosignRepositories := map[string]interface{}{
+				"secret-name-1": "registry.example.com/fakeProject",
+			}
+
+			attester := NewImageAttester(log.New(writer, "", 0), attestCmd)
+			err := attester.Attest(testCtx, report, secretLocation, []Attestation{provenance}, cosignRepositories, nil)
+			require.NoError(t, err)
+
+			assertUnset(t, cosignRepositoryEnv)
+		})
+
+		it("errors when attesting fails", func() {
+			attestCmd := func(
+				ctx context.Context, ko sign.KeyOpts, regOpts options.RegistryOptions, imageRef string, certPath string,
+				noUpload bool, predicatePath string, force bool, predicateType string, replace bool, timeout time.Duration,
+			) error {
+				return errors.New("fake attest error")
+			}
+
+			attester := NewImageAttester(log.New(writer, "", 0), attestCmd)
+			err := attester.Attest(testCtx, report, secretLocation, []Attestation{provenance}, nil, nil)
+			assert.EqualError(t, err, "unable to attest image with "+path.Join(secretLocation, "secret-name-1", "cosign.key")+": fake attest error")
+		})
+
+		it("errors without cosign secrets", func() {
+			attester := NewImageAttester(log.New(writer, "", 0), nil)
+			err := attester.Attest(testCtx, report, t.TempDir(), []Attestation{provenance}, nil, nil)
+			assert.EqualError(t, err, "no keys found for cosign attestation")
+		})
+
+		it("has no image.Tags in report", func() {
+			attester := NewImageAttester(log.New(writer, "", 0), nil)
+			err := attester.Attest(testCtx, createEmptyReportToml(t), secretLocation, []Attestation{provenance}, nil, nil)
+			assert.EqualError(t, err, "no image found in report to attest")
+		})
+	})
+
+	when("#AttestKeyless", func() {
+		var tokenPath string
+
+		it.Before(func() {
+			tokenPath = path.Join(t.TempDir(), "oidc-token")
+			require.NoError(t, os.WriteFile(tokenPath, []byte("some-token\n"), 0600))
+		})
+
+		it("attests with the identity token and uploads to rekor", func() {
+			attestCmd := func(
+				ctx context.Context, ko sign.KeyOpts, regOpts options.RegistryOptions, imageRef string, certPath string,
+				noUpload bool, predicatePath string, force bool, predicateType string, replace bool, timeout time.Duration,
+			) error {
+				assert.Equal(t, "some-token", ko.IDToken)
+				assert.Equal(t, "https://fulcio.example.com", ko.FulcioURL)
+				assert.Equal(t, "https://rekor.example.com", ko.RekorURL)
+				assert.Equal(t, "1", os.Getenv(cosignExperimentalEnv))
+				assert.True(t, force)
+
+				predicate, err := os.ReadFile(predicatePath)
+				require.NoError(t, err)
+				assert.Equal(t, cycloneDX.Predicate, predicate)
+				return nil
+			}
+
+			attester := NewImageAttester(log.New(writer, "", 0), attestCmd)
+			err := attester.AttestKeyless(testCtx, report, KeylessOptions{
+				FulcioURL:   "https://fulcio.example.com",
+				RekorURL:    "https://rekor.example.com",
+				IDTokenPath: tokenPath,
+			}, []Attestation{cycloneDX})
+			require.NoError(t, err)
+
+			assertUnset(t, cosignExperimentalEnv)
+		})
+
+		it("errors without an identity token", func() {
+			attester := NewImageAttester(log.New(writer, "", 0), nil)
+			err := attester.AttestKeyless(testCtx, report, KeylessOptions{
+				IDTokenPath: path.Join(t.TempDir(), "missing"),
+			}, []Attestation{cycloneDX})
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "no identity token found for keyless signing")
+		})
+	})
+}
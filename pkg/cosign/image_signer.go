@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/pkg/errors"
@@ -24,9 +25,17 @@ type ImageSigner struct {
 	signFunc SignFunc
 }
 
+type KeylessOptions struct {
+	FulcioURL   string
+	RekorURL    string
+	OIDCIssuer  string
+	IDTokenPath string
+}
+
 const (
 	cosignRepositoryEnv       = "COSIGN_REPOSITORY"
 	cosignDockerMediaTypesEnv = "COSIGN_DOCKER_MEDIA_TYPES"
+	cosignExperimentalEnv     = "COSIGN_EXPERIMENTAL"
 )
 
 func NewImageSigner(logger *log.Logger, signFunc SignFunc) *ImageSigner {
@@ -61,11 +70,76 @@ func (s *ImageSigner) Sign(ctx context.Context, report platform.ExportReport, se
 	return nil
 }
 
+func (s *ImageSigner) SignKeyless(ctx context.Context, report platform.ExportReport, keyless KeylessOptions, annotations map[string]interface{}) error {
+	if len(report.Image.Tags) == 0 {
+		return errors.New("no image found in report to sign")
+	}
+
+	ko, err := keylessKeyOpts(keyless)
+	if err != nil {
+		return err
+	}
+
+	unsetEnv, err := setKeylessEnv()
+	if err != nil {
+		return err
+	}
+	defer unsetEnv()
+
+	if err := s.signFunc(
+		ctx,
+		ko,
+		options.RegistryOptions{},
+		annotations,
+		[]string{report.Image.Tags[0]},
+		"",
+		true,
+		"",
+		"",
+		"",
+		true,
+		false,
+		""); err != nil {
+		return errors.Errorf("unable to sign image with keyless signing: %v", err)
+	}
+
+	return nil
+}
+
 func (s *ImageSigner) sign(ctx context.Context, refImage, secretLocation, cosignSecret string, annotations, cosignRepositories, cosignDockerMediaTypes map[string]interface{}) error {
+	ko := secretKeyOpts(secretLocation, cosignSecret)
+
+	unsetEnv, err := setSecretEnv(cosignSecret, cosignRepositories, cosignDockerMediaTypes)
+	if err != nil {
+		return err
+	}
+	defer unsetEnv()
+
+	if err := s.signFunc(
+		ctx,
+		ko,
+		options.RegistryOptions{},
+		annotations,
+		[]string{refImage},
+		"",
+		true,
+		"",
+		"",
+		"",
+		false,
+		false,
+		""); err != nil {
+		return errors.Errorf("unable to sign image with %s: %v", ko.KeyRef, err)
+	}
+
+	return nil
+}
+
+func secretKeyOpts(secretLocation, cosignSecret string) sign.KeyOpts {
 	cosignKeyFile := fmt.Sprintf("%s/%s/cosign.key", secretLocation, cosignSecret)
 	cosignPasswordFile := fmt.Sprintf("%s/%s/cosign.password", secretLocation, cosignSecret)
 
-	ko := sign.KeyOpts{KeyRef: cosignKeyFile, PassFunc: func(bool) ([]byte, error) {
+	return sign.KeyOpts{KeyRef: cosignKeyFile, PassFunc: func(bool) ([]byte, error) {
 		content, err := ioutil.ReadFile(cosignPasswordFile)
 		// When password file is not available, default empty password is used
 		if err != nil {
@@ -74,39 +148,57 @@ func (s *ImageSigner) sign(ctx context.Context, refImage, secretLocation, cosign
 
 		return content, nil
 	}}
+}
+
+// setSecretEnv applies the registry settings annotated on a cosign secret and
+// returns a func to restore the environment
+func setSecretEnv(cosignSecret string, cosignRepositories, cosignDockerMediaTypes map[string]interface{}) (func(), error) {
+	var envs []string
+	unsetEnv := func() {
+		for _, env := range envs {
+			os.Unsetenv(env)
+		}
+	}
 
 	if cosignRepository, ok := cosignRepositories[cosignSecret]; ok {
 		if err := os.Setenv(cosignRepositoryEnv, fmt.Sprintf("%s", cosignRepository)); err != nil {
-			return errors.Errorf("failed setting %s env variable: %v", cosignRepositoryEnv, err)
+			return nil, errors.Errorf("failed setting %s env variable: %v", cosignRepositoryEnv, err)
 		}
-		defer os.Unsetenv(cosignRepositoryEnv)
+		envs = append(envs, cosignRepositoryEnv)
 	}
 
 	if cosignDockerMediaType, ok := cosignDockerMediaTypes[cosignSecret]; ok {
 		if err := os.Setenv(cosignDockerMediaTypesEnv, fmt.Sprintf("%s", cosignDockerMediaType)); err != nil {
-			return errors.Errorf("failed setting COSIGN_DOCKER_MEDIA_TYPES env variable: %v", err)
+			unsetEnv()
+			return nil, errors.Errorf("failed setting COSIGN_DOCKER_MEDIA_TYPES env variable: %v", err)
 		}
-		defer os.Unsetenv(cosignDockerMediaTypesEnv)
+		envs = append(envs, cosignDockerMediaTypesEnv)
 	}
 
-	if err := s.signFunc(
-		ctx,
-		ko,
-		options.RegistryOptions{},
-		annotations,
-		[]string{refImage},
-		"",
-		true,
-		"",
-		"",
-		"",
-		false,
-		false,
-		""); err != nil {
-		return errors.Errorf("unable to sign image with %s: %v", cosignKeyFile, err)
+	return unsetEnv, nil
+}
+
+func keylessKeyOpts(keyless KeylessOptions) (sign.KeyOpts, error) {
+	idToken, err := ioutil.ReadFile(keyless.IDTokenPath)
+	if err != nil {
+		return sign.KeyOpts{}, errors.Errorf("no identity token found for keyless signing: %v", err)
 	}
 
-	return nil
+	return sign.KeyOpts{
+		FulcioURL:  keyless.FulcioURL,
+		RekorURL:   keyless.RekorURL,
+		OIDCIssuer: keyless.OIDCIssuer,
+		IDToken:    strings.TrimSpace(string(idToken)),
+	}, nil
+}
+
+// setKeylessEnv enables keyless signing and uploads to rekor which are
+// experimental in cosign
+func setKeylessEnv() (func(), error) {
+	if err := os.Setenv(cosignExperimentalEnv, "1"); err != nil {
+		return nil, errors.Errorf("failed setting %s env variable: %v", cosignExperimentalEnv, err)
+	}
+	return func() { os.Unsetenv(cosignExperimentalEnv) }, nil
 }
 
 func findCosignSecrets(secretLocation string) ([]string, error) {
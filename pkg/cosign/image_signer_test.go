@@ -3,9 +3,19 @@ package cosign
 import (
 	"bufio"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math/big"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
@@ -17,6 +27,9 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/platform"
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/registry"
@@ -30,8 +43,10 @@ import (
 	sigstoreCosign "github.com/sigstore/cosign/pkg/cosign"
 	ociremote "github.com/sigstore/cosign/pkg/oci/remote"
 	"github.com/sigstore/cosign/pkg/signature"
+	fulcioapi "github.com/sigstore/fulcio/pkg/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	tufleveldbstore "github.com/theupdateframework/go-tuf/client/leveldbstore"
 )
 
 func TestImageSigner(t *testing.T) {
@@ -503,6 +518,158 @@ func testImageSigner(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#SignKeyless", func() {
+		var (
+			oidcIssuer *httptest.Server
+			fulcio     *fulcioStandIn
+			rekor      *rekorStandIn
+			tokenPath  string
+			idToken    string
+		)
+
+		it.Before(func() {
+			oidcIssuer = oidcStandIn(t)
+			fulcio = newFulcioStandIn(t)
+			rekor = newRekorStandIn(t)
+
+			idToken = jwt(t, oidcIssuer.URL, keylessSubject)
+			tokenPath = filepath.Join(t.TempDir(), "oidc-token")
+			require.NoError(t, ioutil.WriteFile(tokenPath, []byte(idToken), 0600))
+
+			require.NoError(t, os.Setenv("SIGSTORE_CT_LOG_PUBLIC_KEY_FILE", fulcio.ctPublicKeyPath))
+			require.NoError(t, os.Setenv("TUF_ROOT", tufRoot(t)))
+
+			report = createReportToml(t, expectedImageName)
+		})
+
+		it.After(func() {
+			oidcIssuer.Close()
+			fulcio.Close()
+			rekor.Close()
+			os.Unsetenv("SIGSTORE_CT_LOG_PUBLIC_KEY_FILE")
+			os.Unsetenv("TUF_ROOT")
+		})
+
+		it("signs images with a certificate issued to the identity token", func() {
+			annotations := map[string]interface{}{"annotationKey1": "value1"}
+
+			cliSignCmdCallCount := 0
+			cliSignCmd := func(
+				ctx context.Context, ko sign.KeyOpts, registryOptions options.RegistryOptions, annotations map[string]interface{},
+				imageRef []string, certPath string, upload bool, outputSignature, outputCertificate string,
+				payloadPath string, force, recursive bool, attachment string,
+			) error {
+				t.Helper()
+				assert.Equal(t, testCtx, ctx)
+				assert.Equal(t, []string{expectedImageName}, imageRef)
+				assert.Empty(t, ko.KeyRef)
+				assert.Equal(t, fulcio.URL, ko.FulcioURL)
+				assert.Equal(t, rekor.URL, ko.RekorURL)
+				assert.Equal(t, idToken, ko.IDToken)
+				assert.Equal(t, "1", os.Getenv(cosignExperimentalEnv))
+				cliSignCmdCallCount++
+				return sign.SignCmd(
+					ctx,
+					ko,
+					registryOptions,
+					annotations,
+					imageRef,
+					certPath,
+					upload,
+					outputSignature,
+					outputCertificate,
+					payloadPath,
+					force,
+					recursive,
+					attachment)
+			}
+
+			signer := NewImageSigner(log.New(writer, "", 0), cliSignCmd)
+			err := signer.SignKeyless(testCtx, report, KeylessOptions{
+				FulcioURL:   fulcio.URL,
+				RekorURL:    rekor.URL,
+				OIDCIssuer:  oidcIssuer.URL,
+				IDTokenPath: tokenPath,
+			}, annotations)
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, cliSignCmdCallCount)
+			assertUnset(t, cosignExperimentalEnv)
+			assert.Equal(t, []string{"Bearer " + idToken}, fulcio.authorizations)
+			assert.Equal(t, 1, rekor.entries)
+
+			ref, err := name.ParseReference(expectedImageName)
+			require.NoError(t, err)
+			signedEntity, err := ociremote.SignedEntity(ref)
+			require.NoError(t, err)
+			signatures, err := signedEntity.Signatures()
+			require.NoError(t, err)
+			sigs, err := signatures.Get()
+			require.NoError(t, err)
+			require.Len(t, sigs, 1)
+
+			cert, err := sigs[0].Cert()
+			require.NoError(t, err)
+			require.Len(t, cert.URIs, 1)
+			assert.Equal(t, keylessSubject, cert.URIs[0].String())
+
+			bundle, err := sigs[0].Bundle()
+			require.NoError(t, err)
+			require.NotNil(t, bundle)
+			assert.Equal(t, rekorLogIndex, bundle.Payload.LogIndex)
+
+			payload, err := sigs[0].Payload()
+			require.NoError(t, err)
+			assert.Contains(t, string(payload), `"annotationKey1":"value1"`)
+		})
+
+		it("errors without an identity token", func() {
+			signer := NewImageSigner(log.New(writer, "", 0), sign.SignCmd)
+			err := signer.SignKeyless(testCtx, report, KeylessOptions{
+				FulcioURL:   fulcio.URL,
+				RekorURL:    rekor.URL,
+				OIDCIssuer:  oidcIssuer.URL,
+				IDTokenPath: "/fake/location/that/doesnt/exist",
+			}, nil)
+			assert.EqualError(t, err, "no identity token found for keyless signing: open /fake/location/that/doesnt/exist: no such file or directory")
+			assert.Empty(t, fulcio.authorizations)
+		})
+
+		it("errors when fulcio rejects the identity token", func() {
+			fulcio.reject = true
+
+			signer := NewImageSigner(log.New(writer, "", 0), sign.SignCmd)
+			err := signer.SignKeyless(testCtx, report, KeylessOptions{
+				FulcioURL:   fulcio.URL,
+				RekorURL:    rekor.URL,
+				OIDCIssuer:  oidcIssuer.URL,
+				IDTokenPath: tokenPath,
+			}, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "unable to sign image with keyless signing")
+			assert.Contains(t, err.Error(), "invalid identity token")
+			assert.Equal(t, 0, rekor.entries)
+			assertUnset(t, cosignExperimentalEnv)
+		})
+
+		it("has no image.Tags in report", func() {
+			cliSignCmdCallCount := 0
+			cliSignCmd := func(
+				ctx context.Context, ko sign.KeyOpts, registryOptions options.RegistryOptions, annotations map[string]interface{},
+				imageRef []string, certPath string, upload bool, outputSignature, outputCertificate string,
+				payloadPath string, force, recursive bool, attachment string,
+			) error {
+				cliSignCmdCallCount++
+				return nil
+			}
+
+			signer := NewImageSigner(log.New(writer, "", 0), cliSignCmd)
+			err := signer.SignKeyless(testCtx, createEmptyReportToml(t), KeylessOptions{IDTokenPath: tokenPath}, nil)
+			assert.EqualError(t, err, "no image found in report to sign")
+			assert.Equal(t, 0, cliSignCmdCallCount)
+		})
+	})
+
 	when("#Cosign.SignCmd", func() {
 		it("signs an image", func() {
 			secretLocation := t.TempDir()
@@ -688,3 +855,241 @@ func verify(keyRef, imageRef string, annotations map[string]interface{}) error {
 
 	return cmd.Exec(context.Background(), args)
 }
+
+const (
+	keylessSubject       = "https://kubernetes.io/namespaces/some-namespace/serviceaccounts/some-sa"
+	rekorLogIndex  int64 = 42
+)
+
+// oidcStandIn serves the discovery document cosign requests from the issuer of the identity token
+func oidcStandIn(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                server.URL,
+			"authorization_endpoint":                server.URL + "/auth",
+			"token_endpoint":                        server.URL + "/token",
+			"jwks_uri":                              server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"ES256"},
+		})
+		assert.NoError(t, err)
+	}))
+	return server
+}
+
+// jwt creates an unsigned identity token. cosign does not verify the token, fulcio does.
+func jwt(t *testing.T, issuer, subject string) string {
+	header, err := json.Marshal(map[string]string{"alg": "ES256", "typ": "JWT"})
+	require.NoError(t, err)
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": issuer,
+		"sub": subject,
+		"aud": []string{"sigstore"},
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	encode := base64.RawURLEncoding.EncodeToString
+	return strings.Join([]string{encode(header), encode(claims), encode([]byte("signature"))}, ".")
+}
+
+type fulcioStandIn struct {
+	*httptest.Server
+	caKey           *ecdsa.PrivateKey
+	caCert          *x509.Certificate
+	ctKey           *ecdsa.PrivateKey
+	ctPublicKeyPath string
+	authorizations  []string
+	reject          bool
+}
+
+// newFulcioStandIn issues certificates for the subject of the identity token from a test CA
+// and returns a signed certificate timestamp from a test CT log
+func newFulcioStandIn(t *testing.T) *fulcioStandIn {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio stand-in"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	ctKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ctPublicKey, err := x509.MarshalPKIXPublicKey(&ctKey.PublicKey)
+	require.NoError(t, err)
+	ctPublicKeyPath := filepath.Join(t.TempDir(), "ctfe.pub")
+	require.NoError(t, ioutil.WriteFile(ctPublicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ctPublicKey}), 0600))
+
+	f := &fulcioStandIn{
+		caKey:           caKey,
+		caCert:          caCert,
+		ctKey:           ctKey,
+		ctPublicKeyPath: ctPublicKeyPath,
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/signingCert" {
+			http.NotFound(w, r)
+			return
+		}
+		f.authorizations = append(f.authorizations, r.Header.Get("Authorization"))
+		if f.reject {
+			http.Error(w, "invalid identity token", http.StatusUnauthorized)
+			return
+		}
+
+		chain, sct, err := f.issue(r)
+		if err != nil {
+			assert.NoError(t, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("SCT", base64.StdEncoding.EncodeToString(sct))
+		w.WriteHeader(http.StatusCreated)
+		_, err = w.Write(chain)
+		assert.NoError(t, err)
+	}))
+	return f
+}
+
+func (f *fulcioStandIn) issue(r *http.Request) ([]byte, []byte, error) {
+	var request fulcioapi.CertificateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, nil, err
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(request.PublicKey.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subject, err := url.Parse(keylessSubject)
+	if err != nil {
+		return nil, nil, err
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{subject},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, f.caCert, publicKey, f.caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sct, err := signedCertificateTimestamp(f.ctKey, leafDER)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chain := append(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.caCert.Raw})...,
+	)
+	return chain, sct, nil
+}
+
+func signedCertificateTimestamp(ctKey *ecdsa.PrivateKey, certDER []byte) ([]byte, error) {
+	cert, err := ctx509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, err
+	}
+
+	sct := ct.SignedCertificateTimestamp{
+		SCTVersion: ct.V1,
+		Timestamp:  uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+	}
+	leaf, err := ct.MerkleTreeLeafFromChain([]*ctx509.Certificate{cert}, ct.X509LogEntryType, sct.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	input, err := ct.SerializeSCTSignatureInput(sct, ct.LogEntry{Leaf: *leaf})
+	if err != nil {
+		return nil, err
+	}
+	signature, err := cttls.CreateSignature(*ctKey, cttls.SHA256, input)
+	if err != nil {
+		return nil, err
+	}
+	sct.Signature = ct.DigitallySigned(signature)
+
+	return json.Marshal(sct)
+}
+
+type rekorStandIn struct {
+	*httptest.Server
+	entries int
+}
+
+// newRekorStandIn accepts every entry uploaded to the transparency log
+func newRekorStandIn(t *testing.T) *rekorStandIn {
+	r := &rekorStandIn{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/v1/log/entries" || req.Method != http.MethodPost {
+			http.NotFound(w, req)
+			return
+		}
+		r.entries++
+
+		body, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		err = json.NewEncoder(w).Encode(map[string]interface{}{
+			"some-uuid": map[string]interface{}{
+				"body":           base64.StdEncoding.EncodeToString(body),
+				"integratedTime": time.Now().Unix(),
+				"logID":          "some-log-id",
+				"logIndex":       rekorLogIndex,
+				"verification": map[string]interface{}{
+					"signedEntryTimestamp": base64.StdEncoding.EncodeToString([]byte("some-signed-entry-timestamp")),
+				},
+			},
+		})
+		assert.NoError(t, err)
+	}))
+	return r
+}
+
+// tufRoot creates a TUF cache with an unexpired timestamp so cosign does not fetch the sigstore TUF root
+func tufRoot(t *testing.T) string {
+	root := t.TempDir()
+
+	store, err := tufleveldbstore.FileLocalStore(filepath.Join(root, "tuf.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	timestamp, err := json.Marshal(map[string]interface{}{
+		"signed": map[string]interface{}{
+			"_type":        "timestamp",
+			"spec_version": "1.0",
+			"version":      1,
+			"expires":      time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			"meta":         map[string]interface{}{},
+		},
+		"signatures": []interface{}{},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.SetMeta("timestamp.json", timestamp))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "remote.json"), []byte(`{"mirror":"http://127.0.0.1"}`), 0600))
+	return root
+}
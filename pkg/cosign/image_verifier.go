@@ -0,0 +1,167 @@
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+	"github.com/sigstore/cosign/cmd/cosign/cli/fulcio"
+	sigstoreCosign "github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/cosign/pkg/oci"
+	ociremote "github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/sigstore/cosign/pkg/signature"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// ImageVerifier checks that an image has a cosign signature trusted by a
+// verification policy
+type ImageVerifier struct {
+	// RootCerts returns the Fulcio roots keyless signatures must chain to
+	RootCerts func() (*x509.CertPool, error)
+	// VerifyBundle reports whether a keyless signature has a Rekor bundle
+	// proving it was made while its short lived certificate was valid
+	VerifyBundle func(ctx context.Context, sig oci.Signature) (bool, error)
+}
+
+func NewImageVerifier() *ImageVerifier {
+	return &ImageVerifier{
+		RootCerts:    sigstoreRoots,
+		VerifyBundle: sigstoreCosign.VerifyBundle,
+	}
+}
+
+func (v *ImageVerifier) Verify(keychain authn.Keychain, image string, policy buildapi.VerificationPolicy) error {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	registryOpts := []ociremote.Option{
+		ociremote.WithRemoteOptions(remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx)),
+	}
+
+	var failures []string
+	for _, publicKey := range policy.PublicKeys {
+		err := verifyWithKey(ctx, ref, publicKey, registryOpts)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+
+	if len(policy.Keyless) > 0 {
+		err := v.verifyKeyless(ctx, ref, policy.Keyless, registryOpts)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+
+	return errors.Errorf("image %s is not signed by a trusted key or identity: %s", image, strings.Join(failures, "; "))
+}
+
+func verifyWithKey(ctx context.Context, ref name.Reference, publicKey string, registryOpts []ociremote.Option) error {
+	verifier, err := signature.LoadPublicKeyRaw([]byte(publicKey), crypto.SHA256)
+	if err != nil {
+		return errors.Wrap(err, "loading public key")
+	}
+
+	_, _, err = sigstoreCosign.VerifyImageSignatures(ctx, ref, &sigstoreCosign.CheckOpts{
+		RegistryClientOpts: registryOpts,
+		SigVerifier:        verifier,
+		ClaimVerifier:      sigstoreCosign.SimpleClaimVerifier,
+	})
+	return err
+}
+
+func (v *ImageVerifier) verifyKeyless(ctx context.Context, ref name.Reference, identities []buildapi.KeylessIdentity, registryOpts []ociremote.Option) error {
+	roots, err := v.RootCerts()
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, identity := range identities {
+		signatures, _, err := sigstoreCosign.VerifyImageSignatures(ctx, ref, &sigstoreCosign.CheckOpts{
+			RegistryClientOpts: registryOpts,
+			RootCerts:          roots,
+			CertOidcIssuer:     identity.Issuer,
+			ClaimVerifier:      sigstoreCosign.SimpleClaimVerifier,
+		})
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		err = v.verifyIdentity(ctx, signatures, identity.Subject)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+
+	return errors.New(strings.Join(failures, "; "))
+}
+
+// verifyIdentity requires a signature by the subject with a verified Rekor
+// bundle. Without a bundle cosign only checks the certificate at its NotBefore
+// time.
+func (v *ImageVerifier) verifyIdentity(ctx context.Context, signatures []oci.Signature, subject string) error {
+	failure := errors.New("no certificate issued to " + subject)
+	for _, sig := range signatures {
+		if !issuedTo(sig, subject) {
+			continue
+		}
+
+		verified, err := v.VerifyBundle(ctx, sig)
+		if err != nil {
+			failure = errors.Wrap(err, "verifying rekor bundle of signature by "+subject)
+			continue
+		}
+		if verified {
+			return nil
+		}
+		failure = errors.New("no rekor bundle for signature by " + subject)
+	}
+	return failure
+}
+
+// issuedTo reports whether the signing certificate was issued to the subject.
+// Fulcio records the subject as an email or a uri depending on the issuer.
+func issuedTo(sig oci.Signature, subject string) bool {
+	cert, err := sig.Cert()
+	if err != nil || cert == nil {
+		return false
+	}
+
+	for _, email := range cert.EmailAddresses {
+		if email == subject {
+			return true
+		}
+	}
+	for _, uri := range cert.URIs {
+		if uri.String() == subject {
+			return true
+		}
+	}
+	return false
+}
+
+// sigstoreRoots loads the public Fulcio roots from the sigstore TUF root.
+// cosign panics when the roots cannot be loaded.
+func sigstoreRoots() (roots *x509.CertPool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("loading fulcio roots: %v", r)
+		}
+	}()
+
+	return fulcio.GetRoots(), nil
+}
@@ -0,0 +1,294 @@
+package cosign
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/url"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/sigstore/cosign/pkg/oci"
+	"github.com/sigstore/cosign/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/pkg/oci/remote"
+	"github.com/sigstore/cosign/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func TestImageVerifier(t *testing.T) {
+	spec.Run(t, "Test Cosign Image Verifier", testImageVerifier)
+}
+
+func testImageVerifier(t *testing.T, when spec.G, it spec.S) {
+	const keylessIssuer = "https://kubernetes.default.svc"
+
+	var (
+		stopRegistry func()
+		imageCleanup func()
+		imageName    string
+		caKey        *ecdsa.PrivateKey
+		caCert       *x509.Certificate
+		verifier     *ImageVerifier
+	)
+
+	it.Before(func() {
+		var repo string
+		repo, stopRegistry = reg(t)
+
+		imageName = path.Join(repo, "test-verify-image")
+		imageCleanup = pushRandomImage(t, imageName)
+
+		caKey, caCert = testCA(t)
+		verifier = &ImageVerifier{
+			RootCerts: func() (*x509.CertPool, error) {
+				roots := x509.NewCertPool()
+				roots.AddCert(caCert)
+				return roots, nil
+			},
+			// cosign verifies rekor bundles with the public rekor key so the
+			// test signatures are trusted as if they had been logged
+			VerifyBundle: func(ctx context.Context, sig oci.Signature) (bool, error) {
+				return true, nil
+			},
+		}
+	})
+
+	it.After(func() {
+		stopRegistry()
+		imageCleanup()
+	})
+
+	when("verifying with public keys", func() {
+		it("passes when the image is signed by one of the keys", func() {
+			signingKey := ecdsaKey(t)
+			signImage(t, imageName, signingKey, nil)
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				PublicKeys: []string{publicKeyPEM(t, ecdsaKey(t)), publicKeyPEM(t, signingKey)},
+			})
+			require.NoError(t, err)
+		})
+
+		it("fails when the image is signed by another key", func() {
+			signImage(t, imageName, ecdsaKey(t), nil)
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				PublicKeys: []string{publicKeyPEM(t, ecdsaKey(t))},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "image "+imageName+" is not signed by a trusted key or identity")
+		})
+
+		it("fails when the image is not signed", func() {
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				PublicKeys: []string{publicKeyPEM(t, ecdsaKey(t))},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "no matching signatures")
+		})
+	})
+
+	when("verifying keyless signatures", func() {
+		it("passes when the certificate was issued to the identity", func() {
+			signingKey := ecdsaKey(t)
+			signImage(t, imageName, signingKey, leafCert(t, caKey, caCert, signingKey, keylessIssuer, keylessSubject))
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{Issuer: "https://accounts.google.com", Subject: keylessSubject},
+					{Issuer: keylessIssuer, Subject: keylessSubject},
+				},
+			})
+			require.NoError(t, err)
+		})
+
+		it("fails when the signature has no rekor bundle", func() {
+			verifier.VerifyBundle = NewImageVerifier().VerifyBundle
+
+			signingKey := ecdsaKey(t)
+			signImage(t, imageName, signingKey, leafCert(t, caKey, caCert, signingKey, keylessIssuer, keylessSubject))
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{Issuer: keylessIssuer, Subject: keylessSubject},
+				},
+			})
+			assert.EqualError(t, err, "image "+imageName+" is not signed by a trusted key or identity: no rekor bundle for signature by "+keylessSubject)
+		})
+
+		it("fails when the rekor bundle cannot be verified", func() {
+			verifier.VerifyBundle = func(ctx context.Context, sig oci.Signature) (bool, error) {
+				return false, errors.New("checking expiry on cert")
+			}
+
+			signingKey := ecdsaKey(t)
+			signImage(t, imageName, signingKey, leafCert(t, caKey, caCert, signingKey, keylessIssuer, keylessSubject))
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{Issuer: keylessIssuer, Subject: keylessSubject},
+				},
+			})
+			assert.EqualError(t, err, "image "+imageName+" is not signed by a trusted key or identity: verifying rekor bundle of signature by "+keylessSubject+": checking expiry on cert")
+		})
+
+		it("fails when the certificate was issued to another subject", func() {
+			signingKey := ecdsaKey(t)
+			signImage(t, imageName, signingKey, leafCert(t, caKey, caCert, signingKey, keylessIssuer, keylessSubject))
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{Issuer: keylessIssuer, Subject: "https://kubernetes.io/namespaces/other-namespace/serviceaccounts/some-sa"},
+				},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "no certificate issued to https://kubernetes.io/namespaces/other-namespace/serviceaccounts/some-sa")
+		})
+
+		it("fails when the certificate was issued by another issuer", func() {
+			signingKey := ecdsaKey(t)
+			signImage(t, imageName, signingKey, leafCert(t, caKey, caCert, signingKey, keylessIssuer, keylessSubject))
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{Issuer: "https://accounts.google.com", Subject: keylessSubject},
+				},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "expected oidc issuer not found in certificate")
+		})
+
+		it("fails when the certificate does not chain to the roots", func() {
+			otherCAKey, otherCACert := testCA(t)
+			signingKey := ecdsaKey(t)
+			signImage(t, imageName, signingKey, leafCert(t, otherCAKey, otherCACert, signingKey, keylessIssuer, keylessSubject))
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{Issuer: keylessIssuer, Subject: keylessSubject},
+				},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "certificate signed by unknown authority")
+		})
+
+		it("fails when the roots cannot be loaded", func() {
+			verifier.RootCerts = func() (*x509.CertPool, error) {
+				return nil, errors.New("no roots")
+			}
+
+			err := verifier.Verify(authn.DefaultKeychain, imageName, buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{Issuer: keylessIssuer, Subject: keylessSubject},
+				},
+			})
+			assert.EqualError(t, err, "image "+imageName+" is not signed by a trusted key or identity: no roots")
+		})
+	})
+}
+
+// signImage attaches a cosign signature for the image digest, with the
+// signing certificate when cert is set
+func signImage(t *testing.T, imageRef string, key *ecdsa.PrivateKey, cert []byte) {
+	regClientOpts := ociremote.WithRemoteOptions(remote.WithAuthFromKeychain(authn.DefaultKeychain))
+
+	ref, err := name.ParseReference(imageRef)
+	require.NoError(t, err)
+
+	descriptor, err := remote.Head(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	require.NoError(t, err)
+	digest := ref.Context().Digest(descriptor.Digest.String())
+
+	signaturePayload, err := payload.Cosign{Image: digest}.MarshalJSON()
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(signaturePayload)
+	rawSignature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	var opts []static.Option
+	if cert != nil {
+		opts = append(opts, static.WithCertChain(cert, nil))
+	}
+	sig, err := static.NewSignature(signaturePayload, base64.StdEncoding.EncodeToString(rawSignature), opts...)
+	require.NoError(t, err)
+
+	entity, err := ociremote.SignedEntity(digest, regClientOpts)
+	require.NoError(t, err)
+
+	entity, err = mutate.AttachSignatureToEntity(entity, sig)
+	require.NoError(t, err)
+
+	require.NoError(t, ociremote.WriteSignatures(digest.Repository, entity, regClientOpts))
+}
+
+func ecdsaKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func testCA(t *testing.T) (*ecdsa.PrivateKey, *x509.Certificate) {
+	caKey := ecdsaKey(t)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test fulcio"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	return caKey, caCert
+}
+
+// leafCert issues a certificate the way fulcio does, with the subject as a uri
+// and the oidc issuer in an extension
+func leafCert(t *testing.T, caKey *ecdsa.PrivateKey, caCert *x509.Certificate, key *ecdsa.PrivateKey, issuer, subject string) []byte {
+	subjectURI, err := url.Parse(subject)
+	require.NoError(t, err)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{subjectURI},
+		ExtraExtensions: []pkix.Extension{{
+			Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1},
+			Value: []byte(issuer),
+		}},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+}
@@ -0,0 +1,35 @@
+package customsource
+
+import (
+	"strings"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ConfigName is the name of the ConfigMap in the kpack namespace that maps custom source
+// kinds to the base url of the source resolver for the kind.
+const ConfigName = "custom-source-resolvers"
+
+type Endpoints struct {
+	endpoints atomic.Value
+}
+
+func NewEndpoints() *Endpoints {
+	e := &Endpoints{}
+	e.endpoints.Store(map[string]string{})
+	return e
+}
+
+func (e *Endpoints) Update(cm *corev1.ConfigMap) {
+	endpoints := make(map[string]string, len(cm.Data))
+	for kind, endpoint := range cm.Data {
+		endpoints[kind] = strings.TrimSuffix(strings.TrimSpace(endpoint), "/")
+	}
+	e.endpoints.Store(endpoints)
+}
+
+func (e *Endpoints) Endpoint(kind string) (string, bool) {
+	endpoint, ok := e.endpoints.Load().(map[string]string)[kind]
+	return endpoint, ok
+}
@@ -0,0 +1,42 @@
+package customsource
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/pivotal/kpack/pkg/archive"
+)
+
+type Fetcher struct {
+	Logger *log.Logger
+	Client *http.Client
+	// Token is the projected service account token of the build presented to the source resolver.
+	Token string
+}
+
+// Fetch extracts the archive of the resolved version of the source returned by the source resolver into dir.
+func (f *Fetcher) Fetch(dir, endpoint, kind string, config map[string]string, version string) error {
+	f.Logger.Printf("Fetching %s source version %s...", kind, version)
+
+	resp, err := post(context.Background(), f.Client, endpoint+FetchPath, f.Token, FetchRequest{
+		Kind:    kind,
+		Config:  config,
+		Version: version,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "fetching %s source", kind)
+	}
+	defer resp.Body.Close()
+
+	err = archive.Extract(resp.Body, dir)
+	if err != nil {
+		return err
+	}
+
+	f.Logger.Printf("Successfully fetched %s source version %s in path %q", kind, version, dir)
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package customsource_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/customsource"
+)
+
+func TestCustomSourceFetcher(t *testing.T) {
+	spec.Run(t, "testCustomSourceFetcher", testCustomSourceFetcher)
+}
+
+func testCustomSourceFetcher(t *testing.T, when spec.G, it spec.S) {
+	var (
+		requests []customsource.FetchRequest
+		server   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, customsource.FetchPath, r.URL.Path)
+			require.Equal(t, "Bearer some-token", r.Header.Get("Authorization"))
+
+			var request customsource.FetchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			requests = append(requests, request)
+
+			if request.Version != "12345" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			http.ServeFile(w, r, filepath.Join("testdata", "test.tar.gz"))
+		}))
+		output  = &bytes.Buffer{}
+		fetcher = &customsource.Fetcher{
+			Logger: log.New(output, "", 0),
+			Client: http.DefaultClient,
+			Token:  "some-token",
+		}
+		dir string
+	)
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "fetch_test")
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	it("extracts the archive returned by the source resolver", func() {
+		err := fetcher.Fetch(dir, server.URL, "perforce", map[string]string{"depot": "//depot/app/..."}, "12345")
+		require.NoError(t, err)
+
+		file, err := ioutil.ReadFile(filepath.Join(dir, "testdir", "testfile"))
+		require.NoError(t, err)
+		require.Equal(t, "test file contents", string(file))
+
+		require.Equal(t, []customsource.FetchRequest{{
+			Kind:    "perforce",
+			Config:  map[string]string{"depot": "//depot/app/..."},
+			Version: "12345",
+		}}, requests)
+		require.Contains(t, output.String(), "Successfully fetched perforce source version 12345")
+	})
+
+	it("returns an error when the source resolver cannot fetch the version", func() {
+		err := fetcher.Fetch(dir, server.URL, "perforce", nil, "missing")
+		require.EqualError(t, err, "fetching perforce source: requesting "+server.URL+"/fetch: unexpected status code 404")
+	})
+}
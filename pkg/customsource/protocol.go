@@ -0,0 +1,80 @@
+package customsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ResolvePath = "/resolve"
+	FetchPath   = "/fetch"
+
+	maxErrorMessageSize = 4096
+)
+
+// ResolveRequest is posted by the controller to the resolve path of a source resolver.
+// The Namespace and ServiceAccountName are informational, source resolvers must
+// authorize the request with the identity of its service account token.
+type ResolveRequest struct {
+	Kind               string            `json:"kind"`
+	Namespace          string            `json:"namespace"`
+	ServiceAccountName string            `json:"serviceAccountName"`
+	Config             map[string]string `json:"config,omitempty"`
+	// PreviousVersion is the version the source previously resolved to, if any.
+	PreviousVersion string `json:"previousVersion,omitempty"`
+}
+
+// ResolveResponse is returned by a source resolver with an opaque version of the source
+// that is passed back to it when the source is fetched. A build is created whenever the
+// version changes.
+type ResolveResponse struct {
+	Version  string `json:"version"`
+	Pollable bool   `json:"pollable,omitempty"`
+}
+
+// FetchRequest is posted by the build pod to the fetch path of a source resolver which
+// responds with a zip, tar, or gzipped tar archive of the source.
+type FetchRequest struct {
+	Kind    string            `json:"kind"`
+	Config  map[string]string `json:"config,omitempty"`
+	Version string            `json:"version"`
+}
+
+// post sends the body to a source resolver with a service account token in the Authorization header.
+// The token has the CustomSourceTokenAudience and identifies the namespace and service account of the image.
+func post(ctx context.Context, client *http.Client, url, token string, body interface{}) (*http.Response, error) {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "requesting %s", url)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorMessageSize))
+		if message := strings.TrimSpace(string(body)); message != "" {
+			return nil, errors.Errorf("requesting %s: unexpected status code %d: %s", url, resp.StatusCode, message)
+		}
+		return nil, errors.Errorf("requesting %s: unexpected status code %d", url, resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package customsource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const tokenExpirySeconds = 600
+
+type Resolver struct {
+	K8sClient k8sclient.Interface
+	Endpoints *Endpoints
+	Client    *http.Client
+}
+
+func NewResolver(k8sClient k8sclient.Interface, endpoints *Endpoints) *Resolver {
+	return &Resolver{
+		K8sClient: k8sClient,
+		Endpoints: endpoints,
+		Client:    &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	custom := sourceResolver.Spec.Source.Custom
+
+	endpoint, ok := r.Endpoints.Endpoint(custom.Kind)
+	if !ok {
+		return corev1alpha1.ResolvedSourceConfig{}, errors.Errorf("no source resolver registered for kind %q", custom.Kind)
+	}
+
+	var previousVersion string
+	if previous := sourceResolver.Status.Source.Custom; previous != nil && previous.Kind == custom.Kind {
+		previousVersion = previous.Version
+	}
+
+	token, err := r.token(ctx, sourceResolver.Namespace, sourceResolver.Spec.ServiceAccountName)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	resp, err := post(ctx, r.Client, endpoint+ResolvePath, token, ResolveRequest{
+		Kind:               custom.Kind,
+		Namespace:          sourceResolver.Namespace,
+		ServiceAccountName: sourceResolver.Spec.ServiceAccountName,
+		Config:             custom.Config,
+		PreviousVersion:    previousVersion,
+	})
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, errors.Wrapf(err, "resolving %s source", custom.Kind)
+	}
+	defer resp.Body.Close()
+
+	var resolved ResolveResponse
+	err = json.NewDecoder(resp.Body).Decode(&resolved)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, errors.Wrapf(err, "decoding %s source resolver response", custom.Kind)
+	}
+
+	if resolved.Version == "" {
+		return corev1alpha1.ResolvedSourceConfig{}, errors.Errorf("%s source resolver did not return a version", custom.Kind)
+	}
+
+	return corev1alpha1.ResolvedSourceConfig{
+		Custom: &corev1alpha1.ResolvedCustomSource{
+			Kind:     custom.Kind,
+			Config:   custom.Config,
+			Endpoint: endpoint,
+			Version:  resolved.Version,
+			Pollable: resolved.Pollable,
+			SubPath:  sourceResolver.Spec.Source.SubPath,
+		},
+	}, nil
+}
+
+func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
+	return sourceResolver.IsCustom()
+}
+
+// token requests a token of the service account of the image for the source resolver,
+// so the source resolver can verify the namespace and service account of the request.
+func (r *Resolver) token(ctx context.Context, namespace, serviceAccountName string) (string, error) {
+	expirationSeconds := int64(tokenExpirySeconds)
+	tokenRequest, err := r.K8sClient.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, serviceAccountName, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{corev1alpha1.CustomSourceTokenAudience},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "requesting token for service account %s", serviceAccountName)
+	}
+	return tokenRequest.Status.Token, nil
+}
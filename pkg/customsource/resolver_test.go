@@ -0,0 +1,189 @@
+package customsource_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/customsource"
+)
+
+func TestCustomSourceResolver(t *testing.T) {
+	spec.Run(t, "testCustomSourceResolver", testCustomSourceResolver)
+}
+
+func testCustomSourceResolver(t *testing.T, when spec.G, it spec.S) {
+	var (
+		handler   http.HandlerFunc
+		server    *httptest.Server
+		requests  []customsource.ResolveRequest
+		tokens    []string
+		k8sClient *fake.Clientset
+		endpoints *customsource.Endpoints
+		resolver  *customsource.Resolver
+	)
+
+	it.Before(func() {
+		requests = nil
+		tokens = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, customsource.ResolvePath, r.URL.Path)
+
+			var request customsource.ResolveRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			requests = append(requests, request)
+			tokens = append(tokens, r.Header.Get("Authorization"))
+
+			handler(w, r)
+		}))
+
+		endpoints = customsource.NewEndpoints()
+		endpoints.Update(&corev1.ConfigMap{
+			Data: map[string]string{
+				"perforce": server.URL + "/",
+			},
+		})
+
+		k8sClient = fake.NewSimpleClientset()
+		k8sClient.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			createAction := action.(k8stesting.CreateActionImpl)
+			if createAction.GetSubresource() != "token" {
+				return false, nil, nil
+			}
+			tokenRequest := createAction.GetObject().(*authenticationv1.TokenRequest)
+			require.Equal(t, []string{corev1alpha1.CustomSourceTokenAudience}, tokenRequest.Spec.Audiences)
+
+			return true, &authenticationv1.TokenRequest{
+				Status: authenticationv1.TokenRequestStatus{
+					Token: "token-for-" + createAction.GetNamespace() + "-" + createAction.Name,
+				},
+			}, nil
+		})
+
+		resolver = customsource.NewResolver(k8sClient, endpoints)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	sourceResolver := &buildapi.SourceResolver{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-source-resolver",
+			Namespace: "some-namespace",
+		},
+		Spec: buildapi.SourceResolverSpec{
+			ServiceAccountName: "some-service-account",
+			Source: corev1alpha1.SourceConfig{
+				Custom: &corev1alpha1.Custom{
+					Kind: "perforce",
+					Config: map[string]string{
+						"depot": "//depot/app/...",
+					},
+				},
+				SubPath: "some-sub-path",
+			},
+		},
+	}
+
+	it("resolves the source with the registered source resolver", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"version": "12345", "pollable": true}`))
+		}
+
+		resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.NoError(t, err)
+
+		require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+			Custom: &corev1alpha1.ResolvedCustomSource{
+				Kind: "perforce",
+				Config: map[string]string{
+					"depot": "//depot/app/...",
+				},
+				Endpoint: server.URL,
+				Version:  "12345",
+				Pollable: true,
+				SubPath:  "some-sub-path",
+			},
+		}, resolvedSource)
+
+		require.Equal(t, []customsource.ResolveRequest{{
+			Kind:               "perforce",
+			Namespace:          "some-namespace",
+			ServiceAccountName: "some-service-account",
+			Config: map[string]string{
+				"depot": "//depot/app/...",
+			},
+		}}, requests)
+	})
+
+	it("authenticates with a token of the service account of the source", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"version": "12345"}`))
+		}
+
+		_, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"Bearer token-for-some-namespace-some-service-account"}, tokens)
+	})
+
+	it("sends the previously resolved version", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"version": "12346"}`))
+		}
+		sourceResolver.Status.Source.Custom = &corev1alpha1.ResolvedCustomSource{
+			Kind:    "perforce",
+			Version: "12345",
+		}
+
+		resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.NoError(t, err)
+
+		require.Equal(t, "12346", resolvedSource.Custom.Version)
+		require.False(t, resolvedSource.Custom.IsPollable())
+		require.Len(t, requests, 1)
+		require.Equal(t, "12345", requests[0].PreviousVersion)
+	})
+
+	it("returns the error message of the source resolver", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("depot not found\n"))
+		}
+
+		_, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.EqualError(t, err, "resolving perforce source: requesting "+server.URL+"/resolve: unexpected status code 400: depot not found")
+	})
+
+	it("returns an error when the source resolver does not return a version", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}
+
+		_, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.EqualError(t, err, "perforce source resolver did not return a version")
+	})
+
+	it("returns an error when no source resolver is registered for the kind", func() {
+		sourceResolver.Spec.Source.Custom.Kind = "svn"
+
+		_, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.EqualError(t, err, `no source resolver registered for kind "svn"`)
+		require.Empty(t, requests)
+	})
+}
@@ -17,6 +17,7 @@ type DuckBuilder struct {
 }
 type DuckBuilderSpec struct {
 	ImagePullSecrets []v1.LocalObjectReference
+	Rollout          *buildapi.BuilderRollout
 }
 
 func (b *DuckBuilder) Ready() bool {
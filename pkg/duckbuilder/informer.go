@@ -65,7 +65,10 @@ func convertBuilder(builder *buildapi.Builder) *DuckBuilder {
 	return &DuckBuilder{
 		TypeMeta:   builder.TypeMeta,
 		ObjectMeta: builder.ObjectMeta,
-		Status:     builder.Status,
+		Spec: DuckBuilderSpec{
+			Rollout: builder.Spec.Rollout,
+		},
+		Status: builder.Status,
 	}
 }
 
@@ -77,6 +80,9 @@ func convertClusterBuilder(builder *buildapi.ClusterBuilder) *DuckBuilder {
 	return &DuckBuilder{
 		TypeMeta:   builder.TypeMeta,
 		ObjectMeta: builder.ObjectMeta,
-		Status:     builder.Status,
+		Spec: DuckBuilderSpec{
+			Rollout: builder.Spec.Rollout,
+		},
+		Status: builder.Status,
 	}
 }
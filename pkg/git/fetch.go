@@ -1,9 +1,11 @@
 package git
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	git2go "github.com/libgit2/git2go/v33"
@@ -11,8 +13,12 @@ import (
 )
 
 type Fetcher struct {
-	Logger   *log.Logger
-	Keychain GitKeychain
+	Logger     *log.Logger
+	Keychain   GitKeychain
+	Submodules bool
+	LFS        bool
+	// Depth limits the fetched history of the revision to the given number of commits
+	Depth int
 }
 
 func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
@@ -33,18 +39,18 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 	}
 	defer remote.Free()
 
-	err = remote.Fetch([]string{"refs/*:refs/*"}, &git2go.FetchOptions{
-		DownloadTags: git2go.DownloadTagsAll,
-		RemoteCallbacks: git2go.RemoteCallbacks{
-			CredentialsCallback:      keychainAsCredentialsCallback(f.Keychain),
-			CertificateCheckCallback: certificateCheckCallback(),
-		},
-		ProxyOptions: git2go.ProxyOptions{
-			Type: git2go.ProxyTypeAuto,
-		},
-	}, "")
+	fetched, err := f.fetchRevision(repository, remote, gitRevision)
 	if err != nil {
-		return errors.Wrap(err, "fetching remote")
+		return err
+	}
+
+	if !fetched {
+		f.Logger.Printf("Unable to fetch %q directly, fetching all refs...", gitRevision)
+
+		err = remote.Fetch([]string{"refs/*:refs/*"}, f.fetchOptions(git2go.DownloadTagsAll), "")
+		if err != nil {
+			return errors.Wrap(err, "fetching remote")
+		}
 	}
 
 	oid, err := resolveRevision(repository, gitRevision)
@@ -68,6 +74,20 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 		return errors.Wrap(err, "checkout head")
 	}
 
+	if f.Submodules {
+		err = f.updateSubmodules(repository)
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.LFS {
+		err = f.smudgeLFS(repository, gitURL)
+		if err != nil {
+			return err
+		}
+	}
+
 	projectMetadataFile, err := os.Create(path.Join(metadataDir, "project-metadata.toml"))
 	if err != nil {
 		return errors.Wrapf(err, "invalid metadata destination '%s/project-metadata.toml' for git repository: %s", metadataDir, gitURL)
@@ -94,6 +114,118 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 	return nil
 }
 
+// fetchRevision fetches only the refs pointing to the revision or, if the revision is a commit
+// that is not the head of any ref, the commit itself. It reports whether the commit of the
+// revision is available so that the caller can fall back to fetching all refs.
+func (f Fetcher) fetchRevision(repository *git2go.Repository, remote *git2go.Remote, gitRevision string) (bool, error) {
+	callbacks := f.remoteCallbacks()
+	err := remote.ConnectFetch(&callbacks, &git2go.ProxyOptions{Type: git2go.ProxyTypeAuto}, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "fetching remote")
+	}
+
+	heads, err := remote.Ls()
+	remote.Disconnect()
+	if err != nil {
+		return false, errors.Wrap(err, "fetching remote")
+	}
+
+	refspecs := revisionRefspecs(heads, gitRevision)
+	if len(refspecs) == 0 {
+		if _, err := git2go.NewOid(gitRevision); err != nil {
+			f.Logger.Printf("No remote ref matches %q", gitRevision)
+			return false, nil
+		}
+
+		// fetching a commit that is not advertised by the server is not supported by every server
+		err = f.fetchRefspecs(repository, remote, []string{gitRevision})
+		if err != nil {
+			f.Logger.Printf("Fetching commit %q failed: %s", gitRevision, err)
+			return false, nil
+		}
+	} else {
+		err = f.fetchRefspecs(repository, remote, refspecs)
+		if err != nil {
+			return false, errors.Wrap(err, "fetching remote")
+		}
+	}
+
+	oid, err := resolveRevision(repository, gitRevision)
+	if err != nil {
+		f.Logger.Printf("Fetched refs do not contain %q: %s", gitRevision, err)
+		return false, nil
+	}
+
+	commit, err := repository.LookupCommit(oid)
+	if git2go.IsErrorCode(err, git2go.ErrorCodeNotFound) {
+		f.Logger.Printf("Fetched refs do not contain commit %q", oid)
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "looking up commit")
+	}
+	commit.Free()
+
+	return true, nil
+}
+
+// fetchRefspecs fetches the refspecs without tags. When a depth is configured the refspecs are
+// fetched shallow with the git cli because libgit2 does not support shallow fetches, falling
+// back to fetching the complete history if the shallow fetch fails.
+func (f Fetcher) fetchRefspecs(repository *git2go.Repository, remote *git2go.Remote, refspecs []string) error {
+	if f.Depth > 0 {
+		err := f.shallowFetch(repository.Workdir(), remote.Url(), refspecs)
+		if err == nil {
+			return nil
+		}
+		f.Logger.Printf("Shallow fetch failed, fetching the complete history: %s", err)
+	}
+
+	return remote.Fetch(refspecs, f.fetchOptions(git2go.DownloadTagsNone), "")
+}
+
+func revisionRefspecs(heads []git2go.RemoteHead, gitRevision string) []string {
+	var refspecs []string
+	seen := map[string]bool{}
+	for _, head := range heads {
+		name := strings.TrimSuffix(head.Name, "^{}")
+		if !strings.HasPrefix(name, "refs/") || seen[name] {
+			continue
+		}
+
+		if head.Id.String() == gitRevision || matchesRevision(name, gitRevision) {
+			seen[name] = true
+			refspecs = append(refspecs, fmt.Sprintf("+%s:%s", name, name))
+		}
+	}
+	return refspecs
+}
+
+func matchesRevision(refName, gitRevision string) bool {
+	for _, format := range refRevParseRules {
+		if fmt.Sprintf(format, gitRevision) == refName {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Fetcher) fetchOptions(downloadTags git2go.DownloadTags) *git2go.FetchOptions {
+	return &git2go.FetchOptions{
+		DownloadTags:    downloadTags,
+		RemoteCallbacks: f.remoteCallbacks(),
+		ProxyOptions: git2go.ProxyOptions{
+			Type: git2go.ProxyTypeAuto,
+		},
+	}
+}
+
+func (f Fetcher) remoteCallbacks() git2go.RemoteCallbacks {
+	return git2go.RemoteCallbacks{
+		CredentialsCallback:      keychainAsCredentialsCallback(f.Keychain),
+		CertificateCheckCallback: certificateCheckCallback(),
+	}
+}
+
 func resolveRevision(repository *git2go.Repository, gitRevision string) (*git2go.Oid, error) {
 	ref, err := repository.References.Dwim(gitRevision)
 	if err != nil {
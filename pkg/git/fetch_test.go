@@ -2,12 +2,17 @@ package git
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	git2go "github.com/libgit2/git2go/v33"
@@ -23,14 +28,16 @@ func TestGitCheckout(t *testing.T) {
 func testGitCheckout(t *testing.T, when spec.G, it spec.S) {
 	when("#Fetch", func() {
 		outputBuffer := &bytes.Buffer{}
-		fetcher := Fetcher{
-			Logger:   log.New(outputBuffer, "", 0),
-			Keychain: fakeGitKeychain{},
-		}
+		var fetcher Fetcher
 		var testDir string
 		var metadataDir string
 
 		it.Before(func() {
+			fetcher = Fetcher{
+				Logger:   log.New(outputBuffer, "", 0),
+				Keychain: fakeGitKeychain{},
+			}
+
 			var err error
 			testDir, err = ioutil.TempDir("", "test-git")
 			require.NoError(t, err)
@@ -97,6 +104,225 @@ func testGitCheckout(t *testing.T, when spec.G, it spec.S) {
 			require.EqualError(t, err, "fetching remote: no auth available")
 		})
 
+		when("using local repositories", func() {
+			var repositoriesDir string
+
+			it.Before(func() {
+				var err error
+				repositoriesDir, err = ioutil.TempDir("", "test-git-repositories")
+				require.NoError(t, err)
+			})
+
+			it.After(func() {
+				require.NoError(t, os.RemoveAll(repositoriesDir))
+			})
+
+			it("recursively checks out submodules when enabled", func() {
+				nestedPath, nestedCommit := createBareRepository(t, path.Join(repositoriesDir, "nested"), map[string]string{
+					"nested.txt": "nested contents",
+				}, nil)
+				libPath, libCommit := createBareRepository(t, path.Join(repositoriesDir, "lib"), map[string]string{
+					"lib.txt":     "lib contents",
+					".gitmodules": fmt.Sprintf("[submodule \"nested\"]\n\tpath = nested\n\turl = %s\n", nestedPath),
+				}, map[string]*git2go.Oid{"nested": nestedCommit})
+				appPath, _ := createBareRepository(t, path.Join(repositoriesDir, "app"), map[string]string{
+					"app.txt":     "app contents",
+					".gitmodules": fmt.Sprintf("[submodule \"lib\"]\n\tpath = lib\n\turl = %s\n", libPath),
+				}, map[string]*git2go.Oid{"lib": libCommit})
+
+				fetcher.Submodules = true
+				err := fetcher.Fetch(testDir, appPath, "main", metadataDir)
+				require.NoError(t, err)
+
+				requireFileContents(t, path.Join(testDir, "app.txt"), "app contents")
+				requireFileContents(t, path.Join(testDir, "lib", "lib.txt"), "lib contents")
+				requireFileContents(t, path.Join(testDir, "lib", "nested", "nested.txt"), "nested contents")
+			})
+
+			it("does not check out submodules by default", func() {
+				libPath, libCommit := createBareRepository(t, path.Join(repositoriesDir, "lib"), map[string]string{
+					"lib.txt": "lib contents",
+				}, nil)
+				appPath, _ := createBareRepository(t, path.Join(repositoriesDir, "app"), map[string]string{
+					".gitmodules": fmt.Sprintf("[submodule \"lib\"]\n\tpath = lib\n\turl = %s\n", libPath),
+				}, map[string]*git2go.Oid{"lib": libCommit})
+
+				err := fetcher.Fetch(testDir, appPath, "main", metadataDir)
+				require.NoError(t, err)
+
+				require.NoFileExists(t, path.Join(testDir, "lib", "lib.txt"))
+			})
+
+			when("fetching a single revision", func() {
+				var (
+					appPath      string
+					firstCommit  *git2go.Oid
+					latestCommit *git2go.Oid
+				)
+
+				it.Before(func() {
+					appPath, firstCommit = createBareRepository(t, path.Join(repositoriesDir, "app"), map[string]string{
+						"app.txt": "first",
+					}, nil)
+
+					repository, err := git2go.OpenRepository(appPath)
+					require.NoError(t, err)
+					defer repository.Free()
+
+					latestCommit = createCommit(t, repository, "refs/heads/main", map[string]string{"app.txt": "latest"}, nil)
+					createCommit(t, repository, "refs/heads/other", map[string]string{"other.txt": "other"}, nil)
+				})
+
+				requireRefs := func(expected ...string) {
+					repository, err := git2go.OpenRepository(testDir)
+					require.NoError(t, err)
+					defer repository.Free()
+
+					iterator, err := repository.NewReferenceIterator()
+					require.NoError(t, err)
+					defer iterator.Free()
+
+					var refs []string
+					names := iterator.Names()
+					for name, err := names.Next(); err == nil; name, err = names.Next() {
+						refs = append(refs, name)
+					}
+					require.ElementsMatch(t, expected, refs)
+				}
+
+				it("only fetches the branch", func() {
+					err := fetcher.Fetch(testDir, appPath, "main", metadataDir)
+					require.NoError(t, err)
+
+					requireFileContents(t, path.Join(testDir, "app.txt"), "latest")
+					requireRefs("refs/heads/main")
+				})
+
+				it("only fetches the refs pointing to a commit", func() {
+					err := fetcher.Fetch(testDir, appPath, latestCommit.String(), metadataDir)
+					require.NoError(t, err)
+
+					requireFileContents(t, path.Join(testDir, "app.txt"), "latest")
+					requireRefs("refs/heads/main")
+				})
+
+				it("fetches the history up to the configured depth", func() {
+					fetcher.Depth = 1
+					err := fetcher.Fetch(testDir, appPath, "main", metadataDir)
+					require.NoError(t, err)
+
+					requireFileContents(t, path.Join(testDir, "app.txt"), "latest")
+					requireRefs("refs/heads/main")
+
+					repository, err := git2go.OpenRepository(testDir)
+					require.NoError(t, err)
+					defer repository.Free()
+
+					shallow, err := repository.IsShallow()
+					require.NoError(t, err)
+					require.True(t, shallow)
+				})
+
+				it("falls back to fetching all refs when the commit cannot be fetched directly", func() {
+					err := fetcher.Fetch(testDir, appPath, firstCommit.String(), metadataDir)
+					require.NoError(t, err)
+
+					requireFileContents(t, path.Join(testDir, "app.txt"), "first")
+					require.Contains(t, outputBuffer.String(), fmt.Sprintf("Unable to fetch %q directly, fetching all refs...", firstCommit.String()))
+
+					var projectMetadata project
+					_, err = toml.DecodeFile(path.Join(metadataDir, "project-metadata.toml"), &projectMetadata)
+					require.NoError(t, err)
+					require.Equal(t, firstCommit.String(), projectMetadata.Source.Version.Commit)
+				})
+			})
+
+			when("lfs", func() {
+				const assetContents = "some large binary asset"
+				assetSha := fmt.Sprintf("%x", sha256.Sum256([]byte(assetContents)))
+				pointer := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", assetSha, len(assetContents))
+
+				var (
+					server         *httptest.Server
+					appPath        string
+					requests       []string
+					authorizations []string
+				)
+
+				it.Before(func() {
+					requests = nil
+					authorizations = nil
+					server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						requests = append(requests, r.Method+" "+r.URL.Path)
+						authorizations = append(authorizations, r.Header.Get("Authorization"))
+						switch {
+						case r.Method == http.MethodPost && r.URL.Path == "/lfs/objects/batch":
+							w.Header().Set("Content-Type", "application/vnd.git-lfs+json")
+							fmt.Fprintf(w, `{"objects": [{"oid": "%s", "size": %d, "actions": {"download": {"href": "http://%s/download/%s", "header": {"X-Some-Header": "some-value"}}}}]}`, assetSha, len(assetContents), r.Host, assetSha)
+						case r.Method == http.MethodGet && r.URL.Path == "/download/"+assetSha && r.Header.Get("X-Some-Header") == "some-value":
+							fmt.Fprint(w, assetContents)
+						default:
+							w.WriteHeader(http.StatusNotFound)
+						}
+					}))
+
+					appPath, _ = createBareRepository(t, path.Join(repositoriesDir, "app"), map[string]string{
+						"asset.bin":  pointer,
+						".lfsconfig": fmt.Sprintf("[lfs]\n\turl = %s/lfs\n", server.URL),
+					}, nil)
+				})
+
+				it.After(func() {
+					server.Close()
+				})
+
+				it("downloads lfs objects when enabled", func() {
+					fetcher.LFS = true
+					err := fetcher.Fetch(testDir, appPath, "main", metadataDir)
+					require.NoError(t, err)
+
+					requireFileContents(t, path.Join(testDir, "asset.bin"), assetContents)
+					require.Equal(t, []string{"POST /lfs/objects/batch", "GET /download/" + assetSha}, requests)
+				})
+
+				it("leaves lfs pointers by default", func() {
+					err := fetcher.Fetch(testDir, appPath, "main", metadataDir)
+					require.NoError(t, err)
+
+					requireFileContents(t, path.Join(testDir, "asset.bin"), pointer)
+					require.Empty(t, requests)
+				})
+
+				it("downloads lfs objects of submodules", func() {
+					appWithSubmodulePath, _ := createBareRepository(t, path.Join(repositoriesDir, "app-with-submodule"), map[string]string{
+						".gitmodules": fmt.Sprintf("[submodule \"app\"]\n\tpath = app\n\turl = %s\n", appPath),
+					}, map[string]*git2go.Oid{"app": resolveHead(t, appPath)})
+
+					fetcher.Submodules = true
+					fetcher.LFS = true
+					err := fetcher.Fetch(testDir, appWithSubmodulePath, "main", metadataDir)
+					require.NoError(t, err)
+
+					requireFileContents(t, path.Join(testDir, "app", "asset.bin"), assetContents)
+				})
+
+				it("only sends credentials to an lfs server on the host of the git repository", func() {
+					fetcher.Keychain = basicAuthGitKeychain{}
+					pointers := []lfsPointer{{Oid: assetSha, Size: int64(len(assetContents))}}
+
+					_, err := fetcher.lfsBatch(server.URL+"/lfs", server.URL+"/org/repo.git", pointers)
+					require.NoError(t, err)
+
+					_, err = fetcher.lfsBatch(server.URL+"/lfs", "https://github.com/org/repo.git", pointers)
+					require.NoError(t, err)
+
+					require.Len(t, authorizations, 2)
+					require.NotEmpty(t, authorizations[0])
+					require.Empty(t, authorizations[1])
+				})
+			})
+		})
+
 		it("uses the http proxy env vars", func() {
 			require.NoError(t, os.Setenv("HTTPS_PROXY", "http://invalid-proxy"))
 			defer os.Unsetenv("HTTPS_PROXY")
@@ -107,6 +333,95 @@ func testGitCheckout(t *testing.T, when spec.G, it spec.S) {
 	})
 }
 
+func createBareRepository(t *testing.T, dir string, files map[string]string, submodules map[string]*git2go.Oid) (string, *git2go.Oid) {
+	repository, err := git2go.InitRepository(dir, true)
+	require.NoError(t, err)
+	defer repository.Free()
+
+	return dir, createCommit(t, repository, "refs/heads/main", files, submodules)
+}
+
+func createCommit(t *testing.T, repository *git2go.Repository, refName string, files map[string]string, submodules map[string]*git2go.Oid) *git2go.Oid {
+	treeId := createTree(t, repository, files, submodules)
+
+	tree, err := repository.LookupTree(treeId)
+	require.NoError(t, err)
+	defer tree.Free()
+
+	var parents []*git2go.Commit
+	if ref, err := repository.References.Lookup(refName); err == nil {
+		parent, err := repository.LookupCommit(ref.Target())
+		require.NoError(t, err)
+		defer parent.Free()
+		parents = append(parents, parent)
+	}
+
+	signature := &git2go.Signature{Name: "kpack", Email: "kpack@example.com", When: time.Now()}
+	commit, err := repository.CreateCommit(refName, signature, signature, "commit", tree, parents...)
+	require.NoError(t, err)
+
+	return commit
+}
+
+// createTree writes a tree for files and submodules, paths containing a slash are written to subtrees
+func createTree(t *testing.T, repository *git2go.Repository, files map[string]string, submodules map[string]*git2go.Oid) *git2go.Oid {
+	treeBuilder, err := repository.TreeBuilder()
+	require.NoError(t, err)
+	defer treeBuilder.Free()
+
+	subtrees := map[string]map[string]string{}
+	for name, contents := range files {
+		if parts := strings.SplitN(name, "/", 2); len(parts) == 2 {
+			if subtrees[parts[0]] == nil {
+				subtrees[parts[0]] = map[string]string{}
+			}
+			subtrees[parts[0]][parts[1]] = contents
+			continue
+		}
+
+		blob, err := repository.CreateBlobFromBuffer([]byte(contents))
+		require.NoError(t, err)
+		require.NoError(t, treeBuilder.Insert(name, blob, git2go.FilemodeBlob))
+	}
+
+	for dir, subtreeFiles := range subtrees {
+		require.NoError(t, treeBuilder.Insert(dir, createTree(t, repository, subtreeFiles, nil), git2go.FilemodeTree))
+	}
+
+	for name, commit := range submodules {
+		require.NoError(t, treeBuilder.Insert(name, commit, git2go.FilemodeCommit))
+	}
+
+	treeId, err := treeBuilder.Write()
+	require.NoError(t, err)
+
+	return treeId
+}
+
+func requireFileContents(t *testing.T, filePath, expected string) {
+	contents, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(contents))
+}
+
+func resolveHead(t *testing.T, repositoryPath string) *git2go.Oid {
+	repository, err := git2go.OpenRepository(repositoryPath)
+	require.NoError(t, err)
+	defer repository.Free()
+
+	ref, err := repository.References.Lookup("refs/heads/main")
+	require.NoError(t, err)
+	defer ref.Free()
+
+	return ref.Target()
+}
+
+type basicAuthGitKeychain struct{}
+
+func (basicAuthGitKeychain) Resolve(url string, usernameFromUrl string, allowedTypes git2go.CredentialType) (Git2GoCredential, error) {
+	return BasicGit2GoAuth{Username: "some-username", Password: "some-password"}, nil
+}
+
 type fakeGitKeychain struct{}
 
 func (f fakeGitKeychain) Resolve(url string, usernameFromUrl string, allowedTypes git2go.CredentialType) (Git2GoCredential, error) {
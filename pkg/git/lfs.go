@@ -0,0 +1,350 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+	giturls "github.com/whilp/git-urls"
+)
+
+const (
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	lfsMaxPointerSize = 1024
+	lfsMediaType      = "application/vnd.git-lfs+json"
+	lfsBatchSize      = 100
+)
+
+var (
+	lfsBatchClient    = &http.Client{Timeout: time.Minute}
+	lfsDownloadClient = &http.Client{Timeout: 30 * time.Minute}
+)
+
+type lfsPointer struct {
+	Path string `json:"-"`
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type lfsBatchRequest struct {
+	Operation string       `json:"operation"`
+	Transfers []string     `json:"transfers"`
+	Objects   []lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsObject `json:"objects"`
+}
+
+type lfsObject struct {
+	Oid     string `json:"oid"`
+	Size    int64  `json:"size"`
+	Actions struct {
+		Download *lfsAction `json:"download"`
+	} `json:"actions"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+// smudgeLFS replaces the Git LFS pointer files checked out in the repository with the
+// objects they reference downloaded from the LFS server of the repository. Credentials
+// are only sent to an LFS server on the host of gitURL, an lfs.url in the .lfsconfig of
+// the repository pointing at another host is requested anonymously.
+func (f Fetcher) smudgeLFS(repository *git2go.Repository, gitURL string) error {
+	workdir := repository.Workdir()
+
+	pointers, err := lfsPointers(repository, workdir)
+	if err != nil {
+		return err
+	}
+	if len(pointers) == 0 {
+		return nil
+	}
+
+	endpoint, err := lfsEndpoint(workdir, gitURL)
+	if err != nil {
+		return err
+	}
+
+	f.Logger.Printf("Downloading %d LFS objects from %q...", len(pointers), endpoint)
+
+	for start := 0; start < len(pointers); start += lfsBatchSize {
+		end := start + lfsBatchSize
+		if end > len(pointers) {
+			end = len(pointers)
+		}
+
+		err := f.downloadLFSObjects(endpoint, gitURL, pointers[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f Fetcher) downloadLFSObjects(endpoint, gitURL string, pointers []lfsPointer) error {
+	objects, err := f.lfsBatch(endpoint, gitURL, pointers)
+	if err != nil {
+		return err
+	}
+
+	for _, pointer := range pointers {
+		object, ok := objects[pointer.Oid]
+		if !ok {
+			return errors.Errorf("lfs object %s for %s not returned by server", pointer.Oid, pointer.Path)
+		}
+		if object.Error != nil {
+			return errors.Errorf("lfs object %s for %s: %s", pointer.Oid, pointer.Path, object.Error.Message)
+		}
+		if object.Actions.Download == nil {
+			return errors.Errorf("lfs object %s for %s has no download action", pointer.Oid, pointer.Path)
+		}
+
+		err := downloadLFSObject(pointer, object.Actions.Download)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f Fetcher) lfsBatch(endpoint, gitURL string, pointers []lfsPointer) (map[string]lfsObject, error) {
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   pointers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+
+	if sameHost(endpoint, gitURL) {
+		if cred, err := f.Keychain.Resolve(endpoint, "", git2go.CredentialTypeUserpassPlaintext); err == nil {
+			if basicAuth, ok := cred.(BasicGit2GoAuth); ok {
+				req.SetBasicAuth(basicAuth.Username, basicAuth.Password)
+			}
+		}
+	}
+
+	resp, err := lfsBatchClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "requesting lfs objects")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("requesting lfs objects: unexpected status code %d", resp.StatusCode)
+	}
+
+	var batchResponse lfsBatchResponse
+	err = json.NewDecoder(resp.Body).Decode(&batchResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding lfs batch response")
+	}
+
+	objects := make(map[string]lfsObject, len(batchResponse.Objects))
+	for _, object := range batchResponse.Objects {
+		objects[object.Oid] = object
+	}
+	return objects, nil
+}
+
+func downloadLFSObject(pointer lfsPointer, action *lfsAction) error {
+	req, err := http.NewRequest(http.MethodGet, action.Href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range action.Header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := lfsDownloadClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "downloading lfs object for %s", pointer.Path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("downloading lfs object for %s: unexpected status code %d", pointer.Path, resp.StatusCode)
+	}
+
+	info, err := os.Stat(pointer.Path)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(pointer.Path), ".lfs-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hash), resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "downloading lfs object for %s", pointer.Path)
+	}
+
+	if size != pointer.Size || hex.EncodeToString(hash.Sum(nil)) != pointer.Oid {
+		return errors.Errorf("lfs object for %s does not match oid %s", pointer.Path, pointer.Oid)
+	}
+
+	err = tmpFile.Chmod(info.Mode())
+	if err != nil {
+		return err
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), pointer.Path)
+}
+
+func lfsPointers(repository *git2go.Repository, workdir string) ([]lfsPointer, error) {
+	index, err := repository.Index()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading index")
+	}
+	defer index.Free()
+
+	var pointers []lfsPointer
+	for i := uint(0); i < index.EntryCount(); i++ {
+		entry, err := index.EntryByIndex(i)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading index entry")
+		}
+
+		if (entry.Mode != git2go.FilemodeBlob && entry.Mode != git2go.FilemodeBlobExecutable) || entry.Size > lfsMaxPointerSize {
+			continue
+		}
+
+		filePath := filepath.Join(workdir, entry.Path)
+		contents, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		pointer, ok := parseLFSPointer(contents)
+		if !ok {
+			continue
+		}
+
+		pointer.Path = filePath
+		pointers = append(pointers, pointer)
+	}
+	return pointers, nil
+}
+
+func parseLFSPointer(contents []byte) (lfsPointer, bool) {
+	if !bytes.HasPrefix(contents, []byte(lfsPointerVersion)) {
+		return lfsPointer{}, false
+	}
+
+	var pointer lfsPointer
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		key, value, found := cut(scanner.Text(), " ")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "oid":
+			pointer.Oid = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return lfsPointer{}, false
+			}
+			pointer.Size = size
+		}
+	}
+
+	return pointer, pointer.Oid != "" && pointer.Size >= 0
+}
+
+// lfsEndpoint returns the lfs.url configured in the .lfsconfig of the repository or
+// the LFS server that is hosted alongside the git repository.
+func lfsEndpoint(workdir, gitURL string) (string, error) {
+	lfsConfigPath := filepath.Join(workdir, ".lfsconfig")
+	if _, err := os.Stat(lfsConfigPath); err == nil {
+		config, err := git2go.OpenOndisk(lfsConfigPath)
+		if err != nil {
+			return "", errors.Wrap(err, "reading .lfsconfig")
+		}
+		defer config.Free()
+
+		if endpoint, err := config.LookupString("lfs.url"); err == nil && endpoint != "" {
+			return strings.TrimSuffix(endpoint, "/"), nil
+		}
+	}
+
+	u, err := giturls.Parse(gitURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing git url %s", gitURL)
+	}
+
+	scheme, host := u.Scheme, u.Host
+	if scheme != "http" && scheme != "https" {
+		scheme, host = "https", u.Hostname()
+	}
+
+	repoPath := strings.TrimSuffix(strings.TrimPrefix(u.Path, "/"), "/")
+	if !strings.HasSuffix(repoPath, ".git") {
+		repoPath += ".git"
+	}
+
+	return fmt.Sprintf("%s://%s/%s/info/lfs", scheme, host, repoPath), nil
+}
+
+func sameHost(endpoint, gitURL string) bool {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+
+	repositoryURL, err := giturls.Parse(gitURL)
+	if err != nil {
+		return false
+	}
+
+	return endpointURL.Hostname() != "" && strings.EqualFold(endpointURL.Hostname(), repositoryURL.Hostname())
+}
+
+func cut(s, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,94 @@
+package git
+
+import (
+	"path"
+	"strings"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+	ignore "github.com/sabhiram/go-gitignore"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+type pathMatcher struct {
+	include *ignore.GitIgnore
+	exclude *ignore.GitIgnore
+}
+
+func newPathMatcher(filter *corev1alpha1.GitPathFilter, subPath string) pathMatcher {
+	if filter == nil {
+		filter = &corev1alpha1.GitPathFilter{}
+	}
+
+	include := filter.Include
+	if len(include) == 0 {
+		include = []string{subPathPattern(subPath)}
+	}
+
+	return pathMatcher{
+		include: ignore.CompileIgnoreLines(include...),
+		exclude: ignore.CompileIgnoreLines(filter.Exclude...),
+	}
+}
+
+func (m pathMatcher) matches(filePath string) bool {
+	return m.include.MatchesPath(filePath) && !m.exclude.MatchesPath(filePath)
+}
+
+func subPathPattern(subPath string) string {
+	subPath = strings.Trim(path.Clean("/"+subPath), "/")
+	if subPath == "" {
+		return "*"
+	}
+	return "/" + subPath + "/"
+}
+
+// changesMatch reports whether any file that differs between the two commits matches the path filter
+func changesMatch(repository *git2go.Repository, oldRevision, newRevision *git2go.Oid, matcher pathMatcher) (bool, error) {
+	oldTree, err := commitTree(repository, oldRevision)
+	if err != nil {
+		return false, err
+	}
+	defer oldTree.Free()
+
+	newTree, err := commitTree(repository, newRevision)
+	if err != nil {
+		return false, err
+	}
+	defer newTree.Free()
+
+	diff, err := repository.DiffTreeToTree(oldTree, newTree, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "diffing trees")
+	}
+	defer diff.Free()
+
+	numDeltas, err := diff.NumDeltas()
+	if err != nil {
+		return false, errors.Wrap(err, "counting deltas")
+	}
+
+	for i := 0; i < numDeltas; i++ {
+		delta, err := diff.Delta(i)
+		if err != nil {
+			return false, errors.Wrap(err, "reading delta")
+		}
+
+		if matcher.matches(delta.OldFile.Path) || matcher.matches(delta.NewFile.Path) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func commitTree(repository *git2go.Repository, oid *git2go.Oid) (*git2go.Tree, error) {
+	commit, err := repository.LookupCommit(oid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up commit %s", oid)
+	}
+	defer commit.Free()
+
+	return commit.Tree()
+}
@@ -0,0 +1,46 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestPathMatcher(t *testing.T) {
+	spec.Run(t, "TestPathMatcher", testPathMatcher)
+}
+
+func testPathMatcher(t *testing.T, when spec.G, it spec.S) {
+	when("include is empty", func() {
+		it("includes the sub path", func() {
+			matcher := newPathMatcher(&corev1alpha1.GitPathFilter{}, "apps/web")
+
+			assert.True(t, matcher.matches("apps/web/index.js"))
+			assert.True(t, matcher.matches("apps/web/src/main.js"))
+			assert.False(t, matcher.matches("apps/api/main.go"))
+			assert.False(t, matcher.matches("README.md"))
+		})
+
+		it("includes everything without a sub path", func() {
+			matcher := newPathMatcher(&corev1alpha1.GitPathFilter{}, "")
+
+			assert.True(t, matcher.matches("README.md"))
+			assert.True(t, matcher.matches("apps/api/main.go"))
+		})
+	})
+
+	it("matches include patterns that are not excluded", func() {
+		matcher := newPathMatcher(&corev1alpha1.GitPathFilter{
+			Include: []string{"apps/web/", "libs/"},
+			Exclude: []string{"*.md"},
+		}, "apps/web")
+
+		assert.True(t, matcher.matches("apps/web/index.js"))
+		assert.True(t, matcher.matches("libs/shared/util.js"))
+		assert.False(t, matcher.matches("apps/web/README.md"))
+		assert.False(t, matcher.matches("apps/api/main.go"))
+	})
+}
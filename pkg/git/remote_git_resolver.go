@@ -20,7 +20,9 @@ var discardLogger = log.New(ioutil.Discard, "", 0)
 type remoteGitResolver struct {
 }
 
-func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha1.SourceConfig) (corev1alpha1.ResolvedSourceConfig, error) {
+// Resolve resolves the revision of the source. Sources with a path filter are compared against builtRevision
+// and previous is the source resolved for the same source configuration, if any.
+func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha1.SourceConfig, builtRevision string, previous *corev1alpha1.ResolvedGitSource) (corev1alpha1.ResolvedSourceConfig, error) {
 	dir, err := ioutil.TempDir("", "git-resolve")
 	if err != nil {
 		return corev1alpha1.ResolvedSourceConfig{}, err
@@ -51,10 +53,13 @@ func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha
 	if err != nil {
 		return corev1alpha1.ResolvedSourceConfig{
 			Git: &corev1alpha1.ResolvedGitSource{
-				URL:      sourceConfig.Git.URL,
-				Revision: sourceConfig.Git.Revision,
-				Type:     corev1alpha1.Unknown,
-				SubPath:  sourceConfig.SubPath,
+				URL:        sourceConfig.Git.URL,
+				Revision:   sourceConfig.Git.Revision,
+				Type:       corev1alpha1.Unknown,
+				SubPath:    sourceConfig.SubPath,
+				Submodules: sourceConfig.Git.Submodules,
+				LFS:        sourceConfig.Git.LFS,
+				Depth:      sourceConfig.Git.Depth,
 			},
 		}, nil
 	}
@@ -67,13 +72,22 @@ func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha
 	for _, ref := range references {
 		for _, format := range refRevParseRules {
 			if fmt.Sprintf(format, sourceConfig.Git.Revision) == ref.Name {
+				resolved := corev1alpha1.ResolvedGitSource{
+					URL:        sourceConfig.Git.URL,
+					Revision:   ref.Id.String(),
+					Type:       sourceType(ref),
+					SubPath:    sourceConfig.SubPath,
+					Submodules: sourceConfig.Git.Submodules,
+					LFS:        sourceConfig.Git.LFS,
+					Depth:      sourceConfig.Git.Depth,
+				}
+
+				if sourceConfig.GitPathFiltered() {
+					resolved = filterRevision(repository, remote, keychain, ref.Name, sourceConfig, builtRevision, previous, resolved)
+				}
+
 				return corev1alpha1.ResolvedSourceConfig{
-					Git: &corev1alpha1.ResolvedGitSource{
-						URL:      sourceConfig.Git.URL,
-						Revision: ref.Id.String(),
-						Type:     sourceType(ref),
-						SubPath:  sourceConfig.SubPath,
-					},
+					Git: &resolved,
 				}, nil
 			}
 		}
@@ -81,14 +95,65 @@ func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha
 
 	return corev1alpha1.ResolvedSourceConfig{
 		Git: &corev1alpha1.ResolvedGitSource{
-			URL:      sourceConfig.Git.URL,
-			Revision: sourceConfig.Git.Revision,
-			Type:     corev1alpha1.Commit,
-			SubPath:  sourceConfig.SubPath,
+			URL:        sourceConfig.Git.URL,
+			Revision:   sourceConfig.Git.Revision,
+			Type:       corev1alpha1.Commit,
+			SubPath:    sourceConfig.SubPath,
+			Submodules: sourceConfig.Git.Submodules,
+			LFS:        sourceConfig.Git.LFS,
+			Depth:      sourceConfig.Git.Depth,
 		},
 	}, nil
 }
 
+// filterRevision keeps the built revision when none of the changes since then touch the path
+// filter of the source. Any error determining the changes resolves to the latest revision so
+// that a source change is never missed.
+func filterRevision(repository *git2go.Repository, remote *git2go.Remote, keychain GitKeychain, refName string, sourceConfig corev1alpha1.SourceConfig, builtRevision string, previous *corev1alpha1.ResolvedGitSource, resolved corev1alpha1.ResolvedGitSource) corev1alpha1.ResolvedGitSource {
+	if builtRevision == "" || builtRevision == resolved.Revision {
+		return resolved
+	}
+
+	if previous != nil && previous.Revision == builtRevision && previous.LatestRevision == resolved.Revision {
+		resolved.Revision = previous.Revision
+		resolved.LatestRevision = previous.LatestRevision
+		return resolved
+	}
+
+	err := remote.Fetch([]string{fmt.Sprintf("+%s:%s", refName, refName)}, &git2go.FetchOptions{
+		DownloadTags: git2go.DownloadTagsNone,
+		RemoteCallbacks: git2go.RemoteCallbacks{
+			CredentialsCallback:      keychainAsCredentialsCallback(keychain),
+			CertificateCheckCallback: certificateCheckCallback(),
+		},
+		ProxyOptions: git2go.ProxyOptions{
+			Type: git2go.ProxyTypeAuto,
+		},
+	}, "")
+	if err != nil {
+		return resolved
+	}
+
+	oldRevision, err := git2go.NewOid(builtRevision)
+	if err != nil {
+		return resolved
+	}
+
+	newRevision, err := git2go.NewOid(resolved.Revision)
+	if err != nil {
+		return resolved
+	}
+
+	matched, err := changesMatch(repository, oldRevision, newRevision, newPathMatcher(sourceConfig.Git.PathFilter, sourceConfig.SubPath))
+	if err != nil || matched {
+		return resolved
+	}
+
+	resolved.LatestRevision = resolved.Revision
+	resolved.Revision = builtRevision
+	return resolved
+}
+
 func sourceType(reference git2go.RemoteHead) corev1alpha1.GitSourceKind {
 	switch {
 	case strings.HasPrefix(reference.Name, "refs/heads"):
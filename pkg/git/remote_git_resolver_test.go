@@ -1,8 +1,12 @@
 package git
 
 import (
+	"io/ioutil"
+	"os"
+	"path"
 	"testing"
 
+	git2go "github.com/libgit2/git2go/v33"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,7 +38,7 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 						Revision: nonHEADCommit,
 					},
 					SubPath: "/foo/bar",
-				})
+				}, "", nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -58,7 +62,7 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 						Revision: "master",
 					},
 					SubPath: "/foo/bar",
-				})
+				}, "", nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -84,7 +88,7 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 						Revision: tag,
 					},
 					SubPath: "/foo/bar",
-				})
+				}, "", nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -108,7 +112,7 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 						Revision: tag,
 					},
 					SubPath: "/foo/bar",
-				})
+				}, "", nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -121,5 +125,165 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("the source is path filtered", func() {
+			var (
+				repositoriesDir string
+				appPath         string
+				builtCommit     *git2go.Oid
+				apiCommit       *git2go.Oid
+				gitResolver     = &remoteGitResolver{}
+			)
+
+			it.Before(func() {
+				var err error
+				repositoriesDir, err = ioutil.TempDir("", "test-git-repositories")
+				require.NoError(t, err)
+
+				appPath, builtCommit = createBareRepository(t, path.Join(repositoriesDir, "app"), map[string]string{
+					"web/index.js": "v1",
+					"api/main.go":  "v1",
+				}, nil)
+
+				repository, err := git2go.OpenRepository(appPath)
+				require.NoError(t, err)
+				defer repository.Free()
+
+				apiCommit = createCommit(t, repository, "refs/heads/main", map[string]string{
+					"web/index.js": "v1",
+					"api/main.go":  "v2",
+				}, nil)
+			})
+
+			it.After(func() {
+				require.NoError(t, os.RemoveAll(repositoriesDir))
+			})
+
+			resolve := func(sourceConfig corev1alpha1.SourceConfig, builtRevision string, previous *corev1alpha1.ResolvedGitSource) *corev1alpha1.ResolvedGitSource {
+				resolved, err := gitResolver.Resolve(&fakeGitKeychain{}, sourceConfig, builtRevision, previous)
+				require.NoError(t, err)
+				require.NotNil(t, resolved.Git)
+				return resolved.Git
+			}
+
+			it("keeps the built revision when the changes are outside the sub path", func() {
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git:     &corev1alpha1.Git{URL: appPath, Revision: "main", PathFilter: &corev1alpha1.GitPathFilter{}},
+					SubPath: "web",
+				}, builtCommit.String(), nil)
+
+				assert.Equal(t, &corev1alpha1.ResolvedGitSource{
+					URL:            appPath,
+					Revision:       builtCommit.String(),
+					LatestRevision: apiCommit.String(),
+					Type:           corev1alpha1.Branch,
+					SubPath:        "web",
+				}, resolved)
+			})
+
+			it("resolves the latest revision when the changes touch the sub path", func() {
+				repository, err := git2go.OpenRepository(appPath)
+				require.NoError(t, err)
+				defer repository.Free()
+
+				webCommit := createCommit(t, repository, "refs/heads/main", map[string]string{
+					"web/index.js": "v2",
+					"api/main.go":  "v2",
+				}, nil)
+
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git:     &corev1alpha1.Git{URL: appPath, Revision: "main", PathFilter: &corev1alpha1.GitPathFilter{}},
+					SubPath: "web",
+				}, builtCommit.String(), nil)
+
+				assert.Equal(t, webCommit.String(), resolved.Revision)
+				assert.Empty(t, resolved.LatestRevision)
+			})
+
+			it("does not filter a sub path without a path filter", func() {
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git:     &corev1alpha1.Git{URL: appPath, Revision: "main"},
+					SubPath: "web",
+				}, builtCommit.String(), nil)
+
+				assert.Equal(t, apiCommit.String(), resolved.Revision)
+				assert.Empty(t, resolved.LatestRevision)
+			})
+
+			it("filters with the include and exclude patterns", func() {
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:        appPath,
+						Revision:   "main",
+						PathFilter: &corev1alpha1.GitPathFilter{Include: []string{"api/"}},
+					},
+				}, builtCommit.String(), nil)
+				assert.Equal(t, apiCommit.String(), resolved.Revision)
+
+				resolved = resolve(corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:        appPath,
+						Revision:   "main",
+						PathFilter: &corev1alpha1.GitPathFilter{Include: []string{"api/"}, Exclude: []string{"*.go"}},
+					},
+				}, builtCommit.String(), nil)
+				assert.Equal(t, builtCommit.String(), resolved.Revision)
+				assert.Equal(t, apiCommit.String(), resolved.LatestRevision)
+			})
+
+			it("reuses the previous resolution while the built and latest revisions are unchanged", func() {
+				previous := &corev1alpha1.ResolvedGitSource{
+					URL:            appPath,
+					Revision:       builtCommit.String(),
+					LatestRevision: apiCommit.String(),
+					Type:           corev1alpha1.Branch,
+				}
+
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git:     &corev1alpha1.Git{URL: appPath, Revision: "main", PathFilter: &corev1alpha1.GitPathFilter{}},
+					SubPath: "api",
+				}, builtCommit.String(), previous)
+
+				assert.Equal(t, builtCommit.String(), resolved.Revision)
+				assert.Equal(t, apiCommit.String(), resolved.LatestRevision)
+			})
+
+			it("compares against the built revision instead of the previously resolved revision", func() {
+				previous := &corev1alpha1.ResolvedGitSource{
+					URL:            appPath,
+					Revision:       "0000000000000000000000000000000000000000",
+					LatestRevision: apiCommit.String(),
+					Type:           corev1alpha1.Branch,
+				}
+
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git:     &corev1alpha1.Git{URL: appPath, Revision: "main", PathFilter: &corev1alpha1.GitPathFilter{}},
+					SubPath: "api",
+				}, builtCommit.String(), previous)
+
+				assert.Equal(t, apiCommit.String(), resolved.Revision)
+				assert.Empty(t, resolved.LatestRevision)
+			})
+
+			it("resolves the latest revision without a built revision", func() {
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git:     &corev1alpha1.Git{URL: appPath, Revision: "main"},
+					SubPath: "web",
+				}, "", nil)
+
+				assert.Equal(t, apiCommit.String(), resolved.Revision)
+				assert.Empty(t, resolved.LatestRevision)
+			})
+
+			it("resolves the latest revision when the built revision is not in the repository", func() {
+				resolved := resolve(corev1alpha1.SourceConfig{
+					Git:     &corev1alpha1.Git{URL: appPath, Revision: "main"},
+					SubPath: "web",
+				}, "1111111111111111111111111111111111111111", nil)
+
+				assert.Equal(t, apiCommit.String(), resolved.Revision)
+				assert.Empty(t, resolved.LatestRevision)
+			})
+		})
 	})
 }
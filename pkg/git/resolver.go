@@ -27,7 +27,13 @@ func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceR
 		return corev1alpha1.ResolvedSourceConfig{}, err
 	}
 
-	return r.remoteGitResolver.Resolve(keychain, sourceResolver.Spec.Source)
+	// the previously resolved source can only be reused while the source configuration is unchanged
+	var previous *corev1alpha1.ResolvedGitSource
+	if sourceResolver.Status.ObservedGeneration == sourceResolver.Generation {
+		previous = sourceResolver.Status.Source.Git
+	}
+
+	return r.remoteGitResolver.Resolve(keychain, sourceResolver.Spec.Source, sourceResolver.Spec.BuiltRevision, previous)
 }
 
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
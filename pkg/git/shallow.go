@@ -0,0 +1,113 @@
+package git
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+	giturls "github.com/whilp/git-urls"
+)
+
+// shallowFetch fetches the refspecs from the origin remote of the repository in workdir with
+// the git cli limited to the depth of the fetcher. The credentials of the keychain are passed
+// to git in its environment and are only sent to the host of gitURL.
+func (f Fetcher) shallowFetch(workdir, gitURL string, refspecs []string) error {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return err
+	}
+
+	env, cleanup, err := f.gitCliEnv(gitURL)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	args := append([]string{
+		"-C", workdir,
+		"fetch",
+		"--no-tags",
+		"--update-head-ok",
+		"--refmap=",
+		fmt.Sprintf("--depth=%d", f.Depth),
+		"origin",
+	}, refspecs...)
+
+	cmd := exec.Command(gitPath, args...)
+	cmd.Env = env
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Errorf("git fetch: %s: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// gitCliEnv returns the environment of the git cli with the credentials for gitURL. Fetching
+// without credentials is attempted if the keychain has none, as libgit2 would.
+func (f Fetcher) gitCliEnv(gitURL string) ([]string, func(), error) {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cleanup := func() {}
+
+	u, err := giturls.Parse(gitURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		cred, err := f.Keychain.Resolve(gitURL, "", git2go.CredentialTypeUserpassPlaintext)
+		if err != nil {
+			return env, cleanup, nil
+		}
+
+		basicAuth, ok := cred.(BasicGit2GoAuth)
+		if !ok {
+			return env, cleanup, nil
+		}
+
+		token := base64.StdEncoding.EncodeToString([]byte(basicAuth.Username + ":" + basicAuth.Password))
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			fmt.Sprintf("GIT_CONFIG_KEY_0=http.%s://%s/.extraHeader", u.Scheme, u.Host),
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+token,
+		)
+	case "ssh":
+		username := "git"
+		if u.User != nil && u.User.Username() != "" {
+			username = u.User.Username()
+		}
+
+		cred, err := f.Keychain.Resolve(gitURL, username, git2go.CredentialTypeSSHKey)
+		if err != nil {
+			return env, cleanup, nil
+		}
+
+		sshAuth, ok := cred.(SSHGit2GoAuth)
+		if !ok {
+			return env, cleanup, nil
+		}
+
+		keyFile, err := ioutil.TempFile("", "git-ssh-key")
+		if err != nil {
+			return nil, nil, err
+		}
+		cleanup = func() { os.Remove(keyFile.Name()) }
+
+		_, err = keyFile.WriteString(strings.TrimSpace(sshAuth.PrivateKey) + "\n")
+		keyFile.Close()
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+
+		// host keys are not verified, matching the certificate check of libgit2 fetches
+		env = append(env, fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes -o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null", keyFile.Name()))
+	}
+
+	return env, cleanup, nil
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+)
+
+// updateSubmodules recursively initializes and checks out the submodules of the repository
+// at the commits recorded in its tree using the credentials of the fetcher keychain.
+func (f Fetcher) updateSubmodules(repository *git2go.Repository) error {
+	var names []string
+	err := repository.Submodules.Foreach(func(_ *git2go.Submodule, name string) error {
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing submodules")
+	}
+
+	for _, name := range names {
+		err := f.updateSubmodule(repository, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f Fetcher) updateSubmodule(repository *git2go.Repository, name string) error {
+	submodule, err := repository.Submodules.Lookup(name)
+	if err != nil {
+		return errors.Wrapf(err, "looking up submodule %s", name)
+	}
+	defer submodule.Free()
+
+	f.Logger.Printf("Updating submodule %q from %q...", submodule.Path(), submodule.Url())
+
+	err = submodule.Update(true, &git2go.SubmoduleUpdateOptions{
+		CheckoutOptions: git2go.CheckoutOptions{
+			Strategy: git2go.CheckoutForce,
+		},
+		FetchOptions: *f.fetchOptions(git2go.DownloadTagsAuto),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "updating submodule %s", name)
+	}
+
+	submoduleRepository, err := submodule.Open()
+	if err != nil {
+		return errors.Wrapf(err, "opening submodule %s", name)
+	}
+	defer submoduleRepository.Free()
+
+	err = f.updateSubmodules(submoduleRepository)
+	if err != nil {
+		return err
+	}
+
+	if f.LFS {
+		return f.smudgeLFS(submoduleRepository, submoduleURL(submoduleRepository, submodule))
+	}
+	return nil
+}
+
+// submoduleURL returns the url the submodule was fetched from, relative submodule urls
+// are resolved against the url of the parent repository in the origin remote.
+func submoduleURL(repository *git2go.Repository, submodule *git2go.Submodule) string {
+	remote, err := repository.Remotes.Lookup(defaultRemote)
+	if err != nil {
+		return submodule.Url()
+	}
+	defer remote.Free()
+
+	return remote.Url()
+}
@@ -0,0 +1,69 @@
+package gitwebhook
+
+import (
+	"fmt"
+	"strings"
+
+	giturls "github.com/whilp/git-urls"
+	"k8s.io/client-go/tools/cache"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+const GitURLIndex = "gitURL"
+
+// GitURLIndexFunc indexes SourceResolvers by the normalized url of their git source
+func GitURLIndexFunc(obj interface{}) ([]string, error) {
+	sourceResolver, ok := obj.(*buildapi.SourceResolver)
+	if !ok {
+		return nil, fmt.Errorf("expected a SourceResolver, got %T", obj)
+	}
+
+	if !sourceResolver.IsGit() {
+		return nil, nil
+	}
+
+	normalized, err := normalizeGitURL(sourceResolver.Spec.Source.Git.URL)
+	if err != nil {
+		return nil, nil
+	}
+
+	return []string{normalized}, nil
+}
+
+func AddIndexers(informer cache.SharedIndexInformer) error {
+	return informer.AddIndexers(cache.Indexers{GitURLIndex: GitURLIndexFunc})
+}
+
+// normalizeGitURL reduces the https and ssh forms of a repository url to host/path
+// so that a push to a repository matches regardless of the form used in the source config
+func normalizeGitURL(rawURL string) (string, error) {
+	u, err := giturls.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", err
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("could not determine host for git url %s", rawURL)
+	}
+
+	path := strings.Trim(u.Path, "/")
+	path = strings.TrimSuffix(path, ".git")
+
+	return strings.ToLower(u.Hostname()) + "/" + path, nil
+}
+
+var refMatchingRules = []string{
+	"refs/%s",
+	"refs/tags/%s",
+	"refs/heads/%s",
+}
+
+func revisionMatchesRef(revision, ref string) bool {
+	for _, format := range refMatchingRules {
+		if fmt.Sprintf(format, revision) == ref {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	githubSignatureHeader    = "X-Hub-Signature-256"
+	githubEventHeader        = "X-GitHub-Event"
+	gitlabTokenHeader        = "X-Gitlab-Token"
+	gitlabEventHeader        = "X-Gitlab-Event"
+	bitbucketSignatureHeader = "X-Hub-Signature"
+	bitbucketEventHeader     = "X-Event-Key"
+	genericSignatureHeader   = "X-Hub-Signature-256"
+
+	signaturePrefix = "sha256="
+)
+
+var errUnauthorized = errors.New("invalid webhook signature")
+
+// pushEvent is the provider independent subset of a push payload needed to find SourceResolvers
+type pushEvent struct {
+	urls []string
+	refs []string
+}
+
+// provider validates and parses the push payloads of a single git hosting service.
+// A nil event with a nil error indicates a payload that does not describe a push.
+type provider interface {
+	authenticate(header http.Header, body, secret []byte) error
+	parse(header http.Header, body []byte) (*pushEvent, error)
+}
+
+type github struct{}
+
+func (github) authenticate(header http.Header, body, secret []byte) error {
+	return validateHMAC(header.Get(githubSignatureHeader), body, secret)
+}
+
+func (github) parse(header http.Header, body []byte) (*pushEvent, error) {
+	if header.Get(githubEventHeader) != "push" {
+		return nil, nil
+	}
+
+	var payload struct {
+		Ref        string `json:"ref"`
+		Repository struct {
+			CloneURL string `json:"clone_url"`
+			SSHURL   string `json:"ssh_url"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "parsing github push payload")
+	}
+
+	return &pushEvent{
+		urls: nonEmpty(payload.Repository.CloneURL, payload.Repository.SSHURL, payload.Repository.HTMLURL),
+		refs: nonEmpty(payload.Ref),
+	}, nil
+}
+
+type gitlab struct{}
+
+// GitLab does not sign payloads, it sends the configured secret token as a header instead
+func (gitlab) authenticate(header http.Header, _, secret []byte) error {
+	token := header.Get(gitlabTokenHeader)
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+		return errUnauthorized
+	}
+	return nil
+}
+
+func (gitlab) parse(header http.Header, body []byte) (*pushEvent, error) {
+	switch header.Get(gitlabEventHeader) {
+	case "Push Hook", "Tag Push Hook":
+	default:
+		return nil, nil
+	}
+
+	var payload struct {
+		Ref     string `json:"ref"`
+		Project struct {
+			GitHTTPURL string `json:"git_http_url"`
+			GitSSHURL  string `json:"git_ssh_url"`
+			WebURL     string `json:"web_url"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "parsing gitlab push payload")
+	}
+
+	return &pushEvent{
+		urls: nonEmpty(payload.Project.GitHTTPURL, payload.Project.GitSSHURL, payload.Project.WebURL),
+		refs: nonEmpty(payload.Ref),
+	}, nil
+}
+
+type bitbucket struct{}
+
+func (bitbucket) authenticate(header http.Header, body, secret []byte) error {
+	return validateHMAC(header.Get(bitbucketSignatureHeader), body, secret)
+}
+
+func (bitbucket) parse(header http.Header, body []byte) (*pushEvent, error) {
+	if header.Get(bitbucketEventHeader) != "repo:push" {
+		return nil, nil
+	}
+
+	var payload struct {
+		Repository struct {
+			Links struct {
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+			} `json:"links"`
+		} `json:"repository"`
+		Push struct {
+			Changes []struct {
+				New *struct {
+					Type string `json:"type"`
+					Name string `json:"name"`
+				} `json:"new"`
+			} `json:"changes"`
+		} `json:"push"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "parsing bitbucket push payload")
+	}
+
+	var refs []string
+	for _, change := range payload.Push.Changes {
+		// a nil new state is a deleted branch or tag which cannot be built
+		if change.New == nil {
+			continue
+		}
+
+		switch change.New.Type {
+		case "branch":
+			refs = append(refs, "refs/heads/"+change.New.Name)
+		case "tag", "annotated_tag":
+			refs = append(refs, "refs/tags/"+change.New.Name)
+		}
+	}
+
+	return &pushEvent{
+		urls: nonEmpty(payload.Repository.Links.HTML.Href),
+		refs: refs,
+	}, nil
+}
+
+// generic accepts a minimal {"url": "", "ref": ""} payload for git servers without a dedicated provider
+type generic struct{}
+
+func (generic) authenticate(header http.Header, body, secret []byte) error {
+	return validateHMAC(header.Get(genericSignatureHeader), body, secret)
+}
+
+func (generic) parse(_ http.Header, body []byte) (*pushEvent, error) {
+	var payload struct {
+		URL string `json:"url"`
+		Ref string `json:"ref"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.Wrap(err, "parsing generic push payload")
+	}
+
+	if payload.URL == "" || payload.Ref == "" {
+		return nil, errors.New("generic push payload requires url and ref")
+	}
+
+	return &pushEvent{
+		urls: []string{payload.URL},
+		refs: []string{payload.Ref},
+	}, nil
+}
+
+func validateHMAC(signature string, body, secret []byte) error {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errUnauthorized
+	}
+
+	actual, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return errUnauthorized
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return errUnauthorized
+	}
+	return nil
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+package gitwebhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+const (
+	SecretKey = "secret"
+
+	maxPayloadBytes = 25 * 1024 * 1024
+)
+
+// Receiver accepts push webhooks from git hosting services and enqueues
+// the SourceResolvers whose git url and revision match the pushed refs
+type Receiver struct {
+	Logger          *zap.SugaredLogger
+	K8sClient       k8sclient.Interface
+	SecretNamespace string
+	SecretName      string
+	Indexer         cache.Indexer
+	Enqueue         func(obj interface{})
+}
+
+func (r *Receiver) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/github", r.handle(github{}))
+	mux.Handle("/gitlab", r.handle(gitlab{}))
+	mux.Handle("/bitbucket", r.handle(bitbucket{}))
+	mux.Handle("/generic", r.handle(generic{}))
+	return mux
+}
+
+func (r *Receiver) handle(p provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxPayloadBytes))
+		if err != nil {
+			http.Error(w, "could not read payload", http.StatusBadRequest)
+			return
+		}
+
+		secret, err := r.secret(req.Context())
+		if err != nil {
+			r.Logger.Errorw("Error reading git webhook secret", zap.Error(err))
+			http.Error(w, "could not read webhook secret", http.StatusInternalServerError)
+			return
+		}
+
+		if err := p.authenticate(req.Header, body, secret); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		event, err := p.parse(req.Header, body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if event == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		sourceResolvers, err := r.matchingSourceResolvers(event)
+		if err != nil {
+			r.Logger.Errorw("Error finding source resolvers for git webhook", zap.Error(err))
+			http.Error(w, "could not find source resolvers", http.StatusInternalServerError)
+			return
+		}
+
+		for _, sourceResolver := range sourceResolvers {
+			r.Enqueue(sourceResolver)
+		}
+
+		r.Logger.Infow("Enqueued source resolvers from git webhook", "urls", event.urls, "refs", event.refs, "count", len(sourceResolvers))
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (r *Receiver) secret(ctx context.Context) ([]byte, error) {
+	secret, err := r.K8sClient.CoreV1().Secrets(r.SecretNamespace).Get(ctx, r.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := secret.Data[SecretKey]
+	if !ok || len(value) == 0 {
+		return nil, errors.Errorf("secret %s/%s is missing key %s", r.SecretNamespace, r.SecretName, SecretKey)
+	}
+	return value, nil
+}
+
+func (r *Receiver) matchingSourceResolvers(event *pushEvent) ([]*buildapi.SourceResolver, error) {
+	seen := map[string]bool{}
+	var matches []*buildapi.SourceResolver
+
+	for _, url := range event.urls {
+		normalized, err := normalizeGitURL(url)
+		if err != nil {
+			continue
+		}
+
+		objs, err := r.Indexer.ByIndex(GitURLIndex, normalized)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range objs {
+			sourceResolver, ok := obj.(*buildapi.SourceResolver)
+			if !ok || !sourceResolver.IsGit() {
+				continue
+			}
+
+			key := sourceResolver.Namespace + "/" + sourceResolver.Name
+			if seen[key] || !revisionMatchesAny(sourceResolver.Spec.Source.Git.Revision, event.refs) {
+				continue
+			}
+
+			seen[key] = true
+			matches = append(matches, sourceResolver)
+		}
+	}
+	return matches, nil
+}
+
+func revisionMatchesAny(revision string, refs []string) bool {
+	for _, ref := range refs {
+		if revisionMatchesRef(revision, ref) {
+			return true
+		}
+	}
+	return false
+}
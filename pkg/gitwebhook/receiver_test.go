@@ -0,0 +1,237 @@
+package gitwebhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestReceiver(t *testing.T) {
+	spec.Run(t, "Git Webhook Receiver", testReceiver)
+}
+
+func testReceiver(t *testing.T, when spec.G, it spec.S) {
+	const (
+		secretNamespace = "kpack"
+		secretName      = "git-webhook"
+		webhookSecret   = "some-secret"
+	)
+
+	var (
+		indexer  cache.Indexer
+		enqueued []interface{}
+		handler  http.Handler
+	)
+
+	gitSourceResolver := func(name, url, revision string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      url,
+						Revision: revision,
+					},
+				},
+			},
+		}
+	}
+
+	mainResolver := gitSourceResolver("main", "https://github.com/org/repo.git", "main")
+	sshResolver := gitSourceResolver("ssh", "git@github.com:org/repo.git", "main")
+	tagResolver := gitSourceResolver("tag", "https://github.com/org/repo", "v1.0.0")
+	otherBranchResolver := gitSourceResolver("other-branch", "https://github.com/org/repo", "other")
+	otherRepoResolver := gitSourceResolver("other-repo", "https://github.com/org/other", "main")
+	blobResolver := &buildapi.SourceResolver{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blob",
+			Namespace: "some-namespace",
+		},
+		Spec: buildapi.SourceResolverSpec{
+			Source: corev1alpha1.SourceConfig{
+				Blob: &corev1alpha1.Blob{URL: "https://github.com/org/repo"},
+			},
+		},
+	}
+
+	it.Before(func() {
+		enqueued = nil
+		indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{GitURLIndex: GitURLIndexFunc})
+		for _, sr := range []*buildapi.SourceResolver{mainResolver, sshResolver, tagResolver, otherBranchResolver, otherRepoResolver, blobResolver} {
+			require.NoError(t, indexer.Add(sr))
+		}
+
+		receiver := &Receiver{
+			Logger: zap.NewNop().Sugar(),
+			K8sClient: fake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: secretNamespace,
+				},
+				Data: map[string][]byte{SecretKey: []byte(webhookSecret)},
+			}),
+			SecretNamespace: secretNamespace,
+			SecretName:      secretName,
+			Indexer:         indexer,
+			Enqueue: func(obj interface{}) {
+				enqueued = append(enqueued, obj)
+			},
+		}
+		handler = receiver.Handler()
+	})
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte(webhookSecret))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	post := func(path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	when("github", func() {
+		const payload = `{"ref": "refs/heads/main", "repository": {"clone_url": "https://github.com/org/repo.git", "ssh_url": "git@github.com:org/repo.git", "html_url": "https://github.com/org/repo"}}`
+
+		it("enqueues source resolvers matching the repository and branch", func() {
+			recorder := post("/github", payload, map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": sign(payload),
+			})
+
+			require.Equal(t, http.StatusAccepted, recorder.Code)
+			require.ElementsMatch(t, []interface{}{mainResolver, sshResolver}, enqueued)
+		})
+
+		it("matches tags", func() {
+			tagPayload := `{"ref": "refs/tags/v1.0.0", "repository": {"clone_url": "https://github.com/org/repo.git"}}`
+			recorder := post("/github", tagPayload, map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": sign(tagPayload),
+			})
+
+			require.Equal(t, http.StatusAccepted, recorder.Code)
+			require.Equal(t, []interface{}{tagResolver}, enqueued)
+		})
+
+		it("rejects invalid signatures", func() {
+			recorder := post("/github", payload, map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": sign("some-other-payload"),
+			})
+
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			require.Empty(t, enqueued)
+		})
+
+		it("rejects missing signatures", func() {
+			recorder := post("/github", payload, map[string]string{
+				"X-GitHub-Event": "push",
+			})
+
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			require.Empty(t, enqueued)
+		})
+
+		it("ignores non push events", func() {
+			recorder := post("/github", `{}`, map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": sign(`{}`),
+			})
+
+			require.Equal(t, http.StatusNoContent, recorder.Code)
+			require.Empty(t, enqueued)
+		})
+	})
+
+	when("gitlab", func() {
+		const payload = `{"ref": "refs/heads/main", "project": {"git_http_url": "https://github.com/org/repo.git", "git_ssh_url": "git@github.com:org/repo.git"}}`
+
+		it("enqueues source resolvers with a valid token", func() {
+			recorder := post("/gitlab", payload, map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": webhookSecret,
+			})
+
+			require.Equal(t, http.StatusAccepted, recorder.Code)
+			require.ElementsMatch(t, []interface{}{mainResolver, sshResolver}, enqueued)
+		})
+
+		it("rejects an invalid token", func() {
+			recorder := post("/gitlab", payload, map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "wrong",
+			})
+
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			require.Empty(t, enqueued)
+		})
+	})
+
+	when("bitbucket", func() {
+		const payload = `{"repository": {"links": {"html": {"href": "https://github.com/org/repo"}}}, "push": {"changes": [{"new": {"type": "branch", "name": "other"}}, {"new": null}]}}`
+
+		it("enqueues source resolvers for each changed branch", func() {
+			recorder := post("/bitbucket", payload, map[string]string{
+				"X-Event-Key":     "repo:push",
+				"X-Hub-Signature": sign(payload),
+			})
+
+			require.Equal(t, http.StatusAccepted, recorder.Code)
+			require.Equal(t, []interface{}{otherBranchResolver}, enqueued)
+		})
+	})
+
+	when("generic", func() {
+		it("enqueues source resolvers for the url and ref", func() {
+			payload := `{"url": "ssh://git@github.com/org/other.git", "ref": "refs/heads/main"}`
+			recorder := post("/generic", payload, map[string]string{
+				"X-Hub-Signature-256": sign(payload),
+			})
+
+			require.Equal(t, http.StatusAccepted, recorder.Code)
+			require.Equal(t, []interface{}{otherRepoResolver}, enqueued)
+		})
+
+		it("rejects payloads without a ref", func() {
+			payload := `{"url": "https://github.com/org/other.git"}`
+			recorder := post("/generic", payload, map[string]string{
+				"X-Hub-Signature-256": sign(payload),
+			})
+
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+			require.Empty(t, enqueued)
+		})
+	})
+
+	it("rejects non post requests", func() {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/github", nil))
+
+		require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
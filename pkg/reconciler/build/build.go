@@ -2,7 +2,11 @@ package build
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"time"
 
+	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
@@ -15,7 +19,9 @@ import (
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/blob"
 	"github.com/pivotal/kpack/pkg/buildpod"
+	"github.com/pivotal/kpack/pkg/buildqueue"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
 	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
@@ -26,8 +32,23 @@ import (
 const (
 	ReconcilerName = "Builds"
 	Kind           = "Build"
+
+	// podDeadlineExceededReason is set by the kubelet when a pod exceeds its activeDeadlineSeconds
+	podDeadlineExceededReason = "DeadlineExceeded"
 )
 
+// registryPhases are the lifecycle phases that read from and write to the registry.
+// Their termination messages fall back to the tail of their logs.
+var registryPhases = map[string]bool{
+	"analyze": true,
+	"restore": true,
+	"export":  true,
+}
+
+// transientErrorPattern matches registry 5xx responses and network errors. Authorization failures,
+// invalid tags and missing repositories are not transient and do not match.
+var transientErrorPattern = regexp.MustCompile(`(?i)(status code:? 5\d\d|5\d\d (internal server error|bad gateway|service unavailable|gateway timeout)|no such host|server misbehaving|i/o timeout|tls handshake timeout|connection reset by peer)`)
+
 //go:generate counterfeiter . MetadataRetriever
 type MetadataRetriever interface {
 	GetBuiltImage(context.Context, *buildapi.Build) (cnb.BuiltImage, error)
@@ -38,6 +59,10 @@ type PodGenerator interface {
 	Generate(context.Context, buildpod.BuildPodable) (*corev1.Pod, error)
 }
 
+type BuildQueue interface {
+	Admit(*buildapi.Build) (int64, error)
+}
+
 func NewController(opt reconciler.Options, k8sClient k8sclient.Interface, informer buildinformers.BuildInformer, podInformer corev1Informers.PodInformer, metadataRetriever MetadataRetriever, podGenerator PodGenerator) *controller.Impl {
 	c := &Reconciler{
 		Client:            opt.Client,
@@ -46,12 +71,39 @@ func NewController(opt reconciler.Options, k8sClient k8sclient.Interface, inform
 		Lister:            informer.Lister(),
 		PodLister:         podInformer.Lister(),
 		PodGenerator:      podGenerator,
+		BuildTimeout:      opt.BuildTimeout,
+		BuildQueue:        buildqueue.New(informer.Informer().GetIndexer(), podInformer.Lister(), opt.MaxConcurrentBuilds, opt.MaxConcurrentBuildsPerNamespace),
+	}
+
+	if err := informer.Informer().AddIndexers(buildqueue.Indexers); err != nil {
+		opt.Logger.Fatalw("could not add build queue indexers", zap.Error(err))
 	}
 
 	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
 
 	informer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
 
+	// a finished or deleted build may free a slot for a queued build
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldBuild, ok := old.(*buildapi.Build)
+			if !ok {
+				return
+			}
+			newBuild, ok := new.(*buildapi.Build)
+			if !ok {
+				return
+			}
+
+			if !oldBuild.Finished() && newBuild.Finished() {
+				impl.FilteredGlobalResync(isQueued, informer.Informer())
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			impl.FilteredGlobalResync(isQueued, informer.Informer())
+		},
+	})
+
 	podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterControllerGK(buildapi.SchemeGroupVersion.WithKind(Kind).GroupKind()),
 		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
@@ -67,6 +119,8 @@ type Reconciler struct {
 	K8sClient         k8sclient.Interface
 	PodLister         v1Listers.PodLister
 	PodGenerator      PodGenerator
+	BuildTimeout      time.Duration
+	BuildQueue        BuildQueue
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -82,27 +136,51 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		return err
 	}
 
+	if build.Spec.Timeout == nil && c.BuildTimeout > 0 && !build.Finished() {
+		return c.setDefaultTimeout(ctx, build)
+	}
+
 	build = build.DeepCopy()
 	build.SetDefaults(ctx)
 
 	err = c.reconcile(ctx, build)
-	if err != nil && !controller.IsPermanentError(err) {
+	requeue, _ := controller.IsRequeueKey(err)
+	if err != nil && !controller.IsPermanentError(err) && !requeue {
 		return err
 	} else if controller.IsPermanentError(err) {
 		build.Status.Error(err)
 	}
 
-	return c.updateStatus(ctx, build)
+	if updateErr := c.updateStatus(ctx, build); updateErr != nil || !requeue {
+		return updateErr
+	}
+	return err
+}
+
+// setDefaultTimeout writes the cluster default timeout to the build so that
+// the build shows the limit that applies to it
+func (c *Reconciler) setDefaultTimeout(ctx context.Context, build *buildapi.Build) error {
+	build = build.DeepCopy()
+	build.Spec.Timeout = &metav1.Duration{Duration: c.BuildTimeout}
+
+	_, err := c.Client.KpackV1alpha2().Builds(build.Namespace).Update(ctx, build, metav1.UpdateOptions{})
+	return err
 }
 
 func (c *Reconciler) reconcile(ctx context.Context, build *buildapi.Build) error {
 	if build.Finished() {
-		return nil
+		return c.deleteTimedOutPod(ctx, build)
+	}
+
+	if build.Spec.Cancelled {
+		return c.cancelBuild(ctx, build)
 	}
 
 	pod, err := c.reconcileBuildPod(ctx, build)
 	if err != nil {
 		return err
+	} else if pod == nil {
+		return nil
 	}
 
 	if build.MetadataReady(pod) {
@@ -126,7 +204,73 @@ func (c *Reconciler) reconcile(ctx context.Context, build *buildapi.Build) error
 	build.Status.PodName = pod.Name
 	build.Status.StepStates = stepStates(pod)
 	build.Status.StepsCompleted = stepCompleted(pod)
-	build.Status.Conditions = conditionForPod(pod)
+	build.Status.Conditions = conditionForPod(build, pod)
+
+	if build.Finished() || build.Spec.Timeout == nil {
+		return nil
+	}
+	return c.reconcileTimeout(ctx, build, pod)
+}
+
+func (c *Reconciler) reconcileTimeout(ctx context.Context, build *buildapi.Build, pod *corev1.Pod) error {
+	remaining := time.Until(pod.CreationTimestamp.Add(build.Spec.Timeout.Duration))
+	if remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+
+	// the pod is deleted once the build is timed out so that a failed
+	// status update cannot lead to the pod being recreated
+	build.Status.Conditions = timedOutConditions(build)
+	return nil
+}
+
+func (c *Reconciler) deleteTimedOutPod(ctx context.Context, build *buildapi.Build) error {
+	if condition := build.Status.GetCondition(corev1alpha1.ConditionSucceeded); condition == nil || condition.Reason != buildapi.BuildTimedOut {
+		return nil
+	}
+
+	pod, err := c.PodLister.Pods(build.Namespace).Get(build.PodName())
+	if k8s_errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return nil
+	}
+
+	err = c.K8sClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Reconciler) cancelBuild(ctx context.Context, build *buildapi.Build) error {
+	pod, err := c.PodLister.Pods(build.Namespace).Get(build.PodName())
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	} else if err == nil {
+		build.Status.PodName = pod.Name
+		build.Status.StepStates = stepStates(pod)
+		build.Status.StepsCompleted = stepCompleted(pod)
+
+		err = c.K8sClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	build.Status.Conditions = corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionFalse,
+			Reason:             buildapi.BuildCancelled,
+			Message:            "Build was cancelled",
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+	}
 	return nil
 }
 
@@ -138,6 +282,16 @@ func (c *Reconciler) reconcileBuildPod(ctx context.Context, build *buildapi.Buil
 		return pod, nil
 	}
 
+	position, err := c.BuildQueue.Admit(build)
+	if err != nil {
+		return nil, err
+	} else if position > 0 {
+		build.Status.QueuePosition = position
+		build.Status.Conditions = queuedConditions(position)
+		return nil, nil
+	}
+	build.Status.QueuePosition = 0
+
 	podConfig, err := c.PodGenerator.Generate(ctx, build)
 	if err != nil {
 		return nil, controller.NewPermanentError(err)
@@ -145,7 +299,7 @@ func (c *Reconciler) reconcileBuildPod(ctx context.Context, build *buildapi.Buil
 	return c.K8sClient.CoreV1().Pods(build.Namespace).Create(ctx, podConfig, metav1.CreateOptions{})
 }
 
-func conditionForPod(pod *corev1.Pod) corev1alpha1.Conditions {
+func conditionForPod(build *buildapi.Build, pod *corev1.Pod) corev1alpha1.Conditions {
 	switch pod.Status.Phase {
 	case corev1.PodSucceeded:
 		return corev1alpha1.Conditions{
@@ -156,10 +310,37 @@ func conditionForPod(pod *corev1.Pod) corev1alpha1.Conditions {
 			},
 		}
 	case corev1.PodFailed:
+		if pod.Status.Reason == podDeadlineExceededReason {
+			return timedOutConditions(build)
+		}
+		if phase, transientErr, ok := transientRegistryError(pod); ok {
+			return corev1alpha1.Conditions{
+				{
+					Type:               corev1alpha1.ConditionSucceeded,
+					Status:             corev1.ConditionFalse,
+					Reason:             buildapi.TransientRegistryError,
+					Message:            fmt.Sprintf("Lifecycle %s phase failed with a transient registry error: %s", phase, transientErr),
+					LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+				},
+			}
+		}
+		if blobDigestMismatch(pod) {
+			return corev1alpha1.Conditions{
+				{
+					Type:               corev1alpha1.ConditionSucceeded,
+					Status:             corev1.ConditionFalse,
+					Reason:             buildapi.BlobDigestMismatch,
+					Message:            "Blob source does not match the expected sha256 digest",
+					LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+				},
+			}
+		}
 		return corev1alpha1.Conditions{
 			{
 				Type:               corev1alpha1.ConditionSucceeded,
 				Status:             corev1.ConditionFalse,
+				Reason:             pod.Status.Reason,
+				Message:            pod.Status.Message,
 				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
 			},
 		}
@@ -189,10 +370,82 @@ func conditionForPod(pod *corev1.Pod) corev1alpha1.Conditions {
 	}
 }
 
+func queuedConditions(position int64) corev1alpha1.Conditions {
+	return corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionUnknown,
+			Reason:             buildapi.BuildQueued,
+			Message:            fmt.Sprintf("Build is queued at position %d", position),
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+		{
+			Type:               buildapi.ConditionQueued,
+			Status:             corev1.ConditionTrue,
+			Message:            fmt.Sprintf("Build is queued at position %d", position),
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+	}
+}
+
+func isQueued(obj interface{}) bool {
+	build, ok := obj.(*buildapi.Build)
+	return ok && !build.Finished() && build.Status.PodName == ""
+}
+
+func timedOutConditions(build *buildapi.Build) corev1alpha1.Conditions {
+	message := "Build timed out"
+	if build.Spec.Timeout != nil {
+		message = fmt.Sprintf("Build timed out after %s", build.Spec.Timeout.Duration)
+	}
+
+	return corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionFalse,
+			Reason:             buildapi.BuildTimedOut,
+			Message:            message,
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+	}
+}
+
+// transientRegistryError reports the lifecycle phase that failed to reach the registry and the
+// error it failed with. Only the matched error is returned as the logs may contain credentials.
+func transientRegistryError(pod *corev1.Pod) (string, string, bool) {
+	for _, s := range pod.Status.InitContainerStatuses {
+		terminated := s.State.Terminated
+		if !registryPhases[s.Name] || terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+
+		if match := transientErrorPattern.FindString(terminated.Message); match != "" {
+			return s.Name, match, true
+		}
+	}
+	return "", "", false
+}
+
+// blobDigestMismatch reports whether the prepare step failed because the blob source did not match its digest
+func blobDigestMismatch(pod *corev1.Pod) bool {
+	for _, s := range pod.Status.InitContainerStatuses {
+		if s.Name == buildapi.PrepareContainerName && s.State.Terminated != nil {
+			return s.State.Terminated.ExitCode == blob.DigestMismatchExitCode
+		}
+	}
+	return false
+}
+
+// stepStates omits the termination messages of the registry phases which hold the tail of their logs
 func stepStates(pod *corev1.Pod) []corev1.ContainerState {
 	states := make([]corev1.ContainerState, 0, len(pod.Status.InitContainerStatuses))
 	for _, s := range pod.Status.InitContainerStatuses {
-		states = append(states, s.State)
+		state := s.State
+		if registryPhases[s.Name] && state.Terminated != nil {
+			state = *state.DeepCopy()
+			state.Terminated.Message = ""
+		}
+		states = append(states, state)
 	}
 	return states
 }
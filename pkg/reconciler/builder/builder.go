@@ -40,6 +40,8 @@ func NewController(opt reconciler.Options,
 	keychainFactory registry.KeychainFactory,
 	clusterStoreInformer buildinformers.ClusterStoreInformer,
 	clusterStackInformer buildinformers.ClusterStackInformer,
+	storeInformer buildinformers.StoreInformer,
+	stackInformer buildinformers.StackInformer,
 ) (*controller.Impl, func()) {
 	c := &Reconciler{
 		Client:             opt.Client,
@@ -48,13 +50,17 @@ func NewController(opt reconciler.Options,
 		KeychainFactory:    keychainFactory,
 		ClusterStoreLister: clusterStoreInformer.Lister(),
 		ClusterStackLister: clusterStackInformer.Lister(),
+		StoreLister:        storeInformer.Lister(),
+		StackLister:        stackInformer.Lister(),
 	}
 	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
-	builderInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
+	builderInformer.Informer().AddEventHandler(reconciler.UpdateFilteringHandler(impl.Enqueue, builderChanged))
 
 	c.Tracker = tracker.New(impl.EnqueueKey, opt.TrackerResyncPeriod())
 	clusterStoreInformer.Informer().AddEventHandler(reconciler.Handler(c.Tracker.OnChanged))
 	clusterStackInformer.Informer().AddEventHandler(reconciler.Handler(c.Tracker.OnChanged))
+	storeInformer.Informer().AddEventHandler(reconciler.Handler(c.Tracker.OnChanged))
+	stackInformer.Informer().AddEventHandler(reconciler.Handler(c.Tracker.OnChanged))
 
 	return impl, func() {
 		impl.GlobalResync(builderInformer.Informer())
@@ -69,6 +75,8 @@ type Reconciler struct {
 	Tracker            reconciler.Tracker
 	ClusterStoreLister buildlisters.ClusterStoreLister
 	ClusterStackLister buildlisters.ClusterStackLister
+	StoreLister        buildlisters.StoreLister
+	StackLister        buildlisters.StackLister
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -103,22 +111,12 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 }
 
 func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Builder) (buildapi.BuilderRecord, error) {
-	clusterStore, err := c.ClusterStoreLister.Get(builder.Spec.Store.Name)
-	if err != nil {
-		return buildapi.BuilderRecord{}, err
-	}
-
-	err = c.Tracker.Track(clusterStore, builder.NamespacedName())
-	if err != nil {
-		return buildapi.BuilderRecord{}, err
-	}
-
-	clusterStack, err := c.ClusterStackLister.Get(builder.Spec.Stack.Name)
+	clusterStore, err := c.resolveStore(builder)
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
 	}
 
-	err = c.Tracker.Track(clusterStack, builder.NamespacedName())
+	clusterStack, err := c.resolveStack(builder)
 	if err != nil {
 		return buildapi.BuilderRecord{}, err
 	}
@@ -138,6 +136,58 @@ func (c *Reconciler) reconcileBuilder(ctx context.Context, builder *buildapi.Bui
 	return c.BuilderCreator.CreateBuilder(keychain, clusterStore, clusterStack, builder.Spec.BuilderSpec)
 }
 
+// resolveStore returns the store referenced by the builder. A namespaced Store
+// is returned in the shape of a ClusterStore as only its status, namespace
+// and verification policy are needed to create the builder.
+func (c *Reconciler) resolveStore(builder *buildapi.Builder) (*buildapi.ClusterStore, error) {
+	if builder.Spec.Store.Kind == buildapi.StoreKind {
+		store, err := c.StoreLister.Stores(builder.Namespace).Get(builder.Spec.Store.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		err = c.Tracker.Track(store, builder.NamespacedName())
+		if err != nil {
+			return nil, err
+		}
+
+		return &buildapi.ClusterStore{ObjectMeta: store.ObjectMeta, Spec: buildapi.ClusterStoreSpec{Verification: store.Spec.Verification}, Status: store.Status}, nil
+	}
+
+	clusterStore, err := c.ClusterStoreLister.Get(builder.Spec.Store.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterStore, c.Tracker.Track(clusterStore, builder.NamespacedName())
+}
+
+// resolveStack returns the stack referenced by the builder. A namespaced Stack
+// is returned in the shape of a ClusterStack as only its status is needed to
+// create the builder.
+func (c *Reconciler) resolveStack(builder *buildapi.Builder) (*buildapi.ClusterStack, error) {
+	if builder.Spec.Stack.Kind == buildapi.StackKind {
+		stack, err := c.StackLister.Stacks(builder.Namespace).Get(builder.Spec.Stack.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		err = c.Tracker.Track(stack, builder.NamespacedName())
+		if err != nil {
+			return nil, err
+		}
+
+		return &buildapi.ClusterStack{ObjectMeta: stack.ObjectMeta, Status: stack.Status}, nil
+	}
+
+	clusterStack, err := c.ClusterStackLister.Get(builder.Spec.Stack.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterStack, c.Tracker.Track(clusterStack, builder.NamespacedName())
+}
+
 func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.Builder) error {
 	desired.Status.ObservedGeneration = desired.Generation
 
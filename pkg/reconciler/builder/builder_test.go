@@ -56,6 +56,8 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 				Tracker:            fakeTracker,
 				ClusterStoreLister: listers.GetClusterStoreLister(),
 				ClusterStackLister: listers.GetClusterStackLister(),
+				StoreLister:        listers.GetStoreLister(),
+				StackLister:        listers.GetStackLister(),
 			}
 			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
 		})
@@ -95,7 +97,7 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 			BuilderSpec: buildapi.BuilderSpec{
 				Tag: builderTag,
 				Stack: corev1.ObjectReference{
-					Kind: "Stack",
+					Kind: "ClusterStack",
 					Name: "some-stack",
 				},
 				Store: corev1.ObjectReference{
@@ -260,6 +262,101 @@ func testBuilderReconciler(t *testing.T, when spec.G, it spec.S) {
 			require.True(t, fakeTracker.IsTracking(clusterStack, builder.NamespacedName()))
 		})
 
+		it("creates the builder from a namespaced store and stack", func() {
+			builderCreator.Record = buildapi.BuilderRecord{
+				Image: builderIdentifier,
+				Stack: corev1alpha1.BuildStack{
+					RunImage: "example.com/run-image@sha256:123456",
+					ID:       "fake.stack.id",
+				},
+				Buildpacks:              corev1alpha1.BuildpackMetadataList{},
+				ObservedStoreGeneration: 3,
+				ObservedStackGeneration: 4,
+			}
+
+			store := &buildapi.Store{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "namespaced-store",
+					Namespace: testNamespace,
+				},
+				Spec: buildapi.StoreSpec{
+					Verification: &buildapi.VerificationPolicy{PublicKeys: []string{"some-public-key"}},
+				},
+				Status: buildapi.ClusterStoreStatus{
+					Status: corev1alpha1.Status{ObservedGeneration: 3},
+				},
+			}
+
+			stack := &buildapi.Stack{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      "namespaced-stack",
+					Namespace: testNamespace,
+				},
+				Status: buildapi.ClusterStackStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: 4,
+						Conditions: []corev1alpha1.Condition{
+							{
+								Type:   corev1alpha1.ConditionReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				},
+			}
+
+			namespacedBuilder := builder.DeepCopy()
+			namespacedBuilder.Spec.Store = corev1.ObjectReference{Kind: buildapi.StoreKind, Name: store.Name}
+			namespacedBuilder.Spec.Stack = corev1.ObjectReference{Kind: buildapi.StackKind, Name: stack.Name}
+
+			rt.Test(rtesting.TableRow{
+				Key: builderKey,
+				Objects: []runtime.Object{
+					store,
+					stack,
+					namespacedBuilder,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Builder{
+							ObjectMeta: namespacedBuilder.ObjectMeta,
+							Spec:       namespacedBuilder.Spec,
+							Status: buildapi.BuilderStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								BuilderMetadata: corev1alpha1.BuildpackMetadataList{},
+								Stack: corev1alpha1.BuildStack{
+									RunImage: "example.com/run-image@sha256:123456",
+									ID:       "fake.stack.id",
+								},
+								LatestImage:             builderIdentifier,
+								ObservedStoreGeneration: 3,
+								ObservedStackGeneration: 4,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, []testhelpers.CreateBuilderArgs{{
+				Keychain:     &registryfakes.FakeKeychain{},
+				ClusterStore: &buildapi.ClusterStore{ObjectMeta: store.ObjectMeta, Spec: buildapi.ClusterStoreSpec{Verification: store.Spec.Verification}, Status: store.Status},
+				ClusterStack: &buildapi.ClusterStack{ObjectMeta: stack.ObjectMeta, Status: stack.Status},
+				BuilderSpec:  namespacedBuilder.Spec.BuilderSpec,
+			}}, builderCreator.CreateBuilderCalls)
+
+			require.True(t, fakeTracker.IsTracking(store, builder.NamespacedName()))
+			require.True(t, fakeTracker.IsTracking(stack, builder.NamespacedName()))
+		})
+
 		it("does not update the status with no status change", func() {
 			builderCreator.Record = buildapi.BuilderRecord{
 				Image: builderIdentifier,
@@ -0,0 +1,27 @@
+package builder
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// builderChanged ignores updates that only change the rollout status. It is
+// written by the builder rollout controller while it follows a rollout and
+// reconciling it would recreate the builder.
+func builderChanged(old, new interface{}) bool {
+	oldBuilder, ok := old.(*buildapi.Builder)
+	if !ok {
+		return true
+	}
+	newBuilder, ok := new.(*buildapi.Builder)
+	if !ok || oldBuilder.ResourceVersion == newBuilder.ResourceVersion {
+		return true
+	}
+
+	withNewRollout := oldBuilder.DeepCopy()
+	withNewRollout.ResourceVersion = newBuilder.ResourceVersion
+	withNewRollout.ManagedFields = newBuilder.ManagedFields
+	withNewRollout.Status.Rollout = newBuilder.Status.Rollout
+	return !equality.Semantic.DeepEqual(withNewRollout, newBuilder)
+}
@@ -0,0 +1,49 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func TestBuilderChanged(t *testing.T) {
+	builder := &buildapi.Builder{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            "name",
+			Generation:      1,
+			ResourceVersion: "1",
+		},
+		Spec: buildapi.NamespacedBuilderSpec{
+			BuilderSpec: buildapi.BuilderSpec{Tag: "some-registry.io/builder"},
+		},
+		Status: buildapi.BuilderStatus{
+			LatestImage: "some-registry.io/builder@sha256:abc",
+		},
+	}
+
+	rolloutProgressed := builder.DeepCopy()
+	rolloutProgressed.ResourceVersion = "2"
+	rolloutProgressed.Status.Rollout = &buildapi.BuilderRolloutStatus{
+		Image:        "some-registry.io/builder@sha256:abc",
+		Phase:        buildapi.RolloutProgressing,
+		CurrentBatch: 1,
+	}
+	require.False(t, builderChanged(builder, rolloutProgressed))
+
+	resynced := builder.DeepCopy()
+	require.True(t, builderChanged(builder, resynced))
+
+	updated := builder.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Generation = 2
+	updated.Spec.Tag = "some-registry.io/other-builder"
+	require.True(t, builderChanged(builder, updated))
+
+	rebuilt := rolloutProgressed.DeepCopy()
+	rebuilt.ResourceVersion = "3"
+	rebuilt.Status.LatestImage = "some-registry.io/builder@sha256:def"
+	require.True(t, builderChanged(rolloutProgressed, rebuilt))
+}
@@ -0,0 +1,270 @@
+package builderrollout
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/rollout"
+)
+
+const (
+	ReconcilerName = "BuilderRollouts"
+)
+
+// NewController creates the controller that reports the rollout of Builders and
+// ClusterBuilders. It is separate from the builder controllers so that
+// following the progress of a rollout never recreates the builder. The key of a
+// ClusterBuilder has no namespace.
+func NewController(
+	opt reconciler.Options,
+	builderInformer buildinformers.BuilderInformer,
+	clusterBuilderInformer buildinformers.ClusterBuilderInformer,
+	imageInformer buildinformers.ImageInformer,
+	buildInformer buildinformers.BuildInformer,
+) *controller.Impl {
+	c := &Reconciler{
+		Client:               opt.Client,
+		BuilderLister:        builderInformer.Lister(),
+		ClusterBuilderLister: clusterBuilderInformer.Lister(),
+		Planner: &rollout.Planner{
+			ImageLister: imageInformer.Lister(),
+			BuildLister: buildInformer.Lister(),
+		},
+	}
+	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+
+	builderInformer.Informer().AddEventHandler(reconciler.UpdateFilteringHandler(impl.Enqueue, builderChanged))
+	clusterBuilderInformer.Informer().AddEventHandler(reconciler.UpdateFilteringHandler(impl.Enqueue, builderChanged))
+
+	enqueueBuilder := func(ref corev1.ObjectReference, namespace string) {
+		if c.hasRollout(ref, namespace) {
+			impl.EnqueueKey(builderKey(ref, namespace))
+		}
+	}
+
+	imageInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if image, ok := obj.(*buildapi.Image); ok {
+				enqueueBuilder(image.Spec.Builder, image.Namespace)
+			}
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldImage, ok := old.(*buildapi.Image)
+			if !ok {
+				return
+			}
+			newImage, ok := new.(*buildapi.Image)
+			if !ok || oldImage.Spec.Builder == newImage.Spec.Builder {
+				return
+			}
+			enqueueBuilder(oldImage.Spec.Builder, oldImage.Namespace)
+			enqueueBuilder(newImage.Spec.Builder, newImage.Namespace)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if image, ok := tombstoneObject(obj).(*buildapi.Image); ok {
+				enqueueBuilder(image.Spec.Builder, image.Namespace)
+			}
+		},
+	})
+
+	enqueueBuilderOfBuild := func(build *buildapi.Build) {
+		imageName, ok := build.Labels[buildapi.ImageLabel]
+		if !ok {
+			return
+		}
+
+		image, err := c.Planner.ImageLister.Images(build.Namespace).Get(imageName)
+		if err != nil {
+			return
+		}
+		enqueueBuilder(image.Spec.Builder, image.Namespace)
+	}
+
+	// the progress of a rollout only changes when a build finishes or an unfinished build is removed
+	buildInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldBuild, ok := old.(*buildapi.Build)
+			if !ok {
+				return
+			}
+			newBuild, ok := new.(*buildapi.Build)
+			if !ok || oldBuild.Finished() || !newBuild.Finished() {
+				return
+			}
+			enqueueBuilderOfBuild(newBuild)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if build, ok := tombstoneObject(obj).(*buildapi.Build); ok && !build.Finished() {
+				enqueueBuilderOfBuild(build)
+			}
+		},
+	})
+
+	return impl
+}
+
+type Reconciler struct {
+	Client               versioned.Interface
+	BuilderLister        buildlisters.BuilderLister
+	ClusterBuilderLister buildlisters.ClusterBuilderLister
+	Planner              *rollout.Planner
+}
+
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	if namespace == "" {
+		return c.reconcileClusterBuilder(ctx, name)
+	}
+	return c.reconcileBuilder(ctx, namespace, name)
+}
+
+func (c *Reconciler) reconcileBuilder(ctx context.Context, namespace, name string) error {
+	builder, err := c.BuilderLister.Builders(namespace).Get(name)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	rolloutStatus, requeueAfter, err := c.rolloutStatus(corev1.ObjectReference{
+		Kind:      buildapi.BuilderKind,
+		Namespace: builder.Namespace,
+		Name:      builder.Name,
+	}, builder.Spec.Rollout, builder.Status)
+	if err != nil {
+		return err
+	}
+
+	if !equality.Semantic.DeepEqual(rolloutStatus, builder.Status.Rollout) {
+		builder = builder.DeepCopy()
+		builder.Status.Rollout = rolloutStatus
+		_, err = c.Client.KpackV1alpha2().Builders(builder.Namespace).UpdateStatus(ctx, builder, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return requeue(requeueAfter)
+}
+
+func (c *Reconciler) reconcileClusterBuilder(ctx context.Context, name string) error {
+	builder, err := c.ClusterBuilderLister.Get(name)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	rolloutStatus, requeueAfter, err := c.rolloutStatus(corev1.ObjectReference{
+		Kind: buildapi.ClusterBuilderKind,
+		Name: builder.Name,
+	}, builder.Spec.Rollout, builder.Status)
+	if err != nil {
+		return err
+	}
+
+	if !equality.Semantic.DeepEqual(rolloutStatus, builder.Status.Rollout) {
+		builder = builder.DeepCopy()
+		builder.Status.Rollout = rolloutStatus
+		_, err = c.Client.KpackV1alpha2().ClusterBuilders().UpdateStatus(ctx, builder, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return requeue(requeueAfter)
+}
+
+func (c *Reconciler) rolloutStatus(ref corev1.ObjectReference, policy *buildapi.BuilderRollout, status buildapi.BuilderStatus) (*buildapi.BuilderRolloutStatus, time.Duration, error) {
+	if policy == nil || status.LatestImage == "" {
+		return nil, 0, nil
+	}
+
+	plan, err := c.Planner.Plan(ref, *policy, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rolloutStatus, requeueAfter := plan.Status(time.Now())
+	return &rolloutStatus, requeueAfter, nil
+}
+
+func (c *Reconciler) hasRollout(ref corev1.ObjectReference, namespace string) bool {
+	switch ref.Kind {
+	case buildapi.BuilderKind:
+		builder, err := c.BuilderLister.Builders(namespace).Get(ref.Name)
+		return err == nil && builder.Spec.Rollout != nil
+	case buildapi.ClusterBuilderKind:
+		builder, err := c.ClusterBuilderLister.Get(ref.Name)
+		return err == nil && builder.Spec.Rollout != nil
+	default:
+		return false
+	}
+}
+
+func requeue(after time.Duration) error {
+	if after <= 0 {
+		return nil
+	}
+	return controller.NewRequeueAfter(after)
+}
+
+func builderKey(ref corev1.ObjectReference, namespace string) types.NamespacedName {
+	if ref.Kind == buildapi.ClusterBuilderKind {
+		return types.NamespacedName{Name: ref.Name}
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
+
+// builderChanged ignores updates that cannot change the rollout, such as the
+// rollout status written by this controller
+func builderChanged(old, new interface{}) bool {
+	oldPolicy, oldStatus, ok := rolloutInputs(old)
+	if !ok {
+		return false
+	}
+	newPolicy, newStatus, ok := rolloutInputs(new)
+	if !ok {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(oldPolicy, newPolicy) ||
+		oldStatus.LatestImage != newStatus.LatestImage ||
+		oldStatus.Stack.RunImage != newStatus.Stack.RunImage ||
+		!equality.Semantic.DeepEqual(oldStatus.BuilderMetadata, newStatus.BuilderMetadata)
+}
+
+func rolloutInputs(obj interface{}) (*buildapi.BuilderRollout, buildapi.BuilderStatus, bool) {
+	switch builder := obj.(type) {
+	case *buildapi.Builder:
+		return builder.Spec.Rollout, builder.Status, true
+	case *buildapi.ClusterBuilder:
+		return builder.Spec.Rollout, builder.Status, true
+	default:
+		return nil, buildapi.BuilderStatus{}, false
+	}
+}
+
+func tombstoneObject(obj interface{}) interface{} {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
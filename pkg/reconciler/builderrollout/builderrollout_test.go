@@ -0,0 +1,251 @@
+package builderrollout_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/reconciler/builderrollout"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+	"github.com/pivotal/kpack/pkg/rollout"
+)
+
+func TestBuilderRolloutReconciler(t *testing.T) {
+	spec.Run(t, "Builder Rollout Reconciler", testBuilderRolloutReconciler)
+}
+
+func testBuilderRolloutReconciler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		builderName     = "some-builder"
+		namespace       = "some-namespace"
+		builderImage    = "example.com/custom-builder@sha256:resolved-builder-digest"
+		oldBuilderImage = "example.com/custom-builder@sha256:previous-builder-digest"
+		runImage        = "example.com/run-image@sha256:123456"
+	)
+
+	rt := testhelpers.ReconcilerTester(t,
+		func(t *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
+			listers := testhelpers.NewListers(row.Objects)
+			fakeClient := fake.NewSimpleClientset(listers.BuildServiceObjects()...)
+			r := &builderrollout.Reconciler{
+				Client:               fakeClient,
+				BuilderLister:        listers.GetBuilderLister(),
+				ClusterBuilderLister: listers.GetClusterBuilderLister(),
+				Planner: &rollout.Planner{
+					ImageLister: listers.GetImageLister(),
+					BuildLister: listers.GetBuildLister(),
+				},
+			}
+			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
+		})
+
+	builderStatus := buildapi.BuilderStatus{
+		Status: corev1alpha1.Status{
+			ObservedGeneration: 1,
+			Conditions: corev1alpha1.Conditions{
+				{
+					Type:   corev1alpha1.ConditionReady,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+		LatestImage: builderImage,
+		Stack: corev1alpha1.BuildStack{
+			RunImage: runImage,
+			ID:       "fake.stack.id",
+		},
+	}
+
+	clusterBuilder := &buildapi.ClusterBuilder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       builderName,
+			Generation: 1,
+		},
+		Spec: buildapi.ClusterBuilderSpec{
+			BuilderSpec: buildapi.BuilderSpec{
+				Rollout: &buildapi.BuilderRollout{BatchSize: 1},
+			},
+		},
+		Status: builderStatus,
+	}
+
+	builder := &buildapi.Builder{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       builderName,
+			Namespace:  namespace,
+			Generation: 1,
+		},
+		Spec: buildapi.NamespacedBuilderSpec{
+			BuilderSpec: buildapi.BuilderSpec{
+				Rollout: &buildapi.BuilderRollout{BatchSize: 1},
+			},
+		},
+		Status: builderStatus,
+	}
+
+	outdatedImage := func(name, namespace, builderKind string) []runtime.Object {
+		return []runtime.Object{
+			&buildapi.Image{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: buildapi.ImageSpec{
+					Builder: corev1.ObjectReference{
+						Kind: builderKind,
+						Name: builderName,
+					},
+				},
+			},
+			&buildapi.Build{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name + "-build-1",
+					Namespace: namespace,
+					Labels: map[string]string{
+						buildapi.ImageLabel: name,
+					},
+				},
+				Spec: buildapi.BuildSpec{
+					Builder: corev1alpha1.BuildBuilderSpec{
+						Image: oldBuilderImage,
+					},
+				},
+				Status: buildapi.BuildStatus{
+					Status: corev1alpha1.Status{
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					Stack: corev1alpha1.BuildStack{
+						RunImage: "example.com/run-image@sha256:previous",
+					},
+				},
+			},
+		}
+	}
+
+	when("#Reconcile", func() {
+		it("reports the progress of a cluster builder rollout", func() {
+			objects := []runtime.Object{clusterBuilder}
+			objects = append(objects, outdatedImage("first-image", "some-namespace", buildapi.ClusterBuilderKind)...)
+			objects = append(objects, outdatedImage("second-image", "other-namespace", buildapi.ClusterBuilderKind)...)
+
+			expectedBuilder := clusterBuilder.DeepCopy()
+			expectedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+				Image:          builderImage,
+				Phase:          buildapi.RolloutProgressing,
+				CurrentBatch:   1,
+				Batches:        2,
+				Images:         2,
+				Message:        "Rolling out batch 1 of 2",
+				AdmittedImages: []string{"other-namespace/second-image"},
+			}
+
+			rt.Test(rtesting.TableRow{
+				Key:     builderName,
+				Objects: objects,
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: expectedBuilder,
+					},
+				},
+			})
+		})
+
+		it("reports the progress of a namespaced builder rollout to the images in its namespace", func() {
+			objects := []runtime.Object{builder}
+			objects = append(objects, outdatedImage("first-image", namespace, buildapi.BuilderKind)...)
+			objects = append(objects, outdatedImage("second-image", namespace, buildapi.BuilderKind)...)
+			objects = append(objects, outdatedImage("other-image", "other-namespace", buildapi.BuilderKind)...)
+
+			expectedBuilder := builder.DeepCopy()
+			expectedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+				Image:          builderImage,
+				Phase:          buildapi.RolloutProgressing,
+				CurrentBatch:   1,
+				Batches:        2,
+				Images:         2,
+				Message:        "Rolling out batch 1 of 2",
+				AdmittedImages: []string{namespace + "/first-image"},
+			}
+
+			rt.Test(rtesting.TableRow{
+				Key:     namespace + "/" + builderName,
+				Objects: objects,
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: expectedBuilder,
+					},
+				},
+			})
+		})
+
+		it("does not update the status when the rollout has not progressed", func() {
+			reportedBuilder := builder.DeepCopy()
+			reportedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+				Image:          builderImage,
+				Phase:          buildapi.RolloutProgressing,
+				CurrentBatch:   1,
+				Batches:        1,
+				Images:         1,
+				Message:        "Rolling out batch 1 of 1",
+				AdmittedImages: []string{namespace + "/first-image"},
+			}
+
+			objects := []runtime.Object{reportedBuilder}
+			objects = append(objects, outdatedImage("first-image", namespace, buildapi.BuilderKind)...)
+
+			rt.Test(rtesting.TableRow{
+				Key:     namespace + "/" + builderName,
+				Objects: objects,
+				WantErr: false,
+			})
+		})
+
+		it("clears the rollout status when the rollout policy is removed", func() {
+			removedBuilder := builder.DeepCopy()
+			removedBuilder.Spec.Rollout = nil
+			removedBuilder.Status.Rollout = &buildapi.BuilderRolloutStatus{
+				Image: builderImage,
+				Phase: buildapi.RolloutProgressing,
+			}
+
+			expectedBuilder := removedBuilder.DeepCopy()
+			expectedBuilder.Status.Rollout = nil
+
+			rt.Test(rtesting.TableRow{
+				Key:     namespace + "/" + builderName,
+				Objects: []runtime.Object{removedBuilder},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: expectedBuilder,
+					},
+				},
+			})
+		})
+
+		it("does nothing for a missing builder", func() {
+			rt.Test(rtesting.TableRow{
+				Key:     builderName,
+				Objects: []runtime.Object{builder},
+				WantErr: false,
+			})
+		})
+	})
+}
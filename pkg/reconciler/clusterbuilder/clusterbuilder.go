@@ -48,7 +48,7 @@ func NewController(
 		ClusterStackLister:   clusterStackInformer.Lister(),
 	}
 	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
-	clusterBuilderInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
+	clusterBuilderInformer.Informer().AddEventHandler(reconciler.UpdateFilteringHandler(impl.Enqueue, clusterBuilderChanged))
 
 	c.Tracker = tracker.New(impl.EnqueueKey, opt.TrackerResyncPeriod())
 	clusterStoreInformer.Informer().AddEventHandler(reconciler.Handler(c.Tracker.OnChanged))
@@ -0,0 +1,27 @@
+package clusterBuilder
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// clusterBuilderChanged ignores updates that only change the rollout status. It is
+// written by the builder rollout controller while it follows a rollout and
+// reconciling it would recreate the cluster builder.
+func clusterBuilderChanged(old, new interface{}) bool {
+	oldClusterBuilder, ok := old.(*buildapi.ClusterBuilder)
+	if !ok {
+		return true
+	}
+	newClusterBuilder, ok := new.(*buildapi.ClusterBuilder)
+	if !ok || oldClusterBuilder.ResourceVersion == newClusterBuilder.ResourceVersion {
+		return true
+	}
+
+	withNewRollout := oldClusterBuilder.DeepCopy()
+	withNewRollout.ResourceVersion = newClusterBuilder.ResourceVersion
+	withNewRollout.ManagedFields = newClusterBuilder.ManagedFields
+	withNewRollout.Status.Rollout = newClusterBuilder.Status.Rollout
+	return !equality.Semantic.DeepEqual(withNewRollout, newClusterBuilder)
+}
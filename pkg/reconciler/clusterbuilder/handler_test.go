@@ -0,0 +1,49 @@
+package clusterBuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func TestClusterBuilderChanged(t *testing.T) {
+	builder := &buildapi.ClusterBuilder{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            "name",
+			Generation:      1,
+			ResourceVersion: "1",
+		},
+		Spec: buildapi.ClusterBuilderSpec{
+			BuilderSpec: buildapi.BuilderSpec{Tag: "some-registry.io/builder"},
+		},
+		Status: buildapi.BuilderStatus{
+			LatestImage: "some-registry.io/builder@sha256:abc",
+		},
+	}
+
+	rolloutProgressed := builder.DeepCopy()
+	rolloutProgressed.ResourceVersion = "2"
+	rolloutProgressed.Status.Rollout = &buildapi.BuilderRolloutStatus{
+		Image:        "some-registry.io/builder@sha256:abc",
+		Phase:        buildapi.RolloutProgressing,
+		CurrentBatch: 1,
+	}
+	require.False(t, clusterBuilderChanged(builder, rolloutProgressed))
+
+	resynced := builder.DeepCopy()
+	require.True(t, clusterBuilderChanged(builder, resynced))
+
+	updated := builder.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Generation = 2
+	updated.Spec.Tag = "some-registry.io/other-builder"
+	require.True(t, clusterBuilderChanged(builder, updated))
+
+	rebuilt := rolloutProgressed.DeepCopy()
+	rebuilt.ResourceVersion = "3"
+	rebuilt.Status.LatestImage = "some-registry.io/builder@sha256:def"
+	require.True(t, clusterBuilderChanged(rolloutProgressed, rebuilt))
+}
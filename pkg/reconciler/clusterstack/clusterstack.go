@@ -2,8 +2,10 @@ package clusterstack
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,17 +41,26 @@ func NewController(
 		ClusterStackLister: clusterStackInformer.Lister(),
 		ClusterStackReader: clusterStackReader,
 		KeychainFactory:    keychainFactory,
+		PollingFrequency:   opt.StackPollingFrequency,
 	}
 	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	c.Enqueuer = impl
 	clusterStackInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
 	return impl
 }
 
+//go:generate counterfeiter . Enqueuer
+type Enqueuer interface {
+	EnqueueAfter(obj interface{}, after time.Duration)
+}
+
 type Reconciler struct {
 	Client             versioned.Interface
 	ClusterStackLister buildlisters.ClusterStackLister
 	ClusterStackReader ClusterStackReader
 	KeychainFactory    registry.KeychainFactory
+	Enqueuer           Enqueuer
+	PollingFrequency   time.Duration
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -74,10 +85,12 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		return updateErr
 	}
 
-	if err != nil {
-		return controller.NewPermanentError(err)
+	if c.PollingFrequency > 0 && hasTaggedImages(clusterStack.Spec) {
+		c.Enqueuer.EnqueueAfter(clusterStack, c.PollingFrequency)
 	}
-	return nil
+
+	// a failed read is retried as it may be a transient registry error
+	return err
 }
 
 func (c *Reconciler) reconcileClusterStackStatus(ctx context.Context, clusterStack *buildapi.ClusterStack) (*buildapi.ClusterStack, error) {
@@ -92,25 +105,51 @@ func (c *Reconciler) reconcileClusterStackStatus(ctx context.Context, clusterSta
 
 	keychain, err := c.KeychainFactory.KeychainForSecretRef(ctx, secretRef)
 	if err != nil {
-		clusterStack.Status = buildapi.ClusterStackStatus{
-			Status: corev1alpha1.CreateStatusWithReadyCondition(clusterStack.Generation, err),
-		}
+		clusterStack.Status = ReadStatus(clusterStack.Status, clusterStack.Generation, buildapi.ResolvedClusterStack{}, err)
 		return clusterStack, err
 	}
 
 	resolvedClusterStack, err := c.ClusterStackReader.Read(keychain, clusterStack.Spec)
+	clusterStack.Status = ReadStatus(clusterStack.Status, clusterStack.Generation, resolvedClusterStack, err)
+	return clusterStack, err
+}
+
+// ReadStatus returns the status of a stack of generation after reading its
+// images. On error the last resolved images and their previous digests are
+// kept if reconciler.KeepLastRead allows it.
+func ReadStatus(status buildapi.ClusterStackStatus, generation int64, resolved buildapi.ResolvedClusterStack, err error) buildapi.ClusterStackStatus {
 	if err != nil {
-		clusterStack.Status = buildapi.ClusterStackStatus{
-			Status: corev1alpha1.CreateStatusWithReadyCondition(clusterStack.Generation, err),
+		if !reconciler.KeepLastRead(status.Status, generation, err) {
+			status.ResolvedClusterStack = buildapi.ResolvedClusterStack{}
 		}
-		return clusterStack, err
+		status.Status = corev1alpha1.CreateStatusWithReadyCondition(generation, err)
+		return status
 	}
 
-	clusterStack.Status = buildapi.ClusterStackStatus{
-		Status:               corev1alpha1.CreateStatusWithReadyCondition(clusterStack.Generation, nil),
-		ResolvedClusterStack: resolvedClusterStack,
+	resolved.BuildImage.PreviousImage = previousImage(status.BuildImage, resolved.BuildImage)
+	resolved.RunImage.PreviousImage = previousImage(status.RunImage, resolved.RunImage)
+
+	return buildapi.ClusterStackStatus{
+		Status:               corev1alpha1.CreateStatusWithReadyCondition(generation, nil),
+		ResolvedClusterStack: resolved,
+	}
+}
+
+func previousImage(current, resolved buildapi.ClusterStackStatusImage) string {
+	if current.LatestImage == "" || current.LatestImage == resolved.LatestImage {
+		return current.PreviousImage
+	}
+	return current.LatestImage
+}
+
+// hasTaggedImages reports whether the build or run image may resolve to a new digest.
+func hasTaggedImages(spec buildapi.ClusterStackSpec) bool {
+	for _, image := range []string{spec.BuildImage.Image, spec.RunImage.Image} {
+		if _, err := name.NewDigest(image); err != nil {
+			return true
+		}
 	}
-	return clusterStack, nil
+	return false
 }
 
 func (c *Reconciler) updateClusterStackStatus(ctx context.Context, desired *buildapi.ClusterStack) error {
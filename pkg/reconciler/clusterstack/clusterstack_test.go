@@ -1,8 +1,10 @@
 package clusterstack_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +20,7 @@ import (
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/cnb"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstack"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstack/clusterstackfakes"
 	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
@@ -34,10 +37,12 @@ func testClusterStackReconciler(t *testing.T, when spec.G, it spec.S) {
 		clusterStackName        = "some-clusterStack"
 		clusterStackKey         = clusterStackName
 		initialGeneration int64 = 1
+		pollingFrequency        = 5 * time.Minute
 	)
 
 	var (
 		fakeKeyChainFactory = &registryfakes.FakeKeychainFactory{}
+		fakeEnqueuer        = &clusterstackfakes.FakeEnqueuer{}
 	)
 
 	fakeClusterStackReader := &clusterstackfakes.FakeClusterStackReader{}
@@ -67,6 +72,8 @@ func testClusterStackReconciler(t *testing.T, when spec.G, it spec.S) {
 				ClusterStackLister: listers.GetClusterStackLister(),
 				ClusterStackReader: fakeClusterStackReader,
 				KeychainFactory:    fakeKeyChainFactory,
+				Enqueuer:           fakeEnqueuer,
+				PollingFrequency:   pollingFrequency,
 			}
 			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
 		})
@@ -160,6 +167,246 @@ func testClusterStackReconciler(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("polling", func() {
+			resolvedClusterStack := buildapi.ResolvedClusterStack{
+				BuildImage: buildapi.ClusterStackStatusImage{
+					LatestImage: "some-registry.io/build-image@sha245:123",
+				},
+				RunImage: buildapi.ClusterStackStatusImage{
+					LatestImage: "some-registry.io/run-image@sha245:123",
+				},
+				Mixins:  []string{"a-nice-mixin"},
+				UserID:  1000,
+				GroupID: 2000,
+			}
+
+			it.Before(func() {
+				fakeKeyChainFactory.AddKeychainForSecretRef(t, registry.SecretRef{}, &registryfakes.FakeKeychain{Name: "default"})
+
+				testClusterStack.Status = buildapi.ClusterStackStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: 1,
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					ResolvedClusterStack: resolvedClusterStack,
+				}
+			})
+
+			it("requeues stacks with images referenced by tag", func() {
+				fakeClusterStackReader.ReadReturns(resolvedClusterStack, nil)
+
+				rt.Test(rtesting.TableRow{
+					Key: clusterStackKey,
+					Objects: []runtime.Object{
+						testClusterStack,
+					},
+					WantErr: false,
+				})
+
+				require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+				enqueued, after := fakeEnqueuer.EnqueueAfterArgsForCall(0)
+				assert.Equal(t, clusterStackName, enqueued.(*buildapi.ClusterStack).Name)
+				assert.Equal(t, pollingFrequency, after)
+			})
+
+			it("does not requeue stacks with images pinned to a digest", func() {
+				fakeClusterStackReader.ReadReturns(resolvedClusterStack, nil)
+				testClusterStack.Spec.BuildImage.Image = "some-registry.io/build-image@sha256:7bd4f43a3ea3ef2a4dd1b3a4e2f5ee84f1d0a1d8e4f1b1b8d3fb3b4c4f5d6e7a"
+				testClusterStack.Spec.RunImage.Image = "some-registry.io/run-image@sha256:8ce5054b4fb4f03b5ee2c4b5f306ff95020e1b2e9f502c2c9e4fc4c5d506e7f8"
+
+				rt.Test(rtesting.TableRow{
+					Key: clusterStackKey,
+					Objects: []runtime.Object{
+						testClusterStack,
+					},
+					WantErr: false,
+				})
+
+				assert.Equal(t, 0, fakeEnqueuer.EnqueueAfterCallCount())
+			})
+
+			it("records the previous digest when a tag resolves to a new image", func() {
+				updatedClusterStack := resolvedClusterStack
+				updatedClusterStack.RunImage = buildapi.ClusterStackStatusImage{
+					LatestImage: "some-registry.io/run-image@sha245:456",
+				}
+				fakeClusterStackReader.ReadReturns(updatedClusterStack, nil)
+
+				expectedClusterStack := updatedClusterStack
+				expectedClusterStack.RunImage.PreviousImage = "some-registry.io/run-image@sha245:123"
+
+				rt.Test(rtesting.TableRow{
+					Key: clusterStackKey,
+					Objects: []runtime.Object{
+						testClusterStack,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStack{
+								ObjectMeta: testClusterStack.ObjectMeta,
+								Spec:       testClusterStack.Spec,
+								Status: buildapi.ClusterStackStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 1,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									ResolvedClusterStack: expectedClusterStack,
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("keeps the previous digest when the image is unchanged", func() {
+				testClusterStack.Status.RunImage.PreviousImage = "some-registry.io/run-image@sha245:000"
+				fakeClusterStackReader.ReadReturns(resolvedClusterStack, nil)
+
+				rt.Test(rtesting.TableRow{
+					Key: clusterStackKey,
+					Objects: []runtime.Object{
+						testClusterStack,
+					},
+					WantErr: false,
+				})
+			})
+
+			it("keeps the resolved images and previous digests when a poll fails", func() {
+				testClusterStack.Status.RunImage.PreviousImage = "some-registry.io/run-image@sha245:000"
+				fakeClusterStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, errors.New("registry unavailable"))
+
+				expectedClusterStack := resolvedClusterStack
+				expectedClusterStack.RunImage.PreviousImage = "some-registry.io/run-image@sha245:000"
+
+				rt.Test(rtesting.TableRow{
+					Key: clusterStackKey,
+					Objects: []runtime.Object{
+						testClusterStack,
+					},
+					WantErr: true,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStack{
+								ObjectMeta: testClusterStack.ObjectMeta,
+								Spec:       testClusterStack.Spec,
+								Status: buildapi.ClusterStackStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 1,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Message: "registry unavailable",
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+											},
+										},
+									},
+									ResolvedClusterStack: expectedClusterStack,
+								},
+							},
+						},
+					},
+				})
+
+				assert.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+			})
+
+			it("drops the resolved images when the stack changed", func() {
+				fakeClusterStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, errors.New("registry unavailable"))
+
+				changedClusterStack := testClusterStack.DeepCopy()
+				changedClusterStack.Generation = 2
+
+				rt.Test(rtesting.TableRow{
+					Key: clusterStackKey,
+					Objects: []runtime.Object{
+						changedClusterStack,
+					},
+					WantErr: true,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStack{
+								ObjectMeta: changedClusterStack.ObjectMeta,
+								Spec:       changedClusterStack.Spec,
+								Status: buildapi.ClusterStackStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 2,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Message: "registry unavailable",
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("drops the resolved images when an image fails verification", func() {
+				fakeClusterStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, &cnb.VerificationError{Err: errors.New("image is not signed")})
+
+				rt.Test(rtesting.TableRow{
+					Key: clusterStackKey,
+					Objects: []runtime.Object{
+						testClusterStack,
+					},
+					WantErr: true,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStack{
+								ObjectMeta: testClusterStack.ObjectMeta,
+								Spec:       testClusterStack.Spec,
+								Status: buildapi.ClusterStackStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 1,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Message: "image is not signed",
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("retries a failed poll", func() {
+				fakeClusterStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, errors.New("registry unavailable"))
+
+				listers := testhelpers.NewListers([]runtime.Object{testClusterStack})
+				r := &clusterstack.Reconciler{
+					Client:             fake.NewSimpleClientset(listers.BuildServiceObjects()...),
+					ClusterStackLister: listers.GetClusterStackLister(),
+					ClusterStackReader: fakeClusterStackReader,
+					KeychainFactory:    fakeKeyChainFactory,
+					Enqueuer:           fakeEnqueuer,
+					PollingFrequency:   pollingFrequency,
+				}
+
+				err := r.Reconcile(context.Background(), clusterStackKey)
+				assert.EqualError(t, err, "registry unavailable")
+				assert.False(t, controller.IsPermanentError(err))
+			})
+		})
+
 		it("sets the status to Ready False if error reading from clusterStack", func() {
 			fakeClusterStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, errors.New("invalid mixins on run image"))
 			emptySecretRef := registry.SecretRef{}
@@ -2,8 +2,10 @@ package clusterstore
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,7 +28,7 @@ const (
 
 //go:generate counterfeiter . StoreReader
 type StoreReader interface {
-	Read(keychain authn.Keychain, storeImages []corev1alpha1.StoreImage) ([]corev1alpha1.StoreBuildpack, error)
+	Read(keychain authn.Keychain, storeImages []corev1alpha1.StoreImage, policy *buildapi.VerificationPolicy) ([]corev1alpha1.StoreBuildpack, error)
 }
 
 func NewController(
@@ -39,17 +41,26 @@ func NewController(
 		ClusterStoreLister: clusterStoreInformer.Lister(),
 		StoreReader:        storeReader,
 		KeychainFactory:    keychainFactory,
+		PollingFrequency:   opt.StorePollingFrequency,
 	}
 	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	c.Enqueuer = impl
 	clusterStoreInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
 	return impl
 }
 
+//go:generate counterfeiter . Enqueuer
+type Enqueuer interface {
+	EnqueueAfter(obj interface{}, after time.Duration)
+}
+
 type Reconciler struct {
 	Client             versioned.Interface
 	StoreReader        StoreReader
 	ClusterStoreLister buildlisters.ClusterStoreLister
 	KeychainFactory    registry.KeychainFactory
+	Enqueuer           Enqueuer
+	PollingFrequency   time.Duration
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -74,10 +85,12 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		return updateErr
 	}
 
-	if err != nil {
-		return controller.NewPermanentError(err)
+	if c.PollingFrequency > 0 && hasTaggedSources(clusterStore.Spec.Sources) {
+		c.Enqueuer.EnqueueAfter(clusterStore, c.PollingFrequency)
 	}
-	return nil
+
+	// a failed read is retried as it may be a transient registry error
+	return err
 }
 
 func (c *Reconciler) updateClusterStoreStatus(ctx context.Context, desired *buildapi.ClusterStore) error {
@@ -108,23 +121,40 @@ func (c *Reconciler) reconcileClusterStoreStatus(ctx context.Context, clusterSto
 
 	keychain, err := c.KeychainFactory.KeychainForSecretRef(ctx, secretRef)
 	if err != nil {
-		clusterStore.Status = buildapi.ClusterStoreStatus{
-			Status: corev1alpha1.CreateStatusWithReadyCondition(clusterStore.Generation, err),
-		}
+		clusterStore.Status = ReadStatus(clusterStore.Status, clusterStore.Generation, nil, err)
 		return clusterStore, err
 	}
 
-	buildpacks, err := c.StoreReader.Read(keychain, clusterStore.Spec.Sources)
-	if err != nil {
-		clusterStore.Status = buildapi.ClusterStoreStatus{
-			Status: corev1alpha1.CreateStatusWithReadyCondition(clusterStore.Generation, err),
+	buildpacks, err := c.StoreReader.Read(keychain, clusterStore.Spec.Sources, clusterStore.Spec.Verification)
+	clusterStore.Status = ReadStatus(clusterStore.Status, clusterStore.Generation, buildpacks, err)
+	return clusterStore, err
+}
+
+// ReadStatus returns the status of a store of generation after reading its
+// buildpacks. On error the last read buildpacks are kept if
+// reconciler.KeepLastRead allows it.
+func ReadStatus(status buildapi.ClusterStoreStatus, generation int64, buildpacks []corev1alpha1.StoreBuildpack, err error) buildapi.ClusterStoreStatus {
+	if err == nil {
+		return buildapi.ClusterStoreStatus{
+			Buildpacks: buildpacks,
+			Status:     corev1alpha1.CreateStatusWithReadyCondition(generation, nil),
 		}
-		return clusterStore, err
 	}
 
-	clusterStore.Status = buildapi.ClusterStoreStatus{
-		Buildpacks: buildpacks,
-		Status:     corev1alpha1.CreateStatusWithReadyCondition(clusterStore.Generation, nil),
+	if !reconciler.KeepLastRead(status.Status, generation, err) {
+		status.Buildpacks = nil
+	}
+	status.Status = corev1alpha1.CreateStatusWithReadyCondition(generation, err)
+	return status
+}
+
+// hasTaggedSources reports whether any source may resolve to a new digest.
+// Sources pinned to a digest never change and do not need to be polled.
+func hasTaggedSources(sources []corev1alpha1.StoreImage) bool {
+	for _, source := range sources {
+		if _, err := name.NewDigest(source.Image); err != nil {
+			return true
+		}
 	}
-	return clusterStore, nil
+	return false
 }
@@ -1,11 +1,14 @@
 package clusterstore_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -17,6 +20,7 @@ import (
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/cnb"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstore"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstore/clusterstorefakes"
 	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
@@ -33,10 +37,12 @@ func testClusterStoreReconciler(t *testing.T, when spec.G, it spec.S) {
 		storeName               = "some-store"
 		storeKey                = storeName
 		initialGeneration int64 = 1
+		pollingFrequency        = 5 * time.Minute
 	)
 	var (
 		fakeStoreReader     = &clusterstorefakes.FakeStoreReader{}
 		fakeKeyChainFactory = &registryfakes.FakeKeychainFactory{}
+		fakeEnqueuer        = &clusterstorefakes.FakeEnqueuer{}
 	)
 
 	rt := testhelpers.ReconcilerTester(t,
@@ -50,6 +56,8 @@ func testClusterStoreReconciler(t *testing.T, when spec.G, it spec.S) {
 				StoreReader:        fakeStoreReader,
 				ClusterStoreLister: listers.GetClusterStoreLister(),
 				KeychainFactory:    fakeKeyChainFactory,
+				Enqueuer:           fakeEnqueuer,
+				PollingFrequency:   pollingFrequency,
 			}
 			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
 		})
@@ -134,8 +142,59 @@ func testClusterStoreReconciler(t *testing.T, when spec.G, it spec.S) {
 
 			assert.Equal(t, 1, fakeStoreReader.ReadCallCount())
 
-			_, clusterStoreSpec := fakeStoreReader.ReadArgsForCall(0)
+			_, clusterStoreSpec, policy := fakeStoreReader.ReadArgsForCall(0)
 			assert.Equal(t, store.Spec.Sources, clusterStoreSpec)
+			assert.Nil(t, policy)
+		})
+
+		it("reads the store images with the verification policy", func() {
+			fakeStoreReader.ReadReturns(readBuildpacks, nil)
+
+			store.Spec.Verification = &buildapi.VerificationPolicy{
+				Keyless: []buildapi.KeylessIdentity{
+					{
+						Issuer:  "https://token.actions.githubusercontent.com",
+						Subject: "https://github.com/some-org/buildpacks/.github/workflows/release.yml@refs/heads/main",
+					},
+				},
+			}
+
+			emptySecretRef := registry.SecretRef{}
+			defaultKeyChain := &registryfakes.FakeKeychain{Name: "default"}
+			fakeKeyChainFactory.AddKeychainForSecretRef(t, emptySecretRef, defaultKeyChain)
+
+			rt.Test(rtesting.TableRow{
+				Key: storeKey,
+				Objects: []runtime.Object{
+					store,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.ClusterStore{
+							ObjectMeta: store.ObjectMeta,
+							Spec:       store.Spec,
+							Status: buildapi.ClusterStoreStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								Buildpacks: readBuildpacks,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, 1, fakeStoreReader.ReadCallCount())
+
+			_, _, policy := fakeStoreReader.ReadArgsForCall(0)
+			assert.Equal(t, store.Spec.Verification, policy)
 		})
 
 		it("uses the keychain of the referenced service account", func() {
@@ -178,7 +237,7 @@ func testClusterStoreReconciler(t *testing.T, when spec.G, it spec.S) {
 			})
 
 			assert.Equal(t, 1, fakeStoreReader.ReadCallCount())
-			actualKeyChain, _ := fakeStoreReader.ReadArgsForCall(0)
+			actualKeyChain, _, _ := fakeStoreReader.ReadArgsForCall(0)
 			assert.Equal(t, expectedKeyChain, actualKeyChain)
 		})
 
@@ -210,6 +269,216 @@ func testClusterStoreReconciler(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("polling", func() {
+			it.Before(func() {
+				fakeStoreReader.ReadReturns(readBuildpacks, nil)
+				fakeKeyChainFactory.AddKeychainForSecretRef(t, registry.SecretRef{}, &registryfakes.FakeKeychain{Name: "default"})
+
+				store.Status = buildapi.ClusterStoreStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: 1,
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					Buildpacks: readBuildpacks,
+				}
+			})
+
+			it("requeues stores with sources referenced by tag", func() {
+				rt.Test(rtesting.TableRow{
+					Key: storeKey,
+					Objects: []runtime.Object{
+						store,
+					},
+					WantErr: false,
+				})
+
+				require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+				enqueued, after := fakeEnqueuer.EnqueueAfterArgsForCall(0)
+				assert.Equal(t, storeName, enqueued.(*buildapi.ClusterStore).Name)
+				assert.Equal(t, pollingFrequency, after)
+			})
+
+			it("does not requeue stores with only sources pinned to a digest", func() {
+				store.Spec.Sources = []corev1alpha1.StoreImage{
+					{
+						Image: "some.registry/some-image-1@sha256:d57937f5ccb6f524afa02dd95224e1914c94a02483d37b07aa668e560dcb3bf4",
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: storeKey,
+					Objects: []runtime.Object{
+						store,
+					},
+					WantErr: false,
+				})
+
+				assert.Equal(t, 0, fakeEnqueuer.EnqueueAfterCallCount())
+			})
+
+			it("updates the status when a tag resolves to new buildpacks", func() {
+				updatedBuildpacks := []corev1alpha1.StoreBuildpack{readBuildpacks[0], readBuildpacks[1]}
+				updatedBuildpacks[0].Version = "0.0.117"
+				updatedBuildpacks[0].DiffId = "sha256:a39ae1ecb7a2ec48cba4e20e67cd6fa23e2a20e3cb1b4d0c7ac8e4eee2b24e8f"
+				fakeStoreReader.ReadReturns(updatedBuildpacks, nil)
+
+				rt.Test(rtesting.TableRow{
+					Key: storeKey,
+					Objects: []runtime.Object{
+						store,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStore{
+								ObjectMeta: store.ObjectMeta,
+								Spec:       store.Spec,
+								Status: buildapi.ClusterStoreStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 1,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									Buildpacks: updatedBuildpacks,
+								},
+							},
+						},
+					},
+				})
+
+				assert.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+			})
+
+			it("keeps the last read buildpacks when a poll fails", func() {
+				fakeStoreReader.ReadReturns(nil, fmt.Errorf("registry unavailable"))
+
+				rt.Test(rtesting.TableRow{
+					Key: storeKey,
+					Objects: []runtime.Object{
+						store,
+					},
+					WantErr: true,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStore{
+								ObjectMeta: store.ObjectMeta,
+								Spec:       store.Spec,
+								Status: buildapi.ClusterStoreStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 1,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Message: "registry unavailable",
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+											},
+										},
+									},
+									Buildpacks: readBuildpacks,
+								},
+							},
+						},
+					},
+				})
+
+				assert.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+			})
+
+			it("drops the last read buildpacks when the store changed", func() {
+				fakeStoreReader.ReadReturns(nil, fmt.Errorf("registry unavailable"))
+
+				changedStore := store.DeepCopy()
+				changedStore.Generation = 2
+
+				rt.Test(rtesting.TableRow{
+					Key: storeKey,
+					Objects: []runtime.Object{
+						changedStore,
+					},
+					WantErr: true,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStore{
+								ObjectMeta: changedStore.ObjectMeta,
+								Spec:       changedStore.Spec,
+								Status: buildapi.ClusterStoreStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 2,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Message: "registry unavailable",
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("drops the last read buildpacks when a store image fails verification", func() {
+				fakeStoreReader.ReadReturns(nil, &cnb.VerificationError{Err: fmt.Errorf("image is not signed")})
+
+				rt.Test(rtesting.TableRow{
+					Key: storeKey,
+					Objects: []runtime.Object{
+						store,
+					},
+					WantErr: true,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.ClusterStore{
+								ObjectMeta: store.ObjectMeta,
+								Spec:       store.Spec,
+								Status: buildapi.ClusterStoreStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: 1,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Message: "image is not signed",
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("retries a failed poll", func() {
+				fakeStoreReader.ReadReturns(nil, fmt.Errorf("registry unavailable"))
+
+				listers := testhelpers.NewListers([]runtime.Object{store})
+				r := &clusterstore.Reconciler{
+					Client:             fake.NewSimpleClientset(listers.BuildServiceObjects()...),
+					StoreReader:        fakeStoreReader,
+					ClusterStoreLister: listers.GetClusterStoreLister(),
+					KeychainFactory:    fakeKeyChainFactory,
+					Enqueuer:           fakeEnqueuer,
+					PollingFrequency:   pollingFrequency,
+				}
+
+				err := r.Reconcile(context.Background(), storeKey)
+				assert.EqualError(t, err, "registry unavailable")
+				assert.False(t, controller.IsPermanentError(err))
+			})
+		})
+
 		it("sets the status to Ready False if error reading buildpacks", func() {
 			fakeStoreReader.ReadReturns(nil, fmt.Errorf("no buildpacks left"))
 
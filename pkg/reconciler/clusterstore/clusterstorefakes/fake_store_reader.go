@@ -5,16 +5,18 @@ import (
 	"sync"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstore"
 )
 
 type FakeStoreReader struct {
-	ReadStub        func(authn.Keychain, []v1alpha1.StoreImage) ([]v1alpha1.StoreBuildpack, error)
+	ReadStub        func(authn.Keychain, []v1alpha1.StoreImage, *v1alpha2.VerificationPolicy) ([]v1alpha1.StoreBuildpack, error)
 	readMutex       sync.RWMutex
 	readArgsForCall []struct {
 		arg1 authn.Keychain
 		arg2 []v1alpha1.StoreImage
+		arg3 *v1alpha2.VerificationPolicy
 	}
 	readReturns struct {
 		result1 []v1alpha1.StoreBuildpack
@@ -28,7 +30,7 @@ type FakeStoreReader struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeStoreReader) Read(arg1 authn.Keychain, arg2 []v1alpha1.StoreImage) ([]v1alpha1.StoreBuildpack, error) {
+func (fake *FakeStoreReader) Read(arg1 authn.Keychain, arg2 []v1alpha1.StoreImage, arg3 *v1alpha2.VerificationPolicy) ([]v1alpha1.StoreBuildpack, error) {
 	var arg2Copy []v1alpha1.StoreImage
 	if arg2 != nil {
 		arg2Copy = make([]v1alpha1.StoreImage, len(arg2))
@@ -39,11 +41,12 @@ func (fake *FakeStoreReader) Read(arg1 authn.Keychain, arg2 []v1alpha1.StoreImag
 	fake.readArgsForCall = append(fake.readArgsForCall, struct {
 		arg1 authn.Keychain
 		arg2 []v1alpha1.StoreImage
-	}{arg1, arg2Copy})
-	fake.recordInvocation("Read", []interface{}{arg1, arg2Copy})
+		arg3 *v1alpha2.VerificationPolicy
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("Read", []interface{}{arg1, arg2Copy, arg3})
 	fake.readMutex.Unlock()
 	if fake.ReadStub != nil {
-		return fake.ReadStub(arg1, arg2)
+		return fake.ReadStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -58,17 +61,17 @@ func (fake *FakeStoreReader) ReadCallCount() int {
 	return len(fake.readArgsForCall)
 }
 
-func (fake *FakeStoreReader) ReadCalls(stub func(authn.Keychain, []v1alpha1.StoreImage) ([]v1alpha1.StoreBuildpack, error)) {
+func (fake *FakeStoreReader) ReadCalls(stub func(authn.Keychain, []v1alpha1.StoreImage, *v1alpha2.VerificationPolicy) ([]v1alpha1.StoreBuildpack, error)) {
 	fake.readMutex.Lock()
 	defer fake.readMutex.Unlock()
 	fake.ReadStub = stub
 }
 
-func (fake *FakeStoreReader) ReadArgsForCall(i int) (authn.Keychain, []v1alpha1.StoreImage) {
+func (fake *FakeStoreReader) ReadArgsForCall(i int) (authn.Keychain, []v1alpha1.StoreImage, *v1alpha2.VerificationPolicy) {
 	fake.readMutex.RLock()
 	defer fake.readMutex.RUnlock()
 	argsForCall := fake.readArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeStoreReader) ReadReturns(result1 []v1alpha1.StoreBuildpack, result2 error) {
@@ -12,3 +12,16 @@ func Handler(h func(interface{})) cache.ResourceEventHandler {
 		DeleteFunc: h,
 	}
 }
+
+// UpdateFilteringHandler ignores updates for which changed returns false
+func UpdateFilteringHandler(h func(interface{}), changed func(old, new interface{}) bool) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: h,
+		UpdateFunc: func(old, new interface{}) {
+			if changed(old, new) {
+				h(new)
+			}
+		},
+		DeleteFunc: h,
+	}
+}
@@ -15,6 +15,11 @@ type buildRequiredResult struct {
 	ReasonsStr      string
 	ChangesStr      string
 	PriorityClass   string
+	RetryAttempt    int64
+	RetryAfter      time.Duration
+	RebuildAfter    time.Duration
+	DeferredReason  string
+	DeferredUntil   time.Time
 }
 
 func newBuildRequiredResult(summary buildchange.ChangeSummary) buildRequiredResult {
@@ -33,16 +38,28 @@ func newBuildRequiredResult(summary buildchange.ChangeSummary) buildRequiredResu
 func isBuildRequired(img *buildapi.Image,
 	lastBuild *buildapi.Build,
 	srcResolver *buildapi.SourceResolver,
-	builder buildapi.BuilderResource) (buildRequiredResult, error) {
+	builder buildapi.BuilderResource,
+	window *buildapi.MaintenanceWindow) (buildRequiredResult, error) {
 
 	result := buildRequiredResult{ConditionStatus: corev1.ConditionUnknown}
 	if !srcResolver.Ready() || !builder.Ready() {
 		return result, nil
 	}
 
+	now := time.Now()
+	retry, retryAfter := retryChange(img, lastBuild, now)
+
+	schedule, rebuildAfter, err := scheduleChange(img, lastBuild, now)
+	if err != nil {
+		return result, err
+	}
+
 	changeSummary, err := buildchange.NewChangeProcessor().
 		Process(triggerChange(lastBuild)).
+		Process(retry).
+		Process(schedule).
 		Process(commitChange(lastBuild, srcResolver)).
+		Process(sourceVersionChange(lastBuild, srcResolver)).
 		Process(configChange(img, lastBuild, srcResolver)).
 		Process(buildpackChange(lastBuild, builder)).
 		Process(stackChange(lastBuild, builder)).
@@ -51,7 +68,30 @@ func isBuildRequired(img *buildapi.Image,
 		return result, err
 	}
 
-	return newBuildRequiredResult(changeSummary), nil
+	result = newBuildRequiredResult(changeSummary)
+	if retry != nil {
+		result.RetryAttempt = lastBuild.RetryAttempt() + 1
+	}
+	result.RetryAfter = retryAfter
+	result.RebuildAfter = rebuildAfter
+
+	if result.ConditionStatus == corev1.ConditionTrue && deferrable(result.ReasonsStr) {
+		opening, err := window.NextOpening(now)
+		if err != nil {
+			return result, err
+		}
+
+		if !opening.IsZero() {
+			return buildRequiredResult{
+				ConditionStatus: corev1.ConditionFalse,
+				RetryAfter:      retryAfter,
+				RebuildAfter:    rebuildAfter,
+				DeferredReason:  result.ReasonsStr,
+				DeferredUntil:   opening,
+			}, nil
+		}
+	}
+	return result, nil
 }
 
 func triggerChange(lastBuild *buildapi.Build) buildchange.Change {
@@ -79,6 +119,27 @@ func commitChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolve
 	return buildchange.NewCommitChange(oldRevision, newRevision)
 }
 
+func sourceVersionChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+	if lastBuild == nil {
+		return nil
+	}
+
+	// If the lastBuild was a different type of source, then it is not a source version change
+	old, new := lastBuild.Spec.Source, srcResolver.Status.Source
+	switch {
+	case old.Blob != nil && new.Blob != nil:
+		return buildchange.NewSourceVersionChange(buildapi.BuildReasonBlob, old.Blob.Version, new.Blob.Version)
+	case old.Registry != nil && new.Registry != nil:
+		return buildchange.NewSourceVersionChange(buildapi.BuildReasonRegistry, old.Registry.Digest, new.Registry.Digest)
+	case old.S3 != nil && new.S3 != nil:
+		return buildchange.NewSourceVersionChange(buildapi.BuildReasonS3, old.S3.Version, new.S3.Version)
+	case old.Custom != nil && new.Custom != nil:
+		return buildchange.NewSourceVersionChange(buildapi.BuildReasonCustom, old.Custom.Version, new.Custom.Version)
+	default:
+		return nil
+	}
+}
+
 func configChange(img *buildapi.Image, lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
 	var old buildchange.Config
 	var new buildchange.Config
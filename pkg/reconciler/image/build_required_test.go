@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -111,7 +112,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 		}
 
 		it("false for no changes", func() {
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 			assert.Equal(t, "", result.ReasonsStr)
@@ -122,7 +123,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 		it("false for different ServiceAccount", func() {
 			image.Spec.ServiceAccountName = "different"
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 			assert.Equal(t, "", result.ReasonsStr)
@@ -166,7 +167,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -211,7 +212,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -256,7 +257,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -277,7 +278,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				Stack: corev1alpha1.BuildStack{},
 			}
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 			assert.Equal(t, "", result.ReasonsStr)
@@ -290,7 +291,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				buildapi.BuildNeededAnnotation: "true",
 			}
 
-			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+			result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 			assert.Equal(t, buildapi.BuildReasonTrigger, result.ReasonsStr)
@@ -312,7 +313,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 					{Id: "buildpack.unused", Version: "unused"},
 				}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -340,7 +341,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonBuildpack, result.ReasonsStr)
@@ -368,7 +369,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonBuildpack, result.ReasonsStr)
@@ -388,7 +389,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonStack, result.ReasonsStr)
@@ -397,6 +398,97 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("rebuild schedule", func() {
+			it("true once a scheduled rebuild has passed since the last build", func() {
+				image.Spec.RebuildSchedule = "0 3 * * 0"
+				latestBuild.CreationTimestamp = metav1.NewTime(time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC))
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "SCHEDULE",
+    "old": "2022-03-01T12:00:00Z",
+    "new": "2022-03-06T03:00:00Z"
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonSchedule, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassScheduled, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false until the next scheduled rebuild", func() {
+				image.Spec.RebuildSchedule = "0 3 * * 0"
+				latestBuild.CreationTimestamp = metav1.Now()
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, "", result.ReasonsStr)
+				assert.True(t, result.RebuildAfter > 0)
+				assert.True(t, result.RebuildAfter <= 7*24*time.Hour)
+			})
+
+			it("defers a scheduled rebuild until the maintenance window opens", func() {
+				image.Spec.RebuildSchedule = "0 3 * * 0"
+				latestBuild.CreationTimestamp = metav1.NewTime(time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC))
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, &buildapi.MaintenanceWindow{
+					Schedule: "0 0 1 1 *",
+					Duration: metav1.Duration{Duration: time.Minute},
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonSchedule, result.DeferredReason)
+			})
+		})
+
+		when("maintenance window", func() {
+			closedWindow := &buildapi.MaintenanceWindow{
+				Schedule: "0 0 1 1 *",
+				Duration: metav1.Duration{Duration: time.Minute},
+			}
+
+			it("defers a builder triggered build until the window opens", func() {
+				builder.LatestRunImage = "some.registry.io/run-image@sha256:a1aa3da2a80a775df55e880b094a1a8de19b919435ad0c71c29a0983d64e65db"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, closedWindow)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Equal(t, "", result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildReasonStack, result.DeferredReason)
+				assert.Equal(t, 1, result.DeferredUntil.UTC().YearDay())
+				assert.True(t, result.DeferredUntil.After(time.Now()))
+			})
+
+			it("builds a builder triggered build when the window is open", func() {
+				builder.LatestRunImage = "some.registry.io/run-image@sha256:a1aa3da2a80a775df55e880b094a1a8de19b919435ad0c71c29a0983d64e65db"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, &buildapi.MaintenanceWindow{
+					Schedule: "* * * * *",
+					Duration: metav1.Duration{Duration: time.Hour},
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonStack, result.ReasonsStr)
+				assert.Equal(t, "", result.DeferredReason)
+			})
+
+			it("does not defer user initiated builds", func() {
+				builder.LatestRunImage = "some.registry.io/run-image@sha256:a1aa3da2a80a775df55e880b094a1a8de19b919435ad0c71c29a0983d64e65db"
+				sourceResolver.Status.Source.Git.Revision = "different"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, closedWindow)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, strings.Join([]string{buildapi.BuildReasonCommit, buildapi.BuildReasonStack}, ","), result.ReasonsStr)
+				assert.Equal(t, "", result.DeferredReason)
+			})
+		})
+
 		when("Git", func() {
 			it("true for different GitURL", func() {
 				sourceResolver.Status.Source.Git.URL = "different"
@@ -426,7 +518,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -463,7 +555,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -483,7 +575,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonCommit, result.ReasonsStr)
@@ -499,7 +591,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 						Status: corev1.ConditionFalse,
 					}}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -511,7 +603,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.Status.Source.Git.URL = "some-change"
 				builder.BuilderReady = false
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -523,7 +615,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.Status.Source.Git.Revision = "different"
 				sourceResolver.Status.Conditions = []corev1alpha1.Condition{}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -535,7 +627,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.Status.Source.Git.Revision = "different"
 				sourceResolver.Status.Conditions = []corev1alpha1.Condition{}
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -548,7 +640,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				sourceResolver.ObjectMeta.Generation = 2
 				sourceResolver.Status.ObservedGeneration = 1
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionUnknown, result.ConditionStatus)
 				assert.Equal(t, "", result.PriorityClass)
@@ -604,7 +696,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -624,7 +716,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonCommit, result.ReasonsStr)
@@ -670,7 +762,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -705,13 +797,43 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
 				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
+			it("true for different Blob version", func() {
+				latestBuild.Spec.Source.Blob.URL = "different"
+				latestBuild.Spec.Source.Blob.Version = "etag:\"old\""
+				sourceResolver.Status.Source.Blob.Version = "etag:\"new\""
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "BLOB",
+    "old": "etag:\"old\"",
+    "new": "etag:\"new\""
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonBlob, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false if the last build does not have a Blob version", func() {
+				latestBuild.Spec.Source.Blob.URL = "different"
+				sourceResolver.Status.Source.Blob.Version = "etag:\"new\""
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
 		})
 
 		when("Registry", func() {
@@ -751,7 +873,7 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
@@ -785,13 +907,158 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
   }
 ]`)
 
-				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
 				assert.NoError(t, err)
 				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
 				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
 				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
+
+			it("true for different Registry digest", func() {
+				latestBuild.Spec.Source.Registry.Image = "different"
+				latestBuild.Spec.Source.Registry.Digest = "sha256:old"
+				sourceResolver.Status.Source.Registry.Digest = "sha256:new"
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "REGISTRY",
+    "old": "sha256:old",
+    "new": "sha256:new"
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonRegistry, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false if the last build does not have a Registry digest", func() {
+				latestBuild.Spec.Source.Registry.Image = "different"
+				sourceResolver.Status.Source.Registry.Digest = "sha256:new"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+		})
+
+		when("S3", func() {
+			sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
+				S3: &corev1alpha1.ResolvedS3Source{
+					Bucket:  "some-bucket",
+					Prefix:  "builds/",
+					Object:  "builds/app-2.tar.gz",
+					Version: "etag:\"new\"",
+				},
+			}
+
+			latestBuild.Spec.Source = corev1alpha1.SourceConfig{
+				S3: &corev1alpha1.S3{
+					Bucket:  "some-bucket",
+					Prefix:  "builds/",
+					Object:  "builds/app-1.tar.gz",
+					Version: "etag:\"old\"",
+				},
+			}
+
+			it("true for different S3 version", func() {
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "S3",
+    "old": "etag:\"old\"",
+    "new": "etag:\"new\""
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonS3, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("true for different S3 bucket", func() {
+				sourceResolver.Status.Source.S3.Bucket = "different"
+				sourceResolver.Status.Source.S3.Version = "etag:\"old\""
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
+			})
+
+			it("false if the last build does not have a S3 version", func() {
+				latestBuild.Spec.Source.S3.Version = ""
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+		})
+
+		when("Custom", func() {
+			sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
+				Custom: &corev1alpha1.ResolvedCustomSource{
+					Kind:     "perforce",
+					Config:   map[string]string{"depot": "//depot/app/..."},
+					Endpoint: "http://perforce-resolver.kpack",
+					Version:  "12346",
+					Pollable: true,
+				},
+			}
+
+			latestBuild.Spec.Source = corev1alpha1.SourceConfig{
+				Custom: &corev1alpha1.Custom{
+					Kind:     "perforce",
+					Config:   map[string]string{"depot": "//depot/app/..."},
+					Endpoint: "http://perforce-resolver.kpack",
+					Version:  "12345",
+				},
+			}
+
+			it("true for different Custom version", func() {
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "CUSTOM",
+    "old": "12345",
+    "new": "12346"
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonCustom, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("true for different Custom config", func() {
+				sourceResolver.Status.Source.Custom.Config = map[string]string{"depot": "//depot/other/..."}
+				sourceResolver.Status.Source.Custom.Version = "12345"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
+			})
+
+			it("false for a different source resolver endpoint", func() {
+				sourceResolver.Status.Source.Custom.Endpoint = "http://other-resolver.kpack"
+				sourceResolver.Status.Source.Custom.Version = "12345"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
 		})
 	})
 }
@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -39,6 +40,7 @@ func NewController(
 	duckbuilderInformer *duckbuilder.DuckBuilderInformer,
 	sourceResolverInformer buildinformers.SourceResolverInformer,
 	pvcInformer coreinformers.PersistentVolumeClaimInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
 	enablePriorityClasses bool,
 ) *controller.Impl {
 	c := &Reconciler{
@@ -49,6 +51,8 @@ func NewController(
 		DuckBuilderLister:     duckbuilderInformer.Lister(),
 		SourceResolverLister:  sourceResolverInformer.Lister(),
 		PvcLister:             pvcInformer.Lister(),
+		NamespaceLister:       namespaceInformer.Lister(),
+		MaintenanceWindow:     opt.MaintenanceWindow,
 		EnablePriorityClasses: enablePriorityClasses,
 	}
 
@@ -63,7 +67,7 @@ func NewController(
 
 	sourceResolverInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterControllerGK(buildapi.SchemeGroupVersion.WithKind(Kind).GroupKind()),
-		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
+		Handler:    reconciler.UpdateFilteringHandler(impl.EnqueueControllerOf, sourceResolverChanged),
 	})
 
 	pvcInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -71,13 +75,21 @@ func NewController(
 		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
 	})
 
+	namespaceInformer.Informer().AddEventHandler(c.maintenanceWindowHandler(impl.Enqueue))
+
 	c.Tracker = tracker.New(impl.EnqueueKey, opt.TrackerResyncPeriod())
+	c.Enqueuer = impl
 
 	duckbuilderInformer.AddEventHandler(reconciler.Handler(c.Tracker.OnChanged))
 
 	return impl
 }
 
+//go:generate counterfeiter . Enqueuer
+type Enqueuer interface {
+	EnqueueAfter(obj interface{}, after time.Duration)
+}
+
 type Reconciler struct {
 	Client                versioned.Interface
 	DuckBuilderLister     *duckbuilder.DuckBuilderLister
@@ -85,9 +97,12 @@ type Reconciler struct {
 	BuildLister           buildlisters.BuildLister
 	SourceResolverLister  buildlisters.SourceResolverLister
 	PvcLister             corelisters.PersistentVolumeClaimLister
+	NamespaceLister       corelisters.NamespaceLister
 	Tracker               reconciler.Tracker
+	Enqueuer              Enqueuer
 	K8sClient             k8sclient.Interface
 	EnablePriorityClasses bool
+	MaintenanceWindow     *buildapi.MaintenanceWindow
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -135,7 +150,7 @@ func (c *Reconciler) reconcileImage(ctx context.Context, image *buildapi.Image)
 	}
 
 	if lastBuild.IsRunning() {
-		return image, nil
+		return image, c.cancelOutdatedBuild(ctx, image, lastBuild)
 	}
 
 	buildCacheName, err := c.reconcileBuildCache(ctx, image)
@@ -143,7 +158,7 @@ func (c *Reconciler) reconcileImage(ctx context.Context, image *buildapi.Image)
 		return nil, err
 	}
 
-	sourceResolver, err := c.reconcileSourceResolver(ctx, image)
+	sourceResolver, err := c.reconcileSourceResolver(ctx, image, lastBuild)
 	if err != nil {
 		return nil, err
 	}
@@ -156,8 +171,11 @@ func (c *Reconciler) reconcileImage(ctx context.Context, image *buildapi.Image)
 	return image, c.deleteOldBuilds(ctx, image)
 }
 
-func (c *Reconciler) reconcileSourceResolver(ctx context.Context, image *buildapi.Image) (*buildapi.SourceResolver, error) {
+func (c *Reconciler) reconcileSourceResolver(ctx context.Context, image *buildapi.Image, lastBuild *buildapi.Build) (*buildapi.SourceResolver, error) {
 	desiredSourceResolver := image.SourceResolver()
+	if image.Spec.Source.GitPathFiltered() && lastBuild != nil && lastBuild.Spec.Source.Git != nil {
+		desiredSourceResolver.Spec.BuiltRevision = lastBuild.Spec.Source.Git.Revision
+	}
 
 	sourceResolver, err := c.SourceResolverLister.SourceResolvers(image.Namespace).Get(image.SourceResolverName())
 	if err != nil && !k8serrors.IsNotFound(err) {
@@ -290,3 +308,24 @@ func buildCacheEqual(desiredBuildCache *corev1.PersistentVolumeClaim, buildCache
 	return equality.Semantic.DeepEqual(desiredBuildCache.Spec.Resources, buildCache.Spec.Resources) &&
 		equality.Semantic.DeepEqual(desiredBuildCache.Labels, buildCache.Labels)
 }
+
+// sourceResolverChanged ignores source resolver updates that only record a completed poll
+func sourceResolverChanged(old, new interface{}) bool {
+	oldSourceResolver, ok := old.(*buildapi.SourceResolver)
+	if !ok {
+		return true
+	}
+	newSourceResolver, ok := new.(*buildapi.SourceResolver)
+	if !ok {
+		return true
+	}
+
+	oldStatus := oldSourceResolver.Status.DeepCopy()
+	oldStatus.LastPollTime, oldStatus.NextPollTime = nil, nil
+	newStatus := newSourceResolver.Status.DeepCopy()
+	newStatus.LastPollTime, newStatus.NextPollTime = nil, nil
+
+	return !equality.Semantic.DeepEqual(oldStatus, newStatus) ||
+		oldSourceResolver.Generation != newSourceResolver.Generation ||
+		!equality.Semantic.DeepEqual(oldSourceResolver.Labels, newSourceResolver.Labels)
+}
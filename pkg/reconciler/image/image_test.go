@@ -25,6 +25,7 @@ import (
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/pivotal/kpack/pkg/reconciler/image"
+	"github.com/pivotal/kpack/pkg/reconciler/image/imagefakes"
 	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
 )
 
@@ -46,7 +47,8 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 		originalGeneration     int64 = 1
 	)
 	var (
-		fakeTracker = testhelpers.FakeTracker{}
+		fakeTracker  = testhelpers.FakeTracker{}
+		fakeEnqueuer = &imagefakes.FakeEnqueuer{}
 	)
 
 	rt := testhelpers.ReconcilerTester(t,
@@ -67,7 +69,9 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				DuckBuilderLister:    listers.GetDuckBuilderLister(),
 				SourceResolverLister: listers.GetSourceResolverLister(),
 				PvcLister:            listers.GetPersistentVolumeClaimLister(),
+				NamespaceLister:      listers.GetNamespaceLister(),
 				Tracker:              fakeTracker,
+				Enqueuer:             fakeEnqueuer,
 				K8sClient:            k8sfakeClient,
 			}
 
@@ -351,6 +355,39 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			it("updates source resolver if source polling changed", func() {
+				sourceResolver := image.SourceResolver()
+
+				pollingImage := image.DeepCopy()
+				pollingImage.Spec.SourcePolling = &buildapi.SourcePollingConfig{
+					Interval: &metav1.Duration{Duration: time.Hour},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						pollingImage,
+						builder,
+						sourceResolver,
+					},
+					WantErr: false,
+					WantUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.SourceResolver{
+								ObjectMeta: sourceResolver.ObjectMeta,
+								Spec: buildapi.SourceResolverSpec{
+									ServiceAccountName: image.Spec.ServiceAccountName,
+									Source:             image.Spec.Source,
+									Polling: &buildapi.SourcePollingConfig{
+										Interval: &metav1.Duration{Duration: time.Hour},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
 			it("updates source resolver if labels change", func() {
 				sourceResolver := image.SourceResolver()
 
@@ -748,6 +785,99 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			it("schedules a build when the namespace maintenance window is invalid", func() {
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						&corev1.Namespace{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: namespace,
+								Annotations: map[string]string{
+									buildapi.MaintenanceWindowScheduleAnnotation: "not a schedule",
+									buildapi.MaintenanceWindowDurationAnnotation: "1h",
+								},
+							},
+						},
+					},
+					WantErr: false,
+					WantCreates: []runtime.Object{
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      imageName + "-build-1",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel:     "1",
+									buildapi.ImageLabel:           imageName,
+									buildapi.ImageGenerationLabel: generation(image),
+									someLabelKey:                  someValueToPassThrough,
+								},
+								Annotations: map[string]string{
+									buildapi.BuildReasonAnnotation: buildapi.BuildReasonConfig,
+									buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "CONFIG",
+    "old": {
+      "resources": {},
+      "source": {}
+    },
+    "new": {
+      "resources": {},
+      "source": {
+        "git": {
+          "url": "https://some.git/url-resolved",
+          "revision": "1234567-resolved"
+        }
+      }
+    }
+  }
+]`),
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: image.Spec.ServiceAccountName,
+								Cache:              &buildapi.BuildCacheConfig{},
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      sourceResolver.Status.Source.Git.URL,
+										Revision: sourceResolver.Status.Source.Git.Revision,
+									},
+								},
+							},
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions:         conditionBuildExecuting("image-name-build-1"),
+									},
+									LatestBuildRef:             "image-name-build-1",
+									LatestBuildReason:          "CONFIG",
+									LatestBuildImageGeneration: originalGeneration,
+									BuildCounter:               1,
+								},
+							},
+						},
+					},
+				})
+			})
+
 			it("schedules a build with a cluster builder", func() {
 				image.Spec.Builder = corev1.ObjectReference{
 					Kind: buildapi.ClusterBuilderKind,
@@ -1704,113 +1834,70 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
-			it("schedules a build with previous build's LastBuild if the last build failed", func() {
-				image.Status.BuildCounter = 2
-				image.Status.LatestBuildRef = "image-name-build200001"
+			it("defers a stack build until the namespace maintenance window opens", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				const updatedBuilderImage = "some/builder@sha256:updated"
+
+				window := &buildapi.MaintenanceWindow{
+					Schedule: "0 0 1 1 *",
+					Duration: metav1.Duration{Duration: time.Minute},
+				}
+				opening, err := window.NextOpening(time.Now())
+				require.NoError(t, err)
 
 				sourceResolver := resolvedSourceResolver(image)
 				rt.Test(rtesting.TableRow{
 					Key: key,
 					Objects: []runtime.Object{
 						image,
-						builder,
-						sourceResolver,
-						&buildapi.Build{
+						&corev1.Namespace{
 							ObjectMeta: metav1.ObjectMeta{
-								Name:      "image-name-build-1",
-								Namespace: namespace,
-								OwnerReferences: []metav1.OwnerReference{
-									*kmeta.NewControllerRef(image),
-								},
-								Labels: map[string]string{
-									buildapi.BuildNumberLabel: "2",
-									buildapi.ImageLabel:       imageName,
+								Name: namespace,
+								Annotations: map[string]string{
+									buildapi.MaintenanceWindowScheduleAnnotation: window.Schedule,
+									buildapi.MaintenanceWindowDurationAnnotation: "1m",
 								},
 							},
-							Spec: buildapi.BuildSpec{
-								Tags: []string{image.Spec.Tag},
-								Builder: corev1alpha1.BuildBuilderSpec{
-									Image: builder.Status.LatestImage,
-								},
-								ServiceAccountName: "old-service-account",
-								Source: corev1alpha1.SourceConfig{
-									Git: &corev1alpha1.Git{
-										URL:      "out-of-date-git-url",
-										Revision: "out-of-date-git-revision",
-									},
-								},
-								LastBuild: &buildapi.LastBuild{
-									Image:   image.Spec.Tag + "@sha256:from-build-before-this-build",
-									StackId: "io.buildpacks.stacks.bionic",
-								},
+						},
+						&buildapi.Builder{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      builderName,
+								Namespace: namespace,
 							},
-							Status: buildapi.BuildStatus{
+							Status: buildapi.BuilderStatus{
 								Status: corev1alpha1.Status{
 									Conditions: corev1alpha1.Conditions{
 										{
-											Type:   corev1alpha1.ConditionSucceeded,
-											Status: corev1.ConditionFalse,
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
 										},
 									},
 								},
+								LatestImage: updatedBuilderImage,
+								Stack: corev1alpha1.BuildStack{
+									RunImage: "gcr.io/test-project/install/run@sha256:01ea3600f15a73f0ad445351c681eb0377738f5964cbcd2bab0cfec9ca891a08",
+									ID:       "io.buildpacks.stacks.bionic",
+								},
 							},
 						},
-					},
-					WantErr: false,
-					WantCreates: []runtime.Object{
+						sourceResolver,
 						&buildapi.Build{
 							ObjectMeta: metav1.ObjectMeta{
-								Name:      imageName + "-build-3",
+								Name:      "image-name-build-1",
 								Namespace: namespace,
 								OwnerReferences: []metav1.OwnerReference{
 									*kmeta.NewControllerRef(image),
 								},
 								Labels: map[string]string{
-									buildapi.BuildNumberLabel:     "3",
-									buildapi.ImageLabel:           imageName,
-									buildapi.ImageGenerationLabel: generation(image),
-									someLabelKey:                  someValueToPassThrough,
-								},
-								Annotations: map[string]string{
-									buildapi.BuildReasonAnnotation: strings.Join([]string{
-										buildapi.BuildReasonCommit,
-										buildapi.BuildReasonConfig,
-									}, ","),
-									buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
-[
-  {
-    "reason": "COMMIT",
-    "old": "out-of-date-git-revision",
-    "new": "1234567-resolved"
-  },
-  {
-    "reason": "CONFIG",
-    "old": {
-      "resources": {},
-      "source": {
-        "git": {
-          "url": "out-of-date-git-url",
-          "revision": "out-of-date-git-revision"
-        }
-      }
-    },
-    "new": {
-      "resources": {},
-      "source": {
-        "git": {
-          "url": "https://some.git/url-resolved",
-          "revision": "1234567-resolved"
-        }
-      }
-    }
-  }
-]`),
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       imageName,
 								},
 							},
 							Spec: buildapi.BuildSpec{
 								Tags: []string{image.Spec.Tag},
 								Builder: corev1alpha1.BuildBuilderSpec{
-									Image: builder.Status.LatestImage,
+									Image: updatedBuilderImage,
 								},
 								ServiceAccountName: image.Spec.ServiceAccountName,
 								Source: corev1alpha1.SourceConfig{
@@ -1819,14 +1906,25 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 										Revision: sourceResolver.Status.Source.Git.Revision,
 									},
 								},
-								Cache: &buildapi.BuildCacheConfig{},
-								LastBuild: &buildapi.LastBuild{
-									Image:   "some/image@sha256:from-build-before-this-build",
-									StackId: "io.buildpacks.stacks.bionic",
+							},
+							Status: buildapi.BuildStatus{
+								LatestImage: image.Spec.Tag + "@sha256:just-built",
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								Stack: corev1alpha1.BuildStack{
+									RunImage: "gcr.io/test-project/install/run@sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+									ID:       "io.buildpacks.stacks.bionic",
 								},
 							},
 						},
 					},
+					WantErr: false,
 					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 						{
 							Object: &buildapi.Image{
@@ -1835,12 +1933,27 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 								Status: buildapi.ImageStatus{
 									Status: corev1alpha1.Status{
 										ObservedGeneration: originalGeneration,
-										Conditions:         conditionBuildExecuting("image-name-build-3"),
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionTrue,
+												Reason:  buildapi.BuildDeferred,
+												Message: fmt.Sprintf("STACK build deferred until the maintenance window opens at %s", opening.UTC().Format(time.RFC3339)),
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    image.Spec.Tag + "@sha256:just-built",
+									LatestStack:    "io.buildpacks.stacks.bionic",
+									BuildCounter:   1,
+									DeferredBuild: &buildapi.DeferredBuild{
+										Reason: buildapi.BuildReasonStack,
+										Until:  metav1.NewTime(opening),
 									},
-									LatestBuildRef:             "image-name-build-3",
-									LatestBuildReason:          "COMMIT,CONFIG",
-									LatestBuildImageGeneration: originalGeneration,
-									BuildCounter:               3,
 								},
 							},
 						},
@@ -1848,12 +1961,383 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
-			it("does not schedule a build if the previous build is running", func() {
-				image.Status.BuildCounter = 1
-				image.Status.LatestBuildRef = "image-name-build-1"
-
-				sourceResolver := resolvedSourceResolver(image)
-				rt.Test(rtesting.TableRow{
+			when("the builder has a rollout policy", func() {
+				const (
+					updatedBuilderImage = "some/builder@sha256:updated"
+					oldRunImage         = "gcr.io/test-project/install/run@sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca"
+					newRunImage         = "gcr.io/test-project/install/run@sha256:01ea3600f15a73f0ad445351c681eb0377738f5964cbcd2bab0cfec9ca891a08"
+				)
+
+				rolloutBuilder := func(currentBatch int64, admittedImages ...string) *buildapi.Builder {
+					return &buildapi.Builder{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      builderName,
+							Namespace: namespace,
+						},
+						Spec: buildapi.NamespacedBuilderSpec{
+							BuilderSpec: buildapi.BuilderSpec{
+								Rollout: &buildapi.BuilderRollout{BatchSize: 1},
+							},
+						},
+						Status: buildapi.BuilderStatus{
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionReady,
+										Status: corev1.ConditionTrue,
+									},
+								},
+							},
+							LatestImage: updatedBuilderImage,
+							Stack: corev1alpha1.BuildStack{
+								RunImage: newRunImage,
+								ID:       "io.buildpacks.stacks.bionic",
+							},
+							Rollout: &buildapi.BuilderRolloutStatus{
+								Image:          updatedBuilderImage,
+								Phase:          buildapi.RolloutProgressing,
+								CurrentBatch:   currentBatch,
+								Batches:        2,
+								Images:         2,
+								AdmittedImages: admittedImages,
+							},
+						},
+					}
+				}
+
+				lastBuild := func(img *buildapi.Image, sourceResolver *buildapi.SourceResolver) *buildapi.Build {
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      img.Name + "-build-1",
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(img),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel: "1",
+								buildapi.ImageLabel:       img.Name,
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{img.Spec.Tag},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: "some/builder@sha256:old",
+							},
+							ServiceAccountName: img.Spec.ServiceAccountName,
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      sourceResolver.Status.Source.Git.URL,
+									Revision: sourceResolver.Status.Source.Git.Revision,
+								},
+							},
+						},
+						Status: buildapi.BuildStatus{
+							LatestImage: img.Spec.Tag + "@sha256:just-built",
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionSucceeded,
+										Status: corev1.ConditionTrue,
+									},
+								},
+							},
+							Stack: corev1alpha1.BuildStack{
+								RunImage: oldRunImage,
+								ID:       "io.buildpacks.stacks.bionic",
+							},
+						},
+					}
+				}
+
+				it("does not schedule a stack build until the rollout reaches the image", func() {
+					image.Status.BuildCounter = 1
+					image.Status.LatestBuildRef = "image-name-build-1"
+
+					canary := image.DeepCopy()
+					canary.Name = "a-canary-image"
+
+					sourceResolver := resolvedSourceResolver(image)
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							canary,
+							rolloutBuilder(1, namespace+"/a-canary-image"),
+							sourceResolver,
+							lastBuild(image, sourceResolver),
+							lastBuild(canary, sourceResolver),
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: image.ObjectMeta,
+									Spec:       image.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:    corev1alpha1.ConditionReady,
+													Status:  corev1.ConditionTrue,
+													Reason:  buildapi.BuilderRolloutPending,
+													Message: "Waiting for the rollout of builder builder-name",
+												},
+												{
+													Type:   buildapi.ConditionBuilderReady,
+													Status: corev1.ConditionTrue,
+												},
+											},
+										},
+										LatestBuildRef: "image-name-build-1",
+										LatestImage:    image.Spec.Tag + "@sha256:just-built",
+										LatestStack:    "io.buildpacks.stacks.bionic",
+										BuildCounter:   1,
+									},
+								},
+							},
+						},
+					})
+				})
+
+				it("schedules a stack build once the rollout reaches the image", func() {
+					image.Status.BuildCounter = 1
+					image.Status.LatestBuildRef = "image-name-build-1"
+
+					canary := image.DeepCopy()
+					canary.Name = "a-canary-image"
+
+					sourceResolver := resolvedSourceResolver(image)
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							canary,
+							rolloutBuilder(2, namespace+"/a-canary-image", key),
+							sourceResolver,
+							lastBuild(image, sourceResolver),
+							lastBuild(canary, sourceResolver),
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(image),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(image),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuildReasonAnnotation: buildapi.BuildReasonStack,
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "STACK",
+    "old": "sha256:42841631725942db48b7ba8b788b97374a2ada34c84ee02ca5e02ef3d4b0dfca",
+    "new": "sha256:01ea3600f15a73f0ad445351c681eb0377738f5964cbcd2bab0cfec9ca891a08"
+  }
+]`),
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{image.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: updatedBuilderImage,
+									},
+									ServiceAccountName: image.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+									Cache: &buildapi.BuildCacheConfig{},
+									LastBuild: &buildapi.LastBuild{
+										Image:   "some/image@sha256:just-built",
+										StackId: "io.buildpacks.stacks.bionic",
+									},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: image.ObjectMeta,
+									Spec:       image.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildImageGeneration: originalGeneration,
+										LatestBuildReason:          buildapi.BuildReasonStack,
+										LatestImage:                image.Spec.Tag + "@sha256:just-built",
+										BuildCounter:               2,
+									},
+								},
+							},
+						},
+					})
+				})
+			})
+
+			it("schedules a build with previous build's LastBuild if the last build failed", func() {
+				image.Status.BuildCounter = 2
+				image.Status.LatestBuildRef = "image-name-build200001"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "image-name-build-1",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "2",
+									buildapi.ImageLabel:       imageName,
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: "old-service-account",
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      "out-of-date-git-url",
+										Revision: "out-of-date-git-revision",
+									},
+								},
+								LastBuild: &buildapi.LastBuild{
+									Image:   image.Spec.Tag + "@sha256:from-build-before-this-build",
+									StackId: "io.buildpacks.stacks.bionic",
+								},
+							},
+							Status: buildapi.BuildStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionFalse,
+										},
+									},
+								},
+							},
+						},
+					},
+					WantErr: false,
+					WantCreates: []runtime.Object{
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      imageName + "-build-3",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel:     "3",
+									buildapi.ImageLabel:           imageName,
+									buildapi.ImageGenerationLabel: generation(image),
+									someLabelKey:                  someValueToPassThrough,
+								},
+								Annotations: map[string]string{
+									buildapi.BuildReasonAnnotation: strings.Join([]string{
+										buildapi.BuildReasonCommit,
+										buildapi.BuildReasonConfig,
+									}, ","),
+									buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "COMMIT",
+    "old": "out-of-date-git-revision",
+    "new": "1234567-resolved"
+  },
+  {
+    "reason": "CONFIG",
+    "old": {
+      "resources": {},
+      "source": {
+        "git": {
+          "url": "out-of-date-git-url",
+          "revision": "out-of-date-git-revision"
+        }
+      }
+    },
+    "new": {
+      "resources": {},
+      "source": {
+        "git": {
+          "url": "https://some.git/url-resolved",
+          "revision": "1234567-resolved"
+        }
+      }
+    }
+  }
+]`),
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: image.Spec.ServiceAccountName,
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      sourceResolver.Status.Source.Git.URL,
+										Revision: sourceResolver.Status.Source.Git.Revision,
+									},
+								},
+								Cache: &buildapi.BuildCacheConfig{},
+								LastBuild: &buildapi.LastBuild{
+									Image:   "some/image@sha256:from-build-before-this-build",
+									StackId: "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions:         conditionBuildExecuting("image-name-build-3"),
+									},
+									LatestBuildRef:             "image-name-build-3",
+									LatestBuildReason:          "COMMIT,CONFIG",
+									LatestBuildImageGeneration: originalGeneration,
+									BuildCounter:               3,
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("does not schedule a build if the previous build is running", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
 					Key: key,
 					Objects: []runtime.Object{
 						image,
@@ -1900,6 +2384,106 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("the image cancels outdated builds", func() {
+				runningBuild := func(revision string) *buildapi.Build {
+					return &buildapi.Build{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "image-name-build-1",
+							Namespace: namespace,
+							OwnerReferences: []metav1.OwnerReference{
+								*kmeta.NewControllerRef(image),
+							},
+							Labels: map[string]string{
+								buildapi.BuildNumberLabel: "1",
+								buildapi.ImageLabel:       imageName,
+							},
+						},
+						Spec: buildapi.BuildSpec{
+							Tags: []string{image.Spec.Tag},
+							Builder: corev1alpha1.BuildBuilderSpec{
+								Image: builder.Status.LatestImage,
+							},
+							ServiceAccountName: image.Spec.ServiceAccountName,
+							Source: corev1alpha1.SourceConfig{
+								Git: &corev1alpha1.Git{
+									URL:      "https://some.git/url-resolved",
+									Revision: revision,
+								},
+							},
+						},
+						Status: buildapi.BuildStatus{
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionSucceeded,
+										Status: corev1.ConditionUnknown,
+									},
+								},
+							},
+						},
+					}
+				}
+
+				it.Before(func() {
+					image.Spec.CancelOutdatedBuilds = true
+					image.Status.BuildCounter = 1
+					image.Status.LatestBuildRef = "image-name-build-1"
+				})
+
+				it("cancels the running build when a newer revision has been resolved", func() {
+					build := runningBuild("old-revision")
+					cancelledBuild := build.DeepCopy()
+					cancelledBuild.Spec.Cancelled = true
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							builder,
+							resolvedSourceResolver(image),
+							build,
+						},
+						WantErr: false,
+						WantUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: cancelledBuild,
+							},
+						},
+					})
+				})
+
+				it("does not cancel the running build when it is building the latest revision", func() {
+					sourceResolver := resolvedSourceResolver(image)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							builder,
+							sourceResolver,
+							runningBuild(sourceResolver.Status.Source.Git.Revision),
+						},
+						WantErr: false,
+					})
+				})
+
+				it("does not cancel a running build that is already cancelled", func() {
+					build := runningBuild("old-revision")
+					build.Spec.Cancelled = true
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							builder,
+							resolvedSourceResolver(image),
+							build,
+						},
+						WantErr: false,
+					})
+				})
+			})
+
 			it("does not schedule a build if the previous build spec matches the current desired spec", func() {
 				image.Status.BuildCounter = 1
 				image.Status.LatestBuildRef = "image-name-build-1"
@@ -2010,6 +2594,111 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			it("reports when the latest source revision was skipped by the git path filter", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@some-old-sha"
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(image)
+				filteredSourceResolver := resolvedSourceResolver(image)
+				filteredSourceResolver.Status.Source.Git.LatestRevision = "some-newer-revision"
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						successfulBuilds(image, sourceResolver, 1),
+						image,
+						builder,
+						filteredSourceResolver,
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionTrue,
+												Reason:  buildapi.SourceChangesFiltered,
+												Message: "Revision some-newer-revision has no changes matching the git path filter",
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("records the revision of the latest build on the source resolver when the source is path filtered", func() {
+				image.Spec.Source.SubPath = "some-sub-path"
+				image.Spec.Source.Git.PathFilter = &corev1alpha1.GitPathFilter{}
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@some-old-sha"
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(image)
+				updatedSourceResolver := sourceResolver.DeepCopy()
+				updatedSourceResolver.Spec.BuiltRevision = sourceResolver.Status.Source.Git.Revision
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						successfulBuilds(image, sourceResolver, 1),
+						image,
+						builder,
+						sourceResolver,
+					),
+					WantErr: false,
+					WantUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: updatedSourceResolver,
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+			})
+
 			it("reports unknown when last build was successful and source resolver is unknown", func() {
 				image.Status.BuildCounter = 1
 				image.Status.LatestBuildRef = "image-name-build-1"
@@ -2106,6 +2795,139 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 
 			})
 
+			when("the last build failed transiently", func() {
+				evictedBuild := func(sourceResolver *buildapi.SourceResolver, failedAt time.Time) *buildapi.Build {
+					return builds(image, sourceResolver, 1, corev1alpha1.Condition{
+						Type:               corev1alpha1.ConditionSucceeded,
+						Status:             corev1.ConditionFalse,
+						Reason:             "Evicted",
+						LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.NewTime(failedAt)},
+					})[0].(*buildapi.Build)
+				}
+
+				it.Before(func() {
+					image.Spec.RetryLimit = limit(2)
+					image.Status.LatestBuildRef = "image-name-build-1"
+					image.Status.Conditions = conditionNotReady()
+					image.Status.BuildCounter = 1
+				})
+
+				it("schedules a retry build once the backoff has elapsed", func() {
+					sourceResolver := resolvedSourceResolver(image)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							builder,
+							sourceResolver,
+							evictedBuild(sourceResolver, time.Now().Add(-time.Hour)),
+						},
+						WantErr: false,
+						WantCreates: []runtime.Object{
+							&buildapi.Build{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      imageName + "-build-2",
+									Namespace: namespace,
+									OwnerReferences: []metav1.OwnerReference{
+										*kmeta.NewControllerRef(image),
+									},
+									Labels: map[string]string{
+										buildapi.BuildNumberLabel:     "2",
+										buildapi.ImageLabel:           imageName,
+										buildapi.ImageGenerationLabel: generation(image),
+										someLabelKey:                  someValueToPassThrough,
+									},
+									Annotations: map[string]string{
+										buildapi.BuildReasonAnnotation: buildapi.BuildReasonRetry,
+										buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": "Build pod was evicted",
+    "new": "Retry 1 of 2"
+  }
+]`),
+										buildapi.BuildRetryAnnotation: "1",
+									},
+								},
+								Spec: buildapi.BuildSpec{
+									Tags: []string{image.Spec.Tag},
+									Builder: corev1alpha1.BuildBuilderSpec{
+										Image: builder.Status.LatestImage,
+									},
+									ServiceAccountName: image.Spec.ServiceAccountName,
+									Source: corev1alpha1.SourceConfig{
+										Git: &corev1alpha1.Git{
+											URL:      sourceResolver.Status.Source.Git.URL,
+											Revision: sourceResolver.Status.Source.Git.Revision,
+										},
+									},
+									Cache: &buildapi.BuildCacheConfig{},
+								},
+							},
+						},
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.Image{
+									ObjectMeta: image.ObjectMeta,
+									Spec:       image.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionBuildExecuting("image-name-build-2"),
+										},
+										LatestBuildRef:             "image-name-build-2",
+										LatestBuildReason:          buildapi.BuildReasonRetry,
+										LatestBuildImageGeneration: originalGeneration,
+										BuildCounter:               2,
+									},
+								},
+							},
+						},
+					})
+					require.Equal(t, 0, fakeEnqueuer.EnqueueAfterCallCount())
+				})
+
+				it("requeues the image until the backoff has elapsed", func() {
+					sourceResolver := resolvedSourceResolver(image)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							builder,
+							sourceResolver,
+							evictedBuild(sourceResolver, time.Now()),
+						},
+						WantErr: false,
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueAfterCallCount())
+					enqueued, after := fakeEnqueuer.EnqueueAfterArgsForCall(0)
+					require.Equal(t, imageName, enqueued.(*buildapi.Image).Name)
+					require.True(t, after > 0 && after <= 30*time.Second, "unexpected backoff %s", after)
+				})
+
+				it("does not retry once the retry limit has been reached", func() {
+					sourceResolver := resolvedSourceResolver(image)
+					build := evictedBuild(sourceResolver, time.Now().Add(-time.Hour))
+					build.Annotations = map[string]string{buildapi.BuildRetryAnnotation: "2"}
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							image,
+							builder,
+							sourceResolver,
+							build,
+						},
+						WantErr: false,
+					})
+					require.Equal(t, 0, fakeEnqueuer.EnqueueAfterCallCount())
+				})
+			})
+
 			when("reconciling old builds", func() {
 
 				it("deletes a failed build if more than the limit", func() {
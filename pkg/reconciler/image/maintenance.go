@@ -0,0 +1,76 @@
+package image
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// maintenanceWindow returns the maintenance window of the image, falling back
+// to the window configured on its namespace and then the cluster default. An
+// invalid namespace window is logged and ignored so that it never blocks builds.
+func (c *Reconciler) maintenanceWindow(ctx context.Context, image *buildapi.Image) (*buildapi.MaintenanceWindow, error) {
+	if image.Spec.MaintenanceWindow != nil {
+		return image.Spec.MaintenanceWindow, nil
+	}
+
+	namespace, err := c.NamespaceLister.Get(image.Namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	} else if err == nil {
+		window, err := buildapi.MaintenanceWindowFromAnnotations(namespace.Annotations)
+		if err != nil {
+			logging.FromContext(ctx).Warnf("ignoring invalid maintenance window for namespace %s: %s", image.Namespace, err)
+			return nil, nil
+		} else if window != nil {
+			return window, nil
+		}
+	}
+
+	return c.MaintenanceWindow, nil
+}
+
+// maintenanceWindowHandler enqueues the images in a namespace when the
+// maintenance window annotations of the namespace change
+func (c *Reconciler) maintenanceWindowHandler(enqueue func(interface{})) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNamespace, ok := old.(*corev1.Namespace)
+			if !ok {
+				return
+			}
+			newNamespace, ok := new.(*corev1.Namespace)
+			if !ok || !maintenanceWindowChanged(oldNamespace, newNamespace) {
+				return
+			}
+
+			images, err := c.ImageLister.Images(newNamespace.Name).List(labels.Everything())
+			if err != nil {
+				return
+			}
+
+			for _, image := range images {
+				enqueue(image)
+			}
+		},
+	}
+}
+
+func maintenanceWindowChanged(old, new *corev1.Namespace) bool {
+	for _, annotation := range []string{
+		buildapi.MaintenanceWindowScheduleAnnotation,
+		buildapi.MaintenanceWindowDurationAnnotation,
+		buildapi.MaintenanceWindowTimeZoneAnnotation,
+	} {
+		if old.Annotations[annotation] != new.Annotations[annotation] {
+			return true
+		}
+	}
+	return false
+}
@@ -4,25 +4,42 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildchange"
+	"github.com/pivotal/kpack/pkg/duckbuilder"
+	"github.com/pivotal/kpack/pkg/rollout"
 )
 
-func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image, latestBuild *buildapi.Build, sourceResolver *buildapi.SourceResolver, builder buildapi.BuilderResource, buildCacheName string) (buildapi.ImageStatus, error) {
+func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image, latestBuild *buildapi.Build, sourceResolver *buildapi.SourceResolver, builder *duckbuilder.DuckBuilder, buildCacheName string) (buildapi.ImageStatus, error) {
 	currentBuildNumber, err := buildCounter(latestBuild)
 	if err != nil {
 		return buildapi.ImageStatus{}, err
 	}
 
-	result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+	window, err := c.maintenanceWindow(ctx, image)
+	if err != nil {
+		return buildapi.ImageStatus{}, err
+	}
+
+	result, err := isBuildRequired(image, latestBuild, sourceResolver, builder, window)
 	if err != nil {
 		return buildapi.ImageStatus{}, errors.Wrap(err, "error determining if an image build is needed")
 	}
+
+	rolloutPending := rolloutPending(image, builder, result)
+	if rolloutPending {
+		result.ConditionStatus = corev1.ConditionFalse
+	}
+
 	priorityClass := ""
 	if c.EnablePriorityClasses {
 		priorityClass = result.PriorityClass
@@ -31,6 +48,10 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 	case corev1.ConditionTrue:
 		nextBuildNumber := currentBuildNumber + 1
 		build := image.Build(sourceResolver, builder, latestBuild, result.ReasonsStr, result.ChangesStr, nextBuildNumber, priorityClass)
+		if result.RetryAttempt > 0 {
+			build.Annotations[buildapi.BuildRetryAnnotation] = strconv.FormatInt(result.RetryAttempt, 10)
+		}
+
 		build, err = c.Client.KpackV1alpha2().Builds(build.Namespace).Create(ctx, build, metav1.CreateOptions{})
 		if err != nil {
 			return buildapi.ImageStatus{}, err
@@ -51,9 +72,34 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 	case corev1.ConditionUnknown:
 		fallthrough
 	case corev1.ConditionFalse:
+		if result.RetryAfter > 0 {
+			c.Enqueuer.EnqueueAfter(image, result.RetryAfter)
+		}
+		if result.RebuildAfter > 0 {
+			c.Enqueuer.EnqueueAfter(image, result.RebuildAfter)
+		}
+
+		conditions := noScheduledBuild(result.ConditionStatus, builder, latestBuild, sourceResolver)
+		if rolloutPending {
+			conditions[0].Reason = buildapi.BuilderRolloutPending
+			conditions[0].Message = fmt.Sprintf("Waiting for the rollout of builder %s", builder.GetName())
+		}
+
+		var deferredBuild *buildapi.DeferredBuild
+		if result.DeferredReason != "" {
+			c.Enqueuer.EnqueueAfter(image, time.Until(result.DeferredUntil))
+
+			conditions[0].Reason = buildapi.BuildDeferred
+			conditions[0].Message = fmt.Sprintf("%s build deferred until the maintenance window opens at %s", result.DeferredReason, result.DeferredUntil.UTC().Format(time.RFC3339))
+			deferredBuild = &buildapi.DeferredBuild{
+				Reason: result.DeferredReason,
+				Until:  metav1.NewTime(result.DeferredUntil),
+			}
+		}
+
 		return buildapi.ImageStatus{
 			Status: corev1alpha1.Status{
-				Conditions: noScheduledBuild(result.ConditionStatus, builder, latestBuild),
+				Conditions: conditions,
 			},
 			LatestBuildRef:             latestBuild.BuildRef(),
 			LatestBuildReason:          latestBuild.BuildReason(),
@@ -62,13 +108,82 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 			LatestStack:                latestBuild.Stack(),
 			BuildCounter:               currentBuildNumber,
 			BuildCacheName:             buildCacheName,
+			DeferredBuild:              deferredBuild,
 		}, nil
 	default:
 		return buildapi.ImageStatus{}, errors.Errorf("unexpected build needed condition %s", result.ConditionStatus)
 	}
 }
 
-func noScheduledBuild(buildNeeded corev1.ConditionStatus, builder buildapi.BuilderResource, build *buildapi.Build) corev1alpha1.Conditions {
+// cancelOutdatedBuild cancels the running build when the image opts in and
+// the source has moved on to a newer revision than the one being built
+func (c *Reconciler) cancelOutdatedBuild(ctx context.Context, image *buildapi.Image, runningBuild *buildapi.Build) error {
+	if !image.Spec.CancelOutdatedBuilds || runningBuild.Spec.Cancelled {
+		return nil
+	}
+
+	sourceResolver, err := c.SourceResolverLister.SourceResolvers(image.Namespace).Get(image.SourceResolverName())
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if !sourceResolver.Ready() {
+		return nil
+	}
+
+	summary, err := buildchange.NewChangeProcessor().
+		Process(commitChange(runningBuild, sourceResolver)).
+		Process(sourceVersionChange(runningBuild, sourceResolver)).
+		Summarize()
+	if err != nil {
+		return errors.Wrap(err, "error determining if the running build is outdated")
+	}
+
+	if !summary.HasChanges {
+		return nil
+	}
+
+	runningBuild = runningBuild.DeepCopy()
+	runningBuild.Spec.Cancelled = true
+	_, err = c.Client.KpackV1alpha2().Builds(runningBuild.Namespace).Update(ctx, runningBuild, metav1.UpdateOptions{})
+	return err
+}
+
+// rolloutPending holds back builds triggered only by a builder update until
+// the rollout status of the builder admits the image
+func rolloutPending(image *buildapi.Image, builder *duckbuilder.DuckBuilder, result buildRequiredResult) bool {
+	if result.ConditionStatus != corev1.ConditionTrue || builder.Spec.Rollout == nil || !builderTriggered(result.ReasonsStr) {
+		return false
+	}
+
+	return !rollout.Admits(builder.Status.Rollout, builder.Status.LatestImage, image)
+}
+
+func builderTriggered(reasons string) bool {
+	for _, reason := range strings.Split(reasons, ",") {
+		if reason != buildapi.BuildReasonStack && reason != buildapi.BuildReasonBuildpack {
+			return false
+		}
+	}
+	return true
+}
+
+// deferrable reports whether a build is only required for reasons that were
+// not initiated by a user and can wait for a maintenance window. Scheduled
+// rebuilds only pick up dependencies fetched at build time, so they wait for
+// the window like stack and buildpack rebuilds.
+func deferrable(reasons string) bool {
+	for _, reason := range strings.Split(reasons, ",") {
+		if reason != buildapi.BuildReasonStack && reason != buildapi.BuildReasonBuildpack && reason != buildapi.BuildReasonSchedule {
+			return false
+		}
+	}
+	return true
+}
+
+func noScheduledBuild(buildNeeded corev1.ConditionStatus, builder buildapi.BuilderResource, build *buildapi.Build, sourceResolver *buildapi.SourceResolver) corev1alpha1.Conditions {
 	if buildNeeded == corev1.ConditionUnknown {
 		return corev1alpha1.Conditions{
 			{
@@ -80,15 +195,21 @@ func noScheduledBuild(buildNeeded corev1.ConditionStatus, builder buildapi.Build
 		}
 	}
 
+	readyCondition := corev1alpha1.Condition{
+		Type:               corev1alpha1.ConditionReady,
+		Status:             unknownIfNil(build.Status.GetCondition(corev1alpha1.ConditionSucceeded)),
+		LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+	}
+
+	if git := sourceResolver.Status.Source.Git; git != nil && git.IsFiltered() {
+		readyCondition.Reason = buildapi.SourceChangesFiltered
+		readyCondition.Message = fmt.Sprintf("Revision %s has no changes matching the git path filter", git.LatestRevision)
+	}
+
 	return corev1alpha1.Conditions{
-		{
-			Type:               corev1alpha1.ConditionReady,
-			Status:             unknownIfNil(build.Status.GetCondition(corev1alpha1.ConditionSucceeded)),
-			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
-		},
+		readyCondition,
 		builderCondition(builder),
 	}
-
 }
 
 func unknownIfNil(condition *corev1alpha1.Condition) corev1.ConditionStatus {
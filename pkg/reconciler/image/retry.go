@@ -0,0 +1,85 @@
+package image
+
+import (
+	"time"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildchange"
+)
+
+const (
+	retryBaseBackoff = 30 * time.Second
+	retryMaxBackoff  = 10 * time.Minute
+
+	podEvictedReason = "Evicted"
+	oomKilledReason  = "OOMKilled"
+)
+
+// retryChange returns a RETRY change when the last build failed transiently
+// and the image has retries left. While the backoff for the retry has not
+// elapsed it returns the time remaining instead.
+func retryChange(img *buildapi.Image, lastBuild *buildapi.Build, now time.Time) (buildchange.Change, time.Duration) {
+	if lastBuild == nil || !lastBuild.IsFailure() {
+		return nil, 0
+	}
+
+	attempt := lastBuild.RetryAttempt() + 1
+	if attempt > img.RetryLimit() {
+		return nil, 0
+	}
+
+	failure, ok := transientFailure(lastBuild)
+	if !ok {
+		return nil, 0
+	}
+
+	failedAt := lastBuild.Status.GetCondition(corev1alpha1.ConditionSucceeded).LastTransitionTime.Inner.Time
+	if remaining := failedAt.Add(retryBackoff(attempt)).Sub(now); remaining > 0 {
+		return nil, remaining
+	}
+
+	priority := buildapi.PriorityForClass(lastBuild.PriorityClassName())
+	if priority == buildapi.BuildPriorityNone {
+		priority = buildapi.BuildPriorityLow
+	}
+
+	return buildchange.NewRetryChange(failure, attempt, img.RetryLimit(), priority), 0
+}
+
+func retryBackoff(attempt int64) time.Duration {
+	backoff := retryBaseBackoff
+	for i := int64(1); i < attempt && backoff < retryMaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > retryMaxBackoff {
+		return retryMaxBackoff
+	}
+	return backoff
+}
+
+func transientFailure(build *buildapi.Build) (string, bool) {
+	if condition := build.Status.GetCondition(corev1alpha1.ConditionSucceeded); condition != nil {
+		switch condition.Reason {
+		case podEvictedReason:
+			return "Build pod was evicted", true
+		case buildapi.TransientRegistryError:
+			return condition.Message, true
+		}
+	}
+
+	for _, state := range build.Status.StepStates {
+		terminated := state.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+
+		if terminated.Reason == oomKilledReason {
+			return "Build step was OOMKilled", true
+		}
+
+	}
+
+	return "", false
+}
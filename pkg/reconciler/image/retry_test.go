@@ -0,0 +1,173 @@
+package image
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestRetry(t *testing.T) {
+	spec.Run(t, "Retry", testRetry)
+}
+
+func testRetry(t *testing.T, when spec.G, it spec.S) {
+	var (
+		now        = time.Now()
+		retryLimit = int64(3)
+		image      = &buildapi.Image{
+			Spec: buildapi.ImageSpec{
+				RetryLimit: &retryLimit,
+			},
+		}
+		failedBuild = &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{},
+			},
+			Status: buildapi.BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:               corev1alpha1.ConditionSucceeded,
+							Status:             corev1.ConditionFalse,
+							LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.NewTime(now.Add(-time.Hour))},
+						},
+					},
+				},
+			},
+		}
+	)
+
+	terminatedStep := func(terminated corev1.ContainerStateTerminated) []corev1.ContainerState {
+		return []corev1.ContainerState{
+			{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+			{Terminated: &terminated},
+		}
+	}
+
+	when("#transientFailure", func() {
+		it("classifies evicted build pods", func() {
+			failedBuild.Status.Conditions[0].Reason = "Evicted"
+
+			failure, ok := transientFailure(failedBuild)
+			assert.True(t, ok)
+			assert.Equal(t, "Build pod was evicted", failure)
+		})
+
+		it("classifies OOMKilled steps", func() {
+			failedBuild.Status.StepStates = terminatedStep(corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"})
+
+			failure, ok := transientFailure(failedBuild)
+			assert.True(t, ok)
+			assert.Equal(t, "Build step was OOMKilled", failure)
+		})
+
+		it("classifies transient registry errors", func() {
+			failedBuild.Status.Conditions[0].Reason = buildapi.TransientRegistryError
+			failedBuild.Status.Conditions[0].Message = "Lifecycle export phase failed with a transient registry error: status code 503"
+
+			failure, ok := transientFailure(failedBuild)
+			assert.True(t, ok)
+			assert.Equal(t, "Lifecycle export phase failed with a transient registry error: status code 503", failure)
+		})
+
+		it("does not classify registry phase failures without a transient registry error", func() {
+			failedBuild.Status.StepStates = terminatedStep(corev1.ContainerStateTerminated{ExitCode: 62, Reason: "Error"})
+
+			_, ok := transientFailure(failedBuild)
+			assert.False(t, ok)
+		})
+
+		it("does not classify buildpack failures", func() {
+			failedBuild.Status.StepStates = terminatedStep(corev1.ContainerStateTerminated{ExitCode: 51, Message: "npm ERR! missing script: build"})
+
+			_, ok := transientFailure(failedBuild)
+			assert.False(t, ok)
+		})
+
+		it("does not classify timed out builds", func() {
+			failedBuild.Status.Conditions[0].Reason = buildapi.BuildTimedOut
+
+			_, ok := transientFailure(failedBuild)
+			assert.False(t, ok)
+		})
+	})
+
+	when("#retryChange", func() {
+		it.Before(func() {
+			failedBuild.Status.Conditions[0].Reason = "Evicted"
+		})
+
+		it("returns a retry change for the next attempt", func() {
+			failedBuild.Annotations[buildapi.BuildRetryAnnotation] = "1"
+
+			change, after := retryChange(image, failedBuild, now)
+			assert.Equal(t, time.Duration(0), after)
+			assert.Equal(t, buildapi.BuildReason(buildapi.BuildReasonRetry), change.Reason())
+			assert.Equal(t, "Build pod was evicted", change.Old())
+			assert.Equal(t, "Retry 2 of 3", change.New())
+			assert.Equal(t, buildapi.BuildPriorityLow, change.Priority())
+		})
+
+		it("keeps the priority of high priority builds", func() {
+			failedBuild.Spec.PriorityClassName = buildapi.BuildPriorityClassHigh
+
+			change, _ := retryChange(image, failedBuild, now)
+			assert.Equal(t, buildapi.BuildPriorityHigh, change.Priority())
+		})
+
+		it("keeps the priority of scheduled builds", func() {
+			failedBuild.Spec.PriorityClassName = buildapi.BuildPriorityClassScheduled
+
+			change, _ := retryChange(image, failedBuild, now)
+			assert.Equal(t, buildapi.BuildPriorityScheduled, change.Priority())
+		})
+
+		it("returns the remaining backoff when the backoff has not elapsed", func() {
+			failedBuild.Annotations[buildapi.BuildRetryAnnotation] = "2"
+			failedBuild.Status.Conditions[0].LastTransitionTime = corev1alpha1.VolatileTime{Inner: metav1.NewTime(now.Add(-time.Minute))}
+
+			change, after := retryChange(image, failedBuild, now)
+			assert.Nil(t, change)
+			assert.Equal(t, time.Minute, after)
+		})
+
+		it("does not retry once the retry limit is reached", func() {
+			failedBuild.Annotations[buildapi.BuildRetryAnnotation] = "3"
+
+			change, after := retryChange(image, failedBuild, now)
+			assert.Nil(t, change)
+			assert.Equal(t, time.Duration(0), after)
+		})
+
+		it("does not retry without a retry limit", func() {
+			image.Spec.RetryLimit = nil
+
+			change, _ := retryChange(image, failedBuild, now)
+			assert.Nil(t, change)
+		})
+
+		it("does not retry successful builds", func() {
+			failedBuild.Status.Conditions[0].Status = corev1.ConditionTrue
+
+			change, _ := retryChange(image, failedBuild, now)
+			assert.Nil(t, change)
+		})
+	})
+
+	when("#retryBackoff", func() {
+		it("doubles the backoff for each attempt up to a maximum", func() {
+			assert.Equal(t, 30*time.Second, retryBackoff(1))
+			assert.Equal(t, time.Minute, retryBackoff(2))
+			assert.Equal(t, 2*time.Minute, retryBackoff(3))
+			assert.Equal(t, 10*time.Minute, retryBackoff(6))
+			assert.Equal(t, 10*time.Minute, retryBackoff(100))
+		})
+	})
+}
@@ -0,0 +1,29 @@
+package image
+
+import (
+	"time"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/buildchange"
+)
+
+// scheduleChange returns a SCHEDULE change when the image has a rebuild
+// schedule and a scheduled rebuild has passed since the last build was
+// created. Otherwise it returns the time remaining until the next one.
+func scheduleChange(img *buildapi.Image, lastBuild *buildapi.Build, now time.Time) (buildchange.Change, time.Duration, error) {
+	if lastBuild == nil {
+		return nil, 0, nil
+	}
+
+	lastBuildTime := lastBuild.CreationTimestamp.Time
+	scheduled, err := img.NextScheduledRebuild(lastBuildTime)
+	if err != nil || scheduled.IsZero() {
+		return nil, 0, err
+	}
+
+	if remaining := scheduled.Sub(now); remaining > 0 {
+		return nil, remaining, nil
+	}
+
+	return buildchange.NewScheduleChange(lastBuildTime.UTC().Format(time.RFC3339), scheduled.UTC().Format(time.RFC3339)), 0, nil
+}
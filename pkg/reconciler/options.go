@@ -5,6 +5,7 @@ import (
 
 	"go.uber.org/zap"
 
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 )
 
@@ -15,6 +16,14 @@ type Options struct {
 	ResyncPeriod            time.Duration
 	SourcePollingFrequency  time.Duration
 	BuilderPollingFrequency time.Duration
+	StorePollingFrequency   time.Duration
+	StackPollingFrequency   time.Duration
+	BuildTimeout            time.Duration
+
+	MaxConcurrentBuilds             int
+	MaxConcurrentBuildsPerNamespace int
+
+	MaintenanceWindow *buildapi.MaintenanceWindow
 }
 
 func (o Options) TrackerResyncPeriod() time.Duration {
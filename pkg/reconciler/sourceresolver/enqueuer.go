@@ -3,15 +3,41 @@ package sourceresolver
 import (
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 )
 
+// pollingJitterFactor spreads polls of resolvers with the same interval so they do not all hit the git host at once
+const pollingJitterFactor = 0.1
+
 type workQueueEnqueuer struct {
 	enqueueAfter func(obj interface{}, after time.Duration)
 	delay        time.Duration
 }
 
+// Enqueue is called once the source has been resolved. A reconcile that runs before the scheduled poll,
+// such as one triggered by a spec change or a webhook, keeps the existing schedule so the poll times
+// in the status only change when a scheduled poll has completed.
 func (e *workQueueEnqueuer) Enqueue(sr *buildapi.SourceResolver) error {
-	e.enqueueAfter(sr, 1*time.Minute)
+	now := time.Now()
+	interval := sr.PollingInterval(e.delay)
+
+	if next := sr.Status.NextPollTime; next != nil && sr.Status.LastPollTime != nil && next.After(now) &&
+		next.Sub(sr.Status.LastPollTime.Time) <= maxPollingDelay(interval) {
+		e.enqueueAfter(sr, next.Sub(now))
+		return nil
+	}
+
+	delay := wait.Jitter(interval, pollingJitterFactor)
+	sr.Status.LastPollTime = &metav1.Time{Time: now}
+	sr.Status.NextPollTime = &metav1.Time{Time: now.Add(delay)}
+
+	e.enqueueAfter(sr, delay)
 	return nil
 }
+
+func maxPollingDelay(interval time.Duration) time.Duration {
+	return interval + time.Duration(float64(interval)*pollingJitterFactor)
+}
@@ -17,14 +17,109 @@ func TestEnqueueAfter(t *testing.T) {
 		},
 	}
 
+	var enqueuedAfter time.Duration
 	enqueuer := &workQueueEnqueuer{
-		delay: time.Minute,
+		delay: 5 * time.Minute,
 		enqueueAfter: func(obj interface{}, after time.Duration) {
 			require.Equal(t, sourceResolver, obj)
-			require.Equal(t, after, time.Minute)
+			enqueuedAfter = after
 		},
 	}
 
 	err := enqueuer.Enqueue(sourceResolver)
 	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, int64(enqueuedAfter), int64(5*time.Minute))
+	require.LessOrEqual(t, int64(enqueuedAfter), int64(5*time.Minute+30*time.Second))
+
+	require.NotNil(t, sourceResolver.Status.LastPollTime)
+	require.NotNil(t, sourceResolver.Status.NextPollTime)
+	require.Equal(t, enqueuedAfter, sourceResolver.Status.NextPollTime.Sub(sourceResolver.Status.LastPollTime.Time))
+}
+
+func TestEnqueueAfterUsesPollingInterval(t *testing.T) {
+	sourceResolver := &buildapi.SourceResolver{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "name",
+		},
+		Spec: buildapi.SourceResolverSpec{
+			Polling: &buildapi.SourcePollingConfig{
+				Interval: &v1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+
+	var enqueuedAfter time.Duration
+	enqueuer := &workQueueEnqueuer{
+		delay: time.Minute,
+		enqueueAfter: func(obj interface{}, after time.Duration) {
+			enqueuedAfter = after
+		},
+	}
+
+	err := enqueuer.Enqueue(sourceResolver)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, int64(enqueuedAfter), int64(time.Hour))
+	require.LessOrEqual(t, int64(enqueuedAfter), int64(time.Hour+6*time.Minute))
+}
+
+func TestEnqueueAfterKeepsScheduledPoll(t *testing.T) {
+	lastPollTime := v1.NewTime(time.Now().Add(-2 * time.Minute))
+	nextPollTime := v1.NewTime(lastPollTime.Add(5 * time.Minute))
+
+	sourceResolver := &buildapi.SourceResolver{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "name",
+		},
+		Status: buildapi.SourceResolverStatus{
+			LastPollTime: &lastPollTime,
+			NextPollTime: &nextPollTime,
+		},
+	}
+
+	var enqueuedAfter time.Duration
+	enqueuer := &workQueueEnqueuer{
+		delay: 5 * time.Minute,
+		enqueueAfter: func(obj interface{}, after time.Duration) {
+			enqueuedAfter = after
+		},
+	}
+
+	err := enqueuer.Enqueue(sourceResolver)
+	require.NoError(t, err)
+
+	require.LessOrEqual(t, int64(enqueuedAfter), int64(3*time.Minute))
+	require.Greater(t, int64(enqueuedAfter), int64(2*time.Minute))
+	require.Equal(t, &lastPollTime, sourceResolver.Status.LastPollTime)
+	require.Equal(t, &nextPollTime, sourceResolver.Status.NextPollTime)
+}
+
+func TestEnqueueAfterReschedulesWhenIntervalShrinks(t *testing.T) {
+	lastPollTime := v1.NewTime(time.Now().Add(-time.Minute))
+	nextPollTime := v1.NewTime(lastPollTime.Add(time.Hour))
+
+	sourceResolver := &buildapi.SourceResolver{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "name",
+		},
+		Status: buildapi.SourceResolverStatus{
+			LastPollTime: &lastPollTime,
+			NextPollTime: &nextPollTime,
+		},
+	}
+
+	var enqueuedAfter time.Duration
+	enqueuer := &workQueueEnqueuer{
+		delay: 5 * time.Minute,
+		enqueueAfter: func(obj interface{}, after time.Duration) {
+			enqueuedAfter = after
+		},
+	}
+
+	err := enqueuer.Enqueue(sourceResolver)
+	require.NoError(t, err)
+
+	require.LessOrEqual(t, int64(enqueuedAfter), int64(5*time.Minute+30*time.Second))
+	require.True(t, sourceResolver.Status.LastPollTime.After(lastPollTime.Time))
 }
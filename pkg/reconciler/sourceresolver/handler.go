@@ -0,0 +1,25 @@
+package sourceresolver
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// sourceResolverChanged ignores status updates such as the resolved source and
+// poll times written by this controller after every poll. The next poll is
+// already scheduled so reconciling them would resolve the source twice.
+func sourceResolverChanged(old, new interface{}) bool {
+	oldSourceResolver, ok := old.(*buildapi.SourceResolver)
+	if !ok {
+		return true
+	}
+	newSourceResolver, ok := new.(*buildapi.SourceResolver)
+	if !ok {
+		return true
+	}
+
+	return oldSourceResolver.Generation != newSourceResolver.Generation ||
+		!equality.Semantic.DeepEqual(oldSourceResolver.Labels, newSourceResolver.Labels) ||
+		!equality.Semantic.DeepEqual(oldSourceResolver.Annotations, newSourceResolver.Annotations)
+}
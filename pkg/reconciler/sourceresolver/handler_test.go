@@ -0,0 +1,44 @@
+package sourceresolver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestSourceResolverChanged(t *testing.T) {
+	sourceResolver := &buildapi.SourceResolver{
+		ObjectMeta: v1.ObjectMeta{
+			Name:       "name",
+			Generation: 1,
+		},
+		Spec: buildapi.SourceResolverSpec{
+			Source: corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{URL: "https://example.com/repo", Revision: "main"},
+			},
+		},
+	}
+
+	polled := sourceResolver.DeepCopy()
+	pollTime := v1.NewTime(time.Now())
+	polled.Status.LastPollTime = &pollTime
+	polled.Status.NextPollTime = &pollTime
+	polled.Status.Source = corev1alpha1.ResolvedSourceConfig{
+		Git: &corev1alpha1.ResolvedGitSource{URL: "https://example.com/repo", Revision: "abc"},
+	}
+	require.False(t, sourceResolverChanged(sourceResolver, polled))
+
+	updated := sourceResolver.DeepCopy()
+	updated.Generation = 2
+	updated.Spec.Source.Git.Revision = "other-branch"
+	require.True(t, sourceResolverChanged(sourceResolver, updated))
+
+	relabeled := sourceResolver.DeepCopy()
+	relabeled.Labels = map[string]string{"some": "label"}
+	require.True(t, sourceResolverChanged(sourceResolver, relabeled))
+}
@@ -24,6 +24,9 @@ const (
 )
 
 //go:generate counterfeiter . Resolver
+
+// Resolver resolves the source of a SourceResolver. A resolver that cannot resolve the latest
+// version of a source may return a fallback resolution with a *buildapi.ResolveFallbackError.
 type Resolver interface {
 	Resolve(context.Context, *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error)
 	CanResolve(*buildapi.SourceResolver) bool
@@ -32,14 +35,10 @@ type Resolver interface {
 func NewController(
 	opt reconciler.Options,
 	sourceResolverInformer buildinformers.SourceResolverInformer,
-	gitResolver Resolver,
-	blobResolver Resolver,
-	registryResolver Resolver,
+	resolvers ...Resolver,
 ) *controller.Impl {
 	c := &Reconciler{
-		GitResolver:          gitResolver,
-		BlobResolver:         blobResolver,
-		RegistryResolver:     registryResolver,
+		Resolvers:            resolvers,
 		Client:               opt.Client,
 		SourceResolverLister: sourceResolverInformer.Lister(),
 	}
@@ -51,7 +50,7 @@ func NewController(
 		delay:        opt.SourcePollingFrequency,
 	}
 
-	sourceResolverInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
+	sourceResolverInformer.Informer().AddEventHandler(reconciler.UpdateFilteringHandler(impl.Enqueue, sourceResolverChanged))
 
 	return impl
 }
@@ -62,9 +61,8 @@ type Enqueuer interface {
 }
 
 type Reconciler struct {
-	GitResolver          Resolver
-	BlobResolver         Resolver
-	RegistryResolver     Resolver
+	// Resolvers are tried in order and the first resolver that can resolve the source is used.
+	Resolvers            []Resolver
 	Enqueuer             Enqueuer
 	Client               versioned.Interface
 	SourceResolverLister buildlisters.SourceResolverLister
@@ -91,12 +89,15 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	resolvedSource, err := sourceReconciler.Resolve(ctx, sourceResolver)
-	if err != nil {
+	var fallbackErr *buildapi.ResolveFallbackError
+	if errors.As(err, &fallbackErr) {
+		sourceResolver.ResolvedFallbackSource(resolvedSource, fallbackErr)
+	} else if err != nil {
 		return err
+	} else {
+		sourceResolver.ResolvedSource(resolvedSource)
 	}
 
-	sourceResolver.ResolvedSource(resolvedSource)
-
 	if sourceResolver.PollingReady() {
 		err := c.Enqueuer.Enqueue(sourceResolver)
 		if err != nil {
@@ -109,12 +110,10 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 }
 
 func (c *Reconciler) sourceReconciler(sourceResolver *buildapi.SourceResolver) (Resolver, error) {
-	if c.GitResolver.CanResolve(sourceResolver) {
-		return c.GitResolver, nil
-	} else if c.BlobResolver.CanResolve(sourceResolver) {
-		return c.BlobResolver, nil
-	} else if c.RegistryResolver.CanResolve(sourceResolver) {
-		return c.RegistryResolver, nil
+	for _, resolver := range c.Resolvers {
+		if resolver.CanResolve(sourceResolver) {
+			return resolver, nil
+		}
 	}
 	return nil, errors.New("invalid source type")
 }
@@ -1,6 +1,7 @@
 package sourceresolver_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/sclevine/spec"
@@ -38,6 +39,7 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 	fakeGitResolver := &sourceresolverfakes.FakeResolver{}
 	fakeBlobResolver := &sourceresolverfakes.FakeResolver{}
 	fakeRegistryResolver := &sourceresolverfakes.FakeResolver{}
+	fakeS3Resolver := &sourceresolverfakes.FakeResolver{}
 	fakeEnqueuer := &sourceresolverfakes.FakeEnqueuer{}
 
 	rt := testhelpers.ReconcilerTester(t,
@@ -52,9 +54,7 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 			eventList := rtesting.EventList{Recorder: eventRecorder}
 
 			r := &sourceresolver.Reconciler{
-				GitResolver:          fakeGitResolver,
-				BlobResolver:         fakeBlobResolver,
-				RegistryResolver:     fakeRegistryResolver,
+				Resolvers:            []sourceresolver.Resolver{fakeGitResolver, fakeBlobResolver, fakeRegistryResolver, fakeS3Resolver},
 				Enqueuer:             fakeEnqueuer,
 				Client:               fakeClient,
 				SourceResolverLister: listers.GetSourceResolverLister(),
@@ -248,6 +248,59 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			when("polling is disabled", func() {
+				resolvedSource := corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      "https://example.com/something",
+						Revision: "abcdef",
+						Type:     corev1alpha1.Branch,
+					},
+				}
+
+				fakeGitResolver.ResolveReturns(resolvedSource, nil)
+				fakeGitResolver.CanResolveReturns(true)
+
+				it("reconciles to ready and does not enqueue a poll", func() {
+					sourceResolver := sourceResolver.DeepCopy()
+					sourceResolver.Spec.Polling = &buildapi.SourcePollingConfig{Disabled: true}
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							sourceResolver,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.SourceResolver{
+									ObjectMeta: sourceResolver.ObjectMeta,
+									Spec:       sourceResolver.Spec,
+									Status: buildapi.SourceResolverStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:   corev1alpha1.ConditionReady,
+													Status: corev1.ConditionTrue,
+												},
+												{
+													Type:   buildapi.ActivePolling,
+													Status: corev1.ConditionFalse,
+													Reason: buildapi.PollingDisabled,
+												},
+											},
+										},
+										Source: resolvedSource,
+									},
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 0, fakeEnqueuer.EnqueueCallCount())
+				})
+			})
+
 			when("git resolves to unknown", func() {
 				resolvedSource := corev1alpha1.ResolvedSourceConfig{
 					Git: &corev1alpha1.ResolvedGitSource{
@@ -387,6 +440,54 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 					},
 				})
 			})
+			it("reconciles to ready with the fallback source when the latest version cannot be resolved", func() {
+				fallbackSource := corev1alpha1.ResolvedSourceConfig{
+					Blob: &corev1alpha1.ResolvedBlobSource{
+						URL:     "https://some-blobstore.example.com/some-blob",
+						Version: `etag:"some-etag"`,
+					},
+				}
+				fakeBlobResolver.ResolveReturns(fallbackSource, &buildapi.ResolveFallbackError{Err: errors.New("some-error")})
+				defer fakeBlobResolver.ResolveReturns(resolvedSource, nil)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						sourceResolver,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.SourceResolver{
+								ObjectMeta: sourceResolver.ObjectMeta,
+								Spec:       sourceResolver.Spec,
+								Status: buildapi.SourceResolverStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:   buildapi.ActivePolling,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:    buildapi.LatestVersionResolved,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.ResolveFailed,
+												Message: "some-error",
+											},
+										},
+									},
+									Source: fallbackSource,
+								},
+							},
+						},
+					},
+				})
+			})
 		})
 
 		when("a registry based source config", func() {
@@ -453,6 +554,81 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("a s3 based source config", func() {
+			sourceResolver := &buildapi.SourceResolver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       sourceResolverName,
+					Namespace:  namespace,
+					Generation: originalGeneration,
+				},
+				Spec: buildapi.SourceResolverSpec{
+					ServiceAccountName: serviceAccount,
+					Source: corev1alpha1.SourceConfig{
+						S3: &corev1alpha1.S3{
+							Bucket:   "some-bucket",
+							Key:      "some-key.tar.gz",
+							Endpoint: "https://minio.example.com",
+						},
+					},
+				},
+			}
+
+			resolvedSource := corev1alpha1.ResolvedSourceConfig{
+				S3: &corev1alpha1.ResolvedS3Source{
+					Bucket:   "some-bucket",
+					Key:      "some-key.tar.gz",
+					Endpoint: "https://minio.example.com",
+					Object:   "some-key.tar.gz",
+					Version:  "version-id:some-version-id",
+				},
+			}
+
+			fakeS3Resolver.ResolveReturns(resolvedSource, nil)
+			fakeS3Resolver.CanResolveReturns(true)
+
+			it("reconciles to ready and active polling", func() {
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						sourceResolver,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.SourceResolver{
+								ObjectMeta: sourceResolver.ObjectMeta,
+								Spec:       sourceResolver.Spec,
+								Status: buildapi.SourceResolverStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:   buildapi.ActivePolling,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									Source: corev1alpha1.ResolvedSourceConfig{
+										S3: &corev1alpha1.ResolvedS3Source{
+											Bucket:   "some-bucket",
+											Key:      "some-key.tar.gz",
+											Endpoint: "https://minio.example.com",
+											Object:   "some-key.tar.gz",
+											Version:  "version-id:some-version-id",
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+		})
 	})
 }
 
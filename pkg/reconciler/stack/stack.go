@@ -0,0 +1,104 @@
+package stack
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterstack"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+const (
+	ReconcilerName = "NamespacedStacks"
+	Kind           = "Stack"
+)
+
+func NewController(
+	opt reconciler.Options,
+	keychainFactory registry.KeychainFactory,
+	stackInformer buildinformers.StackInformer,
+	stackReader clusterstack.ClusterStackReader) *controller.Impl {
+	c := &Reconciler{
+		Client:          opt.Client,
+		StackLister:     stackInformer.Lister(),
+		StackReader:     stackReader,
+		KeychainFactory: keychainFactory,
+	}
+	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	stackInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
+	return impl
+}
+
+type Reconciler struct {
+	Client          versioned.Interface
+	StackLister     buildlisters.StackLister
+	StackReader     clusterstack.ClusterStackReader
+	KeychainFactory registry.KeychainFactory
+}
+
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, stackName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	stack, err := c.StackLister.Stacks(namespace).Get(stackName)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	stack = stack.DeepCopy()
+
+	stack, err = c.reconcileStackStatus(ctx, stack)
+
+	updateErr := c.updateStackStatus(ctx, stack)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	// a failed read is retried as it may be a transient registry error
+	return err
+}
+
+func (c *Reconciler) reconcileStackStatus(ctx context.Context, stack *buildapi.Stack) (*buildapi.Stack, error) {
+	keychain, err := c.KeychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{
+		ServiceAccount: stack.Spec.ServiceAccountName,
+		Namespace:      stack.Namespace,
+	})
+	if err != nil {
+		stack.Status = clusterstack.ReadStatus(stack.Status, stack.Generation, buildapi.ResolvedClusterStack{}, err)
+		return stack, err
+	}
+
+	resolvedStack, err := c.StackReader.Read(keychain, stack.Spec.ClusterStackSpec())
+	stack.Status = clusterstack.ReadStatus(stack.Status, stack.Generation, resolvedStack, err)
+	return stack, err
+}
+
+func (c *Reconciler) updateStackStatus(ctx context.Context, desired *buildapi.Stack) error {
+	desired.Status.ObservedGeneration = desired.Generation
+
+	original, err := c.StackLister.Stacks(desired.Namespace).Get(desired.Name)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(desired.Status, original.Status) {
+		return nil
+	}
+
+	_, err = c.Client.KpackV1alpha2().Stacks(desired.Namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,234 @@
+package stack_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterstack/clusterstackfakes"
+	"github.com/pivotal/kpack/pkg/reconciler/stack"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestStackReconciler(t *testing.T) {
+	spec.Run(t, "Namespaced Stack Reconciler", testStackReconciler)
+}
+
+func testStackReconciler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		namespace                = "some-namespace"
+		stackName                = "some-stack"
+		stackKey                 = namespace + "/" + stackName
+		serviceAccountName       = "some-service-account"
+		initialGeneration  int64 = 1
+	)
+
+	var (
+		fakeStackReader     = &clusterstackfakes.FakeClusterStackReader{}
+		fakeKeyChainFactory = &registryfakes.FakeKeychainFactory{}
+		keychain            = &registryfakes.FakeKeychain{Name: "service-account"}
+	)
+
+	it.Before(func() {
+		fakeKeyChainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount: serviceAccountName,
+			Namespace:      namespace,
+		}, keychain)
+	})
+
+	testStack := &buildapi.Stack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       stackName,
+			Namespace:  namespace,
+			Generation: initialGeneration,
+		},
+		Spec: buildapi.StackSpec{
+			Id: "some.stack.id",
+			BuildImage: buildapi.ClusterStackSpecImage{
+				Image: "some-registry.io/build-image",
+			},
+			RunImage: buildapi.ClusterStackSpecImage{
+				Image: "some-registry.io/run-image",
+			},
+			ServiceAccountName: serviceAccountName,
+		},
+	}
+
+	rt := testhelpers.ReconcilerTester(t,
+		func(t *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
+			listers := testhelpers.NewListers(row.Objects)
+			fakeClient := fake.NewSimpleClientset(listers.BuildServiceObjects()...)
+			r := &stack.Reconciler{
+				Client:          fakeClient,
+				StackLister:     listers.GetStackLister(),
+				StackReader:     fakeStackReader,
+				KeychainFactory: fakeKeyChainFactory,
+			}
+			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
+		})
+
+	when("#Reconcile", func() {
+		it("saves metadata read with the service account keychain to the status", func() {
+			resolvedStack := buildapi.ResolvedClusterStack{
+				Id: "some.stack.id",
+				BuildImage: buildapi.ClusterStackStatusImage{
+					LatestImage: "some-registry.io/build-image@sha245:123",
+				},
+				RunImage: buildapi.ClusterStackStatusImage{
+					LatestImage: "some-registry.io/run-image@sha245:123",
+				},
+			}
+			fakeStackReader.ReadReturns(resolvedStack, nil)
+
+			rt.Test(rtesting.TableRow{
+				Key:     stackKey,
+				Objects: []runtime.Object{testStack},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Stack{
+							ObjectMeta: testStack.ObjectMeta,
+							Spec:       testStack.Spec,
+							Status: buildapi.ClusterStackStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								ResolvedClusterStack: resolvedStack,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, 1, fakeStackReader.ReadCallCount())
+			actualKeychain, stackSpec := fakeStackReader.ReadArgsForCall(0)
+			assert.Equal(t, keychain, actualKeychain)
+			assert.Equal(t, buildapi.ClusterStackSpec{
+				Id:         "some.stack.id",
+				BuildImage: testStack.Spec.BuildImage,
+				RunImage:   testStack.Spec.RunImage,
+			}, stackSpec)
+		})
+
+		it("sets the status to Ready False if error reading the stack", func() {
+			fakeStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, errors.New("invalid mixins on run image"))
+
+			rt.Test(rtesting.TableRow{
+				Key:     stackKey,
+				Objects: []runtime.Object{testStack},
+				WantErr: true,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Stack{
+							ObjectMeta: testStack.ObjectMeta,
+							Spec:       testStack.Spec,
+							Status: buildapi.ClusterStackStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionFalse,
+											Message: "invalid mixins on run image",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		})
+
+		it("keeps the resolved images when a read of the unchanged stack fails", func() {
+			fakeStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, errors.New("registry unavailable"))
+
+			resolvedStack := buildapi.ResolvedClusterStack{
+				BuildImage: buildapi.ClusterStackStatusImage{
+					LatestImage: "some-registry.io/build-image@sha245:123",
+				},
+				RunImage: buildapi.ClusterStackStatusImage{
+					LatestImage: "some-registry.io/run-image@sha245:123",
+				},
+			}
+
+			readStack := testStack.DeepCopy()
+			readStack.Status = buildapi.ClusterStackStatus{
+				Status: corev1alpha1.Status{
+					ObservedGeneration: 1,
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:   corev1alpha1.ConditionReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				ResolvedClusterStack: resolvedStack,
+			}
+
+			rt.Test(rtesting.TableRow{
+				Key:     stackKey,
+				Objects: []runtime.Object{readStack},
+				WantErr: true,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Stack{
+							ObjectMeta: readStack.ObjectMeta,
+							Spec:       readStack.Spec,
+							Status: buildapi.ClusterStackStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionFalse,
+											Message: "registry unavailable",
+										},
+									},
+								},
+								ResolvedClusterStack: resolvedStack,
+							},
+						},
+					},
+				},
+			})
+		})
+
+		it("retries a failed read", func() {
+			fakeStackReader.ReadReturns(buildapi.ResolvedClusterStack{}, errors.New("registry unavailable"))
+
+			listers := testhelpers.NewListers([]runtime.Object{testStack})
+			r := &stack.Reconciler{
+				Client:          fake.NewSimpleClientset(listers.BuildServiceObjects()...),
+				StackLister:     listers.GetStackLister(),
+				StackReader:     fakeStackReader,
+				KeychainFactory: fakeKeyChainFactory,
+			}
+
+			err := r.Reconcile(context.Background(), stackKey)
+			assert.EqualError(t, err, "registry unavailable")
+			assert.False(t, controller.IsPermanentError(err))
+		})
+	})
+}
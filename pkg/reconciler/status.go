@@ -0,0 +1,17 @@
+package reconciler
+
+import (
+	"github.com/pkg/errors"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/cnb"
+)
+
+// KeepLastRead reports whether a store or stack keeps what it last read when a
+// read fails. It is kept while a read of the unchanged spec is retried so that
+// builders can still be created, but not once the spec changed or an image
+// failed verification.
+func KeepLastRead(status corev1alpha1.Status, generation int64, err error) bool {
+	var verificationErr *cnb.VerificationError
+	return status.ObservedGeneration == generation && !errors.As(err, &verificationErr)
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterstore"
+	"github.com/pivotal/kpack/pkg/registry"
+)
+
+const (
+	ReconcilerName = "Stores"
+	Kind           = "Store"
+)
+
+func NewController(
+	opt reconciler.Options,
+	keychainFactory registry.KeychainFactory,
+	storeInformer buildinformers.StoreInformer,
+	storeReader clusterstore.StoreReader) *controller.Impl {
+	c := &Reconciler{
+		Client:          opt.Client,
+		StoreLister:     storeInformer.Lister(),
+		StoreReader:     storeReader,
+		KeychainFactory: keychainFactory,
+	}
+	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	storeInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
+	return impl
+}
+
+type Reconciler struct {
+	Client          versioned.Interface
+	StoreReader     clusterstore.StoreReader
+	StoreLister     buildlisters.StoreLister
+	KeychainFactory registry.KeychainFactory
+}
+
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, storeName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	store, err := c.StoreLister.Stores(namespace).Get(storeName)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	store = store.DeepCopy()
+
+	store, err = c.reconcileStoreStatus(ctx, store)
+
+	updateErr := c.updateStoreStatus(ctx, store)
+	if updateErr != nil {
+		return updateErr
+	}
+
+	// a failed read is retried as it may be a transient registry error
+	return err
+}
+
+func (c *Reconciler) updateStoreStatus(ctx context.Context, desired *buildapi.Store) error {
+	desired.Status.ObservedGeneration = desired.Generation
+
+	original, err := c.StoreLister.Stores(desired.Namespace).Get(desired.Name)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(desired.Status, original.Status) {
+		return nil
+	}
+
+	_, err = c.Client.KpackV1alpha2().Stores(desired.Namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Reconciler) reconcileStoreStatus(ctx context.Context, store *buildapi.Store) (*buildapi.Store, error) {
+	keychain, err := c.KeychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{
+		ServiceAccount: store.Spec.ServiceAccountName,
+		Namespace:      store.Namespace,
+	})
+	if err != nil {
+		store.Status = clusterstore.ReadStatus(store.Status, store.Generation, nil, err)
+		return store, err
+	}
+
+	buildpacks, err := c.StoreReader.Read(keychain, store.Spec.Sources, store.Spec.Verification)
+	store.Status = clusterstore.ReadStatus(store.Status, store.Generation, buildpacks, err)
+	return store, err
+}
@@ -0,0 +1,231 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/reconciler/clusterstore/clusterstorefakes"
+	"github.com/pivotal/kpack/pkg/reconciler/store"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestStoreReconciler(t *testing.T) {
+	spec.Run(t, "Store Reconciler", testStoreReconciler)
+}
+
+func testStoreReconciler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		namespace                = "some-namespace"
+		storeName                = "some-store"
+		storeKey                 = namespace + "/" + storeName
+		serviceAccountName       = "some-service-account"
+		initialGeneration  int64 = 1
+	)
+	var (
+		fakeStoreReader     = &clusterstorefakes.FakeStoreReader{}
+		fakeKeyChainFactory = &registryfakes.FakeKeychainFactory{}
+		keychain            = &registryfakes.FakeKeychain{Name: "service-account"}
+	)
+
+	it.Before(func() {
+		fakeKeyChainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount: serviceAccountName,
+			Namespace:      namespace,
+		}, keychain)
+	})
+
+	rt := testhelpers.ReconcilerTester(t,
+		func(t *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
+			listers := testhelpers.NewListers(row.Objects)
+
+			fakeClient := fake.NewSimpleClientset(listers.BuildServiceObjects()...)
+
+			r := &store.Reconciler{
+				Client:          fakeClient,
+				StoreReader:     fakeStoreReader,
+				StoreLister:     listers.GetStoreLister(),
+				KeychainFactory: fakeKeyChainFactory,
+			}
+			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: record.NewFakeRecorder(10)}
+		})
+
+	testStore := &buildapi.Store{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       storeName,
+			Namespace:  namespace,
+			Generation: initialGeneration,
+		},
+		Spec: buildapi.StoreSpec{
+			Sources: []corev1alpha1.StoreImage{
+				{
+					Image: "some.registry/some-image-1",
+				},
+			},
+			ServiceAccountName: serviceAccountName,
+		},
+	}
+
+	when("#Reconcile", func() {
+		readBuildpacks := []corev1alpha1.StoreBuildpack{
+			{
+				BuildpackInfo: corev1alpha1.BuildpackInfo{
+					Id:      "paketo-buildpacks/node-engine",
+					Version: "0.0.116",
+				},
+				DiffId: "sha256:d57937f5ccb6f524afa02dd95224e1914c94a02483d37b07aa668e560dcb3bf4",
+				StoreImage: corev1alpha1.StoreImage{
+					Image: "some.registry/some-image-1",
+				},
+			},
+		}
+
+		it("saves metadata read with the service account keychain to the status", func() {
+			fakeStoreReader.ReadReturns(readBuildpacks, nil)
+
+			rt.Test(rtesting.TableRow{
+				Key: storeKey,
+				Objects: []runtime.Object{
+					testStore,
+				},
+				WantErr: false,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Store{
+							ObjectMeta: testStore.ObjectMeta,
+							Spec:       testStore.Spec,
+							Status: buildapi.ClusterStoreStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								Buildpacks: readBuildpacks,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, 1, fakeStoreReader.ReadCallCount())
+			actualKeychain, sources, policy := fakeStoreReader.ReadArgsForCall(0)
+			assert.Equal(t, keychain, actualKeychain)
+			assert.Equal(t, testStore.Spec.Sources, sources)
+			assert.Nil(t, policy)
+		})
+
+		it("sets the status to Ready False if error reading buildpacks", func() {
+			fakeStoreReader.ReadReturns(nil, fmt.Errorf("no buildpacks left"))
+
+			rt.Test(rtesting.TableRow{
+				Key: storeKey,
+				Objects: []runtime.Object{
+					testStore,
+				},
+				WantErr: true,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Store{
+							ObjectMeta: testStore.ObjectMeta,
+							Spec:       testStore.Spec,
+							Status: buildapi.ClusterStoreStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Message: "no buildpacks left",
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionFalse,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		})
+
+		it("keeps the last read buildpacks when a read of the unchanged store fails", func() {
+			fakeStoreReader.ReadReturns(nil, fmt.Errorf("registry unavailable"))
+
+			readStore := testStore.DeepCopy()
+			readStore.Status = buildapi.ClusterStoreStatus{
+				Status: corev1alpha1.Status{
+					ObservedGeneration: 1,
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:   corev1alpha1.ConditionReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+				Buildpacks: readBuildpacks,
+			}
+
+			rt.Test(rtesting.TableRow{
+				Key: storeKey,
+				Objects: []runtime.Object{
+					readStore,
+				},
+				WantErr: true,
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: &buildapi.Store{
+							ObjectMeta: readStore.ObjectMeta,
+							Spec:       readStore.Spec,
+							Status: buildapi.ClusterStoreStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: 1,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Message: "registry unavailable",
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionFalse,
+										},
+									},
+								},
+								Buildpacks: readBuildpacks,
+							},
+						},
+					},
+				},
+			})
+		})
+
+		it("retries a failed read", func() {
+			fakeStoreReader.ReadReturns(nil, fmt.Errorf("registry unavailable"))
+
+			listers := testhelpers.NewListers([]runtime.Object{testStore})
+			r := &store.Reconciler{
+				Client:          fake.NewSimpleClientset(listers.BuildServiceObjects()...),
+				StoreReader:     fakeStoreReader,
+				StoreLister:     listers.GetStoreLister(),
+				KeychainFactory: fakeKeyChainFactory,
+			}
+
+			err := r.Reconcile(context.Background(), storeKey)
+			assert.EqualError(t, err, "registry unavailable")
+			assert.False(t, controller.IsPermanentError(err))
+		})
+	})
+}
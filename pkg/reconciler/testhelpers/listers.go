@@ -51,6 +51,15 @@ func (l *Listers) GetKubeObjects() []runtime.Object {
 	return l.sorter.ObjectsForSchemeFunc(fakekubeclientset.AddToScheme)
 }
 
+// GetBuildIndexer returns an indexer of the builds with the indexers of an informer
+func (l *Listers) GetBuildIndexer(indexers cache.Indexers) cache.Indexer {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, indexers)
+	for _, obj := range l.indexerFor(&buildapi.Build{}).List() {
+		_ = indexer.Add(obj)
+	}
+	return indexer
+}
+
 func (l *Listers) GetImageLister() buildlisters.ImageLister {
 	return buildlisters.NewImageLister(l.indexerFor(&buildapi.Image{}))
 }
@@ -75,6 +84,14 @@ func (l *Listers) GetClusterStackLister() buildlisters.ClusterStackLister {
 	return buildlisters.NewClusterStackLister(l.indexerFor(&buildapi.ClusterStack{}))
 }
 
+func (l *Listers) GetStoreLister() buildlisters.StoreLister {
+	return buildlisters.NewStoreLister(l.indexerFor(&buildapi.Store{}))
+}
+
+func (l *Listers) GetStackLister() buildlisters.StackLister {
+	return buildlisters.NewStackLister(l.indexerFor(&buildapi.Stack{}))
+}
+
 func (l *Listers) GetSourceResolverLister() buildlisters.SourceResolverLister {
 	return buildlisters.NewSourceResolverLister(l.indexerFor(&buildapi.SourceResolver{}))
 }
@@ -83,6 +100,10 @@ func (l *Listers) GetPersistentVolumeClaimLister() corev1listers.PersistentVolum
 	return corev1listers.NewPersistentVolumeClaimLister(l.indexerFor(&corev1.PersistentVolumeClaim{}))
 }
 
+func (l *Listers) GetNamespaceLister() corev1listers.NamespaceLister {
+	return corev1listers.NewNamespaceLister(l.indexerFor(&corev1.Namespace{}))
+}
+
 func (l *Listers) GetPodLister() corev1listers.PodLister {
 	return corev1listers.NewPodLister(l.indexerFor(&corev1.Pod{}))
 }
@@ -2,22 +2,56 @@ package registry
 
 import (
 	"context"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
 type Resolver struct {
+	KeychainFactory KeychainFactory
+	ImageFetcher    ImageClient
 }
 
-func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
-	return corev1alpha1.ResolvedSourceConfig{
-		Registry: &corev1alpha1.ResolvedRegistrySource{
-			Image:            sourceResolver.Spec.Source.Registry.Image,
-			ImagePullSecrets: sourceResolver.Spec.Source.Registry.ImagePullSecrets,
-			SubPath:          sourceResolver.Spec.Source.SubPath,
-		},
-	}, nil
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	registry := sourceResolver.Spec.Source.Registry
+
+	keychain, err := r.KeychainFactory.KeychainForSecretRef(ctx, SecretRef{
+		ServiceAccount:   sourceResolver.Spec.ServiceAccountName,
+		Namespace:        sourceResolver.Namespace,
+		ImagePullSecrets: registry.ImagePullSecrets,
+	})
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, errors.Wrapf(err, "unable to create keychain for %s", registry.Image)
+	}
+
+	resolved := &corev1alpha1.ResolvedRegistrySource{
+		Image:            registry.Image,
+		ImagePullSecrets: registry.ImagePullSecrets,
+		SubPath:          sourceResolver.Spec.Source.SubPath,
+	}
+
+	// images the controller cannot fetch are built from the previously resolved digest, or
+	// from the unresolved image if it was never resolved, and the error is reported on the
+	// source resolver
+	_, identifier, err := r.ImageFetcher.Fetch(keychain, registry.Image)
+	if err != nil {
+		if previous := sourceResolver.Status.Source.Registry; previous != nil && previous.Image == registry.Image {
+			resolved.Digest = previous.Digest
+		}
+		return corev1alpha1.ResolvedSourceConfig{Registry: resolved}, &buildapi.ResolveFallbackError{
+			Err: errors.Wrapf(err, "unable to resolve %s", registry.Image),
+		}
+	}
+
+	resolved.Digest = identifier
+	if i := strings.LastIndex(identifier, "@"); i >= 0 {
+		resolved.Digest = identifier[i+1:]
+	}
+
+	return corev1alpha1.ResolvedSourceConfig{Registry: resolved}, nil
 }
 
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
@@ -0,0 +1,146 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestRegistryResolver(t *testing.T) {
+	spec.Run(t, "testRegistryResolver", testRegistryResolver)
+}
+
+func testRegistryResolver(t *testing.T, when spec.G, it spec.S) {
+	const (
+		namespace = "some-namespace"
+		image     = "registry.example/some-source:latest"
+	)
+
+	var (
+		keychain        = &registryfakes.FakeKeychain{}
+		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		client          = registryfakes.NewFakeClient()
+		resolver        = &registry.Resolver{
+			KeychainFactory: keychainFactory,
+			ImageFetcher:    client,
+		}
+		imagePullSecrets = []corev1.LocalObjectReference{{Name: "some-secret"}}
+		sourceResolver   = &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-source-resolver",
+				Namespace: namespace,
+			},
+			Spec: buildapi.SourceResolverSpec{
+				ServiceAccountName: "some-service-account",
+				Source: corev1alpha1.SourceConfig{
+					Registry: &corev1alpha1.Registry{
+						Image:            image,
+						ImagePullSecrets: imagePullSecrets,
+					},
+					SubPath: "some-sub-path",
+				},
+			},
+		}
+	)
+
+	it.Before(func() {
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount:   "some-service-account",
+			Namespace:        namespace,
+			ImagePullSecrets: imagePullSecrets,
+		}, keychain)
+	})
+
+	it("resolves the image to its digest", func() {
+		img, err := random.Image(10, 1)
+		require.NoError(t, err)
+		client.AddImage(image, img, keychain)
+
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.NoError(t, err)
+
+		require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+			Registry: &corev1alpha1.ResolvedRegistrySource{
+				Image:            image,
+				Digest:           digest.String(),
+				ImagePullSecrets: imagePullSecrets,
+				SubPath:          "some-sub-path",
+			},
+		}, resolvedSource)
+		require.True(t, resolvedSource.Registry.IsPollable())
+		require.Equal(t, []corev1.EnvVar{{
+			Name:  "REGISTRY_IMAGE",
+			Value: "registry.example/some-source@" + digest.String(),
+		}}, resolvedSource.Registry.SourceConfig().Registry.BuildEnvVars())
+	})
+
+	it("is not pollable when the image is referenced by digest", func() {
+		img, err := random.Image(10, 1)
+		require.NoError(t, err)
+
+		digest, err := img.Digest()
+		require.NoError(t, err)
+
+		digestImage := "registry.example/some-source@" + digest.String()
+		client.AddImage(digestImage, img, keychain)
+		sourceResolver.Spec.Source.Registry.Image = digestImage
+
+		resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver)
+		require.NoError(t, err)
+
+		require.Equal(t, digest.String(), resolvedSource.Registry.Digest)
+		require.False(t, resolvedSource.Registry.IsPollable())
+		require.Equal(t, digestImage, resolvedSource.Registry.SourceConfig().Registry.BuildEnvVars()[0].Value)
+	})
+
+	when("the image cannot be fetched", func() {
+		it.Before(func() {
+			client.SetFetchError(errors.New("some fetch error"))
+		})
+
+		it("falls back to the unresolved image and reports the error", func() {
+			resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver)
+			var fallbackErr *buildapi.ResolveFallbackError
+			require.True(t, errors.As(err, &fallbackErr))
+			require.EqualError(t, err, "unable to resolve registry.example/some-source:latest: some fetch error")
+
+			require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+				Registry: &corev1alpha1.ResolvedRegistrySource{
+					Image:            image,
+					ImagePullSecrets: imagePullSecrets,
+					SubPath:          "some-sub-path",
+				},
+			}, resolvedSource)
+			require.False(t, resolvedSource.Registry.IsPollable())
+		})
+
+		it("falls back to the previously resolved digest", func() {
+			previousSourceResolver := sourceResolver.DeepCopy()
+			previousSourceResolver.Status.Source.Registry = &corev1alpha1.ResolvedRegistrySource{
+				Image:  image,
+				Digest: "sha256:some-previous-digest",
+			}
+
+			resolvedSource, err := resolver.Resolve(context.Background(), previousSourceResolver)
+			var fallbackErr *buildapi.ResolveFallbackError
+			require.True(t, errors.As(err, &fallbackErr))
+
+			require.Equal(t, "sha256:some-previous-digest", resolvedSource.Registry.Digest)
+			require.True(t, resolvedSource.Registry.IsPollable())
+		})
+	})
+}
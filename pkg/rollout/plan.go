@@ -0,0 +1,253 @@
+package rollout
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// Target is an Image that uses the builder being rolled out
+type Target struct {
+	Image     *buildapi.Image
+	LastBuild *buildapi.Build
+}
+
+// Plan divides the Images affected by a builder update into ordered batches.
+// Canary Images form the first batch and the remaining Images are batched in
+// namespace and name order.
+type Plan struct {
+	policy  buildapi.BuilderRollout
+	image   string
+	batches [][]Target
+}
+
+func NewPlan(policy buildapi.BuilderRollout, builder buildapi.BuilderStatus, targets []Target) (Plan, error) {
+	canarySelector := labels.Nothing()
+	if policy.CanarySelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policy.CanarySelector)
+		if err != nil {
+			return Plan{}, err
+		}
+		canarySelector = selector
+	}
+
+	var canaries, rest []Target
+	for _, t := range targets {
+		if !affected(t, builder) {
+			continue
+		}
+
+		if canarySelector.Matches(labels.Set(t.Image.Labels)) {
+			canaries = append(canaries, t)
+		} else {
+			rest = append(rest, t)
+		}
+	}
+	sortTargets(canaries)
+	sortTargets(rest)
+
+	var batches [][]Target
+	if len(canaries) > 0 {
+		batches = append(batches, canaries)
+	}
+
+	batchSize := int(policy.BatchSize)
+	if batchSize <= 0 {
+		batchSize = len(rest)
+	}
+	for len(rest) > 0 {
+		n := batchSize
+		if n > len(rest) {
+			n = len(rest)
+		}
+		batches = append(batches, rest[:n])
+		rest = rest[n:]
+	}
+
+	return Plan{
+		policy:  policy,
+		image:   builder.LatestImage,
+		batches: batches,
+	}, nil
+}
+
+// Status reports the progress of the rollout and, when the rollout is paused
+// between batches, how long until the next batch may start. While the rollout
+// is in progress the status lists the Images in the batches that have started
+// so that Images can be admitted without planning the rollout again.
+func (p Plan) Status(now time.Time) (buildapi.BuilderRolloutStatus, time.Duration) {
+	status, requeueAfter := p.progress(now)
+	if status.Phase != buildapi.RolloutProgressing && status.Phase != buildapi.RolloutPaused {
+		return status, requeueAfter
+	}
+
+	for _, batch := range p.batches[:status.CurrentBatch] {
+		for _, t := range batch {
+			status.AdmittedImages = append(status.AdmittedImages, key(t.Image))
+		}
+	}
+	return status, requeueAfter
+}
+
+func (p Plan) progress(now time.Time) (buildapi.BuilderRolloutStatus, time.Duration) {
+	status := buildapi.BuilderRolloutStatus{
+		Image:   p.image,
+		Batches: int64(len(p.batches)),
+	}
+
+	for _, batch := range p.batches {
+		for _, t := range batch {
+			status.Images++
+			switch p.state(t) {
+			case corev1.ConditionTrue:
+				status.Updated++
+			case corev1.ConditionFalse:
+				status.Failed++
+			}
+		}
+	}
+
+	if len(p.batches) == 0 {
+		status.Phase = buildapi.RolloutCompleted
+		status.Message = "No images require a rebuild"
+		return status, 0
+	}
+
+	current := 0
+	for ; current < len(p.batches); current++ {
+		if !p.complete(p.batches[current]) {
+			break
+		}
+		if current == len(p.batches)-1 {
+			status.CurrentBatch = int64(len(p.batches))
+			status.Phase = buildapi.RolloutCompleted
+			status.Message = fmt.Sprintf("Rolled out to %d images", status.Images)
+			return status, 0
+		}
+		if p.halted(status) {
+			break
+		}
+
+		if wait := p.pause() - now.Sub(p.finishedAt(p.batches[current])); wait > 0 {
+			status.CurrentBatch = int64(current + 1)
+			status.Phase = buildapi.RolloutPaused
+			status.Message = fmt.Sprintf("Waiting %s before starting batch %d of %d", wait.Round(time.Second), current+2, len(p.batches))
+			return status, wait
+		}
+	}
+	status.CurrentBatch = int64(current + 1)
+
+	if p.halted(status) {
+		status.Phase = buildapi.RolloutHalted
+		status.Message = fmt.Sprintf("Halted after %d of %d builds failed", status.Failed, status.Failed+status.Updated)
+		return status, 0
+	}
+
+	status.Phase = buildapi.RolloutProgressing
+	status.Message = fmt.Sprintf("Rolling out batch %d of %d", current+1, len(p.batches))
+	return status, 0
+}
+
+// Admits reports whether the rollout status of a builder allows the image to
+// be rebuilt with the builder image
+func Admits(status *buildapi.BuilderRolloutStatus, builderImage string, image *buildapi.Image) bool {
+	if status == nil || status.Image != builderImage {
+		return false
+	}
+
+	switch status.Phase {
+	case buildapi.RolloutCompleted:
+		return true
+	case buildapi.RolloutHalted:
+		return false
+	}
+
+	for _, admitted := range status.AdmittedImages {
+		if admitted == key(image) {
+			return true
+		}
+	}
+	return false
+}
+
+// state is True when the image was rebuilt with the builder, False when the
+// rebuild failed and Unknown while it is pending or running
+func (p Plan) state(t Target) corev1.ConditionStatus {
+	if t.LastBuild.BuilderSpec().Image != p.image {
+		return corev1.ConditionUnknown
+	}
+
+	switch {
+	case t.LastBuild.IsSuccess():
+		return corev1.ConditionTrue
+	case t.LastBuild.IsFailure():
+		return corev1.ConditionFalse
+	default:
+		return corev1.ConditionUnknown
+	}
+}
+
+func (p Plan) complete(batch []Target) bool {
+	for _, t := range batch {
+		if p.state(t) == corev1.ConditionUnknown {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Plan) finishedAt(batch []Target) time.Time {
+	var finishedAt time.Time
+	for _, t := range batch {
+		condition := t.LastBuild.Status.GetCondition(corev1alpha1.ConditionSucceeded)
+		if condition != nil && condition.LastTransitionTime.Inner.Time.After(finishedAt) {
+			finishedAt = condition.LastTransitionTime.Inner.Time
+		}
+	}
+	return finishedAt
+}
+
+func (p Plan) halted(status buildapi.BuilderRolloutStatus) bool {
+	if p.policy.MaxFailurePercentage == nil || status.Failed == 0 {
+		return false
+	}
+	return status.Failed*100 > *p.policy.MaxFailurePercentage*(status.Failed+status.Updated)
+}
+
+func (p Plan) pause() time.Duration {
+	if p.policy.PauseBetweenBatches == nil {
+		return 0
+	}
+	return p.policy.PauseBetweenBatches.Duration
+}
+
+// affected images have already been built with the builder or were
+// successfully built with a run image or buildpacks the builder replaced
+func affected(t Target, builder buildapi.BuilderStatus) bool {
+	if t.LastBuild == nil {
+		return false
+	}
+
+	return t.LastBuild.BuilderSpec().Image == builder.LatestImage ||
+		t.LastBuild.OutdatedBy(builder.Stack.RunImage, builder.BuilderMetadata)
+}
+
+func sortTargets(targets []Target) {
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Image.Namespace != targets[j].Image.Namespace {
+			return targets[i].Image.Namespace < targets[j].Image.Namespace
+		}
+		return targets[i].Image.Name < targets[j].Image.Name
+	})
+}
+
+func key(image *buildapi.Image) string {
+	return image.Namespace + "/" + image.Name
+}
@@ -0,0 +1,280 @@
+package rollout_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/rollout"
+)
+
+func TestPlan(t *testing.T) {
+	spec.Run(t, "Plan", testPlan)
+}
+
+func testPlan(t *testing.T, when spec.G, it spec.S) {
+	const (
+		oldBuilderImage = "some/builder@sha256:old"
+		newBuilderImage = "some/builder@sha256:new"
+		oldRunImage     = "some/run:old"
+		newRunImage     = "some/run:new"
+	)
+
+	var (
+		now     = time.Now()
+		policy  = buildapi.BuilderRollout{}
+		targets []rollout.Target
+		builder = buildapi.BuilderStatus{
+			LatestImage: newBuilderImage,
+			Stack: corev1alpha1.BuildStack{
+				RunImage: newRunImage,
+			},
+		}
+	)
+
+	build := func(builderImage, runImage string, status corev1.ConditionStatus, finishedAt time.Time) *buildapi.Build {
+		return &buildapi.Build{
+			Spec: buildapi.BuildSpec{
+				Builder: corev1alpha1.BuildBuilderSpec{Image: builderImage},
+			},
+			Status: buildapi.BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:               corev1alpha1.ConditionSucceeded,
+							Status:             status,
+							LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.NewTime(finishedAt)},
+						},
+					},
+				},
+				Stack: corev1alpha1.BuildStack{RunImage: runImage},
+			},
+		}
+	}
+
+	outdated := func() *buildapi.Build {
+		return build(oldBuilderImage, oldRunImage, corev1.ConditionTrue, now.Add(-time.Hour))
+	}
+
+	rebuilt := func(status corev1.ConditionStatus, finishedAt time.Time) *buildapi.Build {
+		return build(newBuilderImage, newRunImage, status, finishedAt)
+	}
+
+	target := func(name string, lastBuild *buildapi.Build, labels map[string]string) *buildapi.Image {
+		image := &buildapi.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "some-namespace",
+				Labels:    labels,
+			},
+		}
+		targets = append(targets, rollout.Target{Image: image, LastBuild: lastBuild})
+		return image
+	}
+
+	status := func() (buildapi.BuilderRolloutStatus, time.Duration) {
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+		return plan.Status(now)
+	}
+
+	it("excludes images that do not use the replaced run image", func() {
+		target("no-builds", nil, nil)
+		target("up-to-date", build(oldBuilderImage, newRunImage, corev1.ConditionTrue, now), nil)
+		target("failed", build(oldBuilderImage, oldRunImage, corev1.ConditionFalse, now), nil)
+
+		s, requeue := status()
+		assert.Equal(t, buildapi.BuilderRolloutStatus{
+			Image:   newBuilderImage,
+			Phase:   buildapi.RolloutCompleted,
+			Message: "No images require a rebuild",
+		}, s)
+		assert.Equal(t, time.Duration(0), requeue)
+	})
+
+	it("rolls out every image at once without a batch size", func() {
+		first := target("first", outdated(), nil)
+		second := target("second", outdated(), nil)
+
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+
+		s, _ := plan.Status(now)
+		assert.Equal(t, buildapi.BuilderRolloutStatus{
+			Image:        newBuilderImage,
+			Phase:        buildapi.RolloutProgressing,
+			CurrentBatch: 1,
+			Batches:      1,
+			Images:       2,
+			Message:      "Rolling out batch 1 of 1",
+			AdmittedImages: []string{
+				"some-namespace/first",
+				"some-namespace/second",
+			},
+		}, s)
+		assert.True(t, rollout.Admits(&s, newBuilderImage, first))
+		assert.True(t, rollout.Admits(&s, newBuilderImage, second))
+	})
+
+	it("rolls out canaries first and then batches in name order", func() {
+		policy.BatchSize = 2
+		policy.CanarySelector = &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}
+
+		c := target("z-canary", outdated(), map[string]string{"canary": "true"})
+		a := target("a", outdated(), nil)
+		b := target("b", outdated(), nil)
+		d := target("d", outdated(), nil)
+
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+
+		s, _ := plan.Status(now)
+		assert.Equal(t, int64(3), s.Batches)
+		assert.Equal(t, int64(1), s.CurrentBatch)
+		assert.True(t, rollout.Admits(&s, newBuilderImage, c))
+		assert.False(t, rollout.Admits(&s, newBuilderImage, a))
+		assert.False(t, rollout.Admits(&s, newBuilderImage, b))
+		assert.False(t, rollout.Admits(&s, newBuilderImage, d))
+	})
+
+	it("starts the next batch when the previous batch finished", func() {
+		policy.BatchSize = 1
+
+		target("a", rebuilt(corev1.ConditionTrue, now.Add(-time.Minute)), nil)
+		b := target("b", outdated(), nil)
+
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+
+		s, _ := plan.Status(now)
+		assert.Equal(t, buildapi.BuilderRolloutStatus{
+			Image:        newBuilderImage,
+			Phase:        buildapi.RolloutProgressing,
+			CurrentBatch: 2,
+			Batches:      2,
+			Images:       2,
+			Updated:      1,
+			Message:      "Rolling out batch 2 of 2",
+			AdmittedImages: []string{
+				"some-namespace/a",
+				"some-namespace/b",
+			},
+		}, s)
+		assert.True(t, rollout.Admits(&s, newBuilderImage, b))
+	})
+
+	it("waits until the previous batch is complete", func() {
+		policy.BatchSize = 1
+
+		target("a", rebuilt(corev1.ConditionUnknown, time.Time{}), nil)
+		b := target("b", outdated(), nil)
+
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+
+		s, _ := plan.Status(now)
+		assert.Equal(t, int64(1), s.CurrentBatch)
+		assert.Equal(t, buildapi.RolloutProgressing, s.Phase)
+		assert.False(t, rollout.Admits(&s, newBuilderImage, b))
+	})
+
+	it("pauses between batches", func() {
+		policy.BatchSize = 1
+		policy.PauseBetweenBatches = &metav1.Duration{Duration: 10 * time.Minute}
+
+		target("a", rebuilt(corev1.ConditionTrue, now.Add(-4*time.Minute)), nil)
+		b := target("b", outdated(), nil)
+
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+
+		s, requeue := plan.Status(now)
+		assert.Equal(t, buildapi.BuilderRolloutStatus{
+			Image:        newBuilderImage,
+			Phase:        buildapi.RolloutPaused,
+			CurrentBatch: 1,
+			Batches:      2,
+			Images:       2,
+			Updated:      1,
+			Message:      "Waiting 6m0s before starting batch 2 of 2",
+			AdmittedImages: []string{
+				"some-namespace/a",
+			},
+		}, s)
+		assert.Equal(t, 6*time.Minute, requeue)
+		assert.False(t, rollout.Admits(&s, newBuilderImage, b))
+	})
+
+	it("halts when too many builds fail", func() {
+		policy.BatchSize = 2
+		maxFailures := int64(25)
+		policy.MaxFailurePercentage = &maxFailures
+
+		target("a", rebuilt(corev1.ConditionTrue, now.Add(-time.Minute)), nil)
+		target("b", rebuilt(corev1.ConditionFalse, now.Add(-time.Minute)), nil)
+		c := target("c", outdated(), nil)
+
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+
+		s, _ := plan.Status(now)
+		assert.Equal(t, buildapi.BuilderRolloutStatus{
+			Image:        newBuilderImage,
+			Phase:        buildapi.RolloutHalted,
+			CurrentBatch: 1,
+			Batches:      2,
+			Images:       3,
+			Updated:      1,
+			Failed:       1,
+			Message:      "Halted after 1 of 2 builds failed",
+		}, s)
+		assert.False(t, rollout.Admits(&s, newBuilderImage, c))
+	})
+
+	it("completes when every batch finished", func() {
+		policy.BatchSize = 1
+		for i := 0; i < 3; i++ {
+			target(fmt.Sprintf("image-%d", i), rebuilt(corev1.ConditionTrue, now), nil)
+		}
+
+		s, requeue := status()
+		assert.Equal(t, buildapi.BuilderRolloutStatus{
+			Image:        newBuilderImage,
+			Phase:        buildapi.RolloutCompleted,
+			CurrentBatch: 3,
+			Batches:      3,
+			Images:       3,
+			Updated:      3,
+			Message:      "Rolled out to 3 images",
+		}, s)
+		assert.Equal(t, time.Duration(0), requeue)
+	})
+
+	it("does not admit images for a different builder image", func() {
+		a := target("a", outdated(), nil)
+
+		plan, err := rollout.NewPlan(policy, builder, targets)
+		require.NoError(t, err)
+
+		s, _ := plan.Status(now)
+		s.Image = oldBuilderImage
+		assert.False(t, rollout.Admits(&s, newBuilderImage, a))
+		assert.False(t, rollout.Admits(nil, newBuilderImage, a))
+	})
+
+	it("admits every image once the rollout completed", func() {
+		a := target("a", rebuilt(corev1.ConditionTrue, now), nil)
+
+		s, _ := status()
+		assert.Equal(t, buildapi.RolloutCompleted, s.Phase)
+		assert.True(t, rollout.Admits(&s, newBuilderImage, a))
+	})
+}
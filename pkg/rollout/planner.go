@@ -0,0 +1,66 @@
+package rollout
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler/build"
+)
+
+type Planner struct {
+	ImageLister buildlisters.ImageLister
+	BuildLister buildlisters.BuildLister
+}
+
+// Plan plans the rollout of a Builder or ClusterBuilder to the Images that use it.
+// The namespace of the reference is only used for a Builder.
+func (p *Planner) Plan(builderRef corev1.ObjectReference, policy buildapi.BuilderRollout, status buildapi.BuilderStatus) (Plan, error) {
+	var (
+		images []*buildapi.Image
+		err    error
+	)
+	if builderRef.Kind == buildapi.BuilderKind {
+		images, err = p.ImageLister.Images(builderRef.Namespace).List(labels.Everything())
+	} else {
+		images, err = p.ImageLister.List(labels.Everything())
+	}
+	if err != nil {
+		return Plan{}, err
+	}
+
+	var targets []Target
+	for _, image := range images {
+		if image.Spec.Builder.Kind != builderRef.Kind || image.Spec.Builder.Name != builderRef.Name {
+			continue
+		}
+
+		lastBuild, err := p.lastBuild(image)
+		if err != nil {
+			return Plan{}, err
+		}
+
+		targets = append(targets, Target{Image: image, LastBuild: lastBuild})
+	}
+
+	return NewPlan(policy, status, targets)
+}
+
+func (p *Planner) lastBuild(image *buildapi.Image) (*buildapi.Build, error) {
+	builds, err := p.BuildLister.Builds(image.Namespace).List(labels.SelectorFromSet(labels.Set{
+		buildapi.ImageLabel: image.Name,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(builds) == 0 {
+		return nil, nil
+	}
+
+	sort.Sort(build.ByCreationTimestamp(builds))
+	return builds[len(builds)-1], nil
+}
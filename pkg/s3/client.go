@@ -0,0 +1,51 @@
+package s3
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+const (
+	versionIDVersionPrefix = "version-id:"
+	etagVersionPrefix      = "etag:"
+
+	defaultRegion = "us-east-1"
+)
+
+func newClient(endpoint, region string, creds *credentials.Credentials) (*awss3.S3, error) {
+	if region == "" {
+		region = defaultRegion
+	}
+
+	config := aws.NewConfig().
+		WithRegion(region).
+		WithCredentials(creds)
+
+	// S3 compatible object stores such as MinIO commonly do not support virtual hosted buckets
+	if endpoint != "" {
+		config = config.
+			WithEndpoint(endpoint).
+			WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating s3 session")
+	}
+
+	return awss3.New(sess), nil
+}
+
+func objectVersion(versionID, etag *string) string {
+	// objects in buckets without versioning have a "null" version id
+	if id := aws.StringValue(versionID); id != "" && id != "null" {
+		return versionIDVersionPrefix + id
+	}
+	if etag := aws.StringValue(etag); etag != "" {
+		return etagVersionPrefix + etag
+	}
+	return ""
+}
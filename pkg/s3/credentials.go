@@ -0,0 +1,53 @@
+package s3
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	AccessKeyIDKey     = "accessKeyId"
+	SecretAccessKeyKey = "secretAccessKey"
+	SessionTokenKey    = "sessionToken"
+)
+
+// ReadCredentialsSecret returns the credentials in a mounted secret or anonymous credentials
+// if no secret is mounted.
+func ReadCredentialsSecret(secretDir string) (*credentials.Credentials, error) {
+	values := map[string]string{}
+	for _, key := range []string{AccessKeyIDKey, SecretAccessKeyKey, SessionTokenKey} {
+		value, err := ioutil.ReadFile(filepath.Join(secretDir, key))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		values[key] = strings.TrimSpace(string(value))
+	}
+
+	return secretCredentials(values), nil
+}
+
+// SecretCredentials returns the credentials in a secret.
+func SecretCredentials(secret *corev1.Secret) *credentials.Credentials {
+	values := map[string]string{}
+	for key, value := range secret.Data {
+		values[key] = strings.TrimSpace(string(value))
+	}
+	return secretCredentials(values)
+}
+
+func secretCredentials(values map[string]string) *credentials.Credentials {
+	accessKeyID, secretAccessKey := values[AccessKeyIDKey], values[SecretAccessKeyKey]
+	if accessKeyID == "" || secretAccessKey == "" {
+		return credentials.AnonymousCredentials
+	}
+
+	return credentials.NewStaticCredentials(accessKeyID, secretAccessKey, values[SessionTokenKey])
+}
@@ -0,0 +1,121 @@
+package s3_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeObject struct {
+	Content      []byte
+	ETag         string
+	VersionID    string
+	LastModified time.Time
+}
+
+// fakeS3Server serves the path style S3 requests used by the resolver and fetcher.
+type fakeS3Server struct {
+	*httptest.Server
+	t        *testing.T
+	bucket   string
+	objects  map[string]fakeObject
+	requests []*http.Request
+}
+
+func newFakeS3Server(t *testing.T, bucket string) *fakeS3Server {
+	s := &fakeS3Server{
+		t:       t,
+		bucket:  bucket,
+		objects: map[string]fakeObject{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *fakeS3Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r)
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if path == s.bucket && r.URL.Query().Get("list-type") == "2" {
+		s.list(w, r.URL.Query().Get("prefix"))
+		return
+	}
+
+	if !strings.HasPrefix(path, s.bucket+"/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	object, ok := s.objects[strings.TrimPrefix(path, s.bucket+"/")]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if versionID := r.URL.Query().Get("versionId"); versionID != "" && versionID != object.VersionID {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != object.ETag {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	w.Header().Set("ETag", object.ETag)
+	w.Header().Set("Last-Modified", object.LastModified.UTC().Format(http.TimeFormat))
+	w.Header().Set("Content-Length", fmt.Sprint(len(object.Content)))
+	if object.VersionID != "" {
+		w.Header().Set("x-amz-version-id", object.VersionID)
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(object.Content)
+	}
+}
+
+func (s *fakeS3Server) list(w http.ResponseWriter, prefix string) {
+	type contents struct {
+		Key          string
+		LastModified string
+		ETag         string
+		Size         int
+	}
+
+	result := struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		IsTruncated bool
+		Contents    []contents
+	}{
+		Name:   s.bucket,
+		Prefix: prefix,
+	}
+
+	for key, object := range s.objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		result.Contents = append(result.Contents, contents{
+			Key:          key,
+			LastModified: object.LastModified.UTC().Format(time.RFC3339),
+			ETag:         object.ETag,
+			Size:         len(object.Content),
+		})
+	}
+	sort.Slice(result.Contents, func(i, j int) bool { return result.Contents[i].Key < result.Contents[j].Key })
+	result.KeyCount = len(result.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	if err := xml.NewEncoder(w).Encode(result); err != nil {
+		s.t.Fatal(err)
+	}
+}
@@ -0,0 +1,57 @@
+package s3
+
+import (
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	"github.com/pivotal/kpack/pkg/archive"
+)
+
+type Fetcher struct {
+	Logger      *log.Logger
+	Credentials *credentials.Credentials
+	Endpoint    string
+	Region      string
+}
+
+// Fetch streams the object into dir. The object must match the version resolved by the
+// source resolver, if any, so that the build uses the archive that triggered it.
+func (f *Fetcher) Fetch(dir, bucket, key, version string) error {
+	f.Logger.Printf("Downloading s3://%s/%s...", bucket, key)
+
+	client, err := newClient(f.Endpoint, f.Region, f.Credentials)
+	if err != nil {
+		return err
+	}
+
+	input := &awss3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	switch {
+	case strings.HasPrefix(version, versionIDVersionPrefix):
+		input.VersionId = aws.String(strings.TrimPrefix(version, versionIDVersionPrefix))
+	case strings.HasPrefix(version, etagVersionPrefix):
+		input.IfMatch = aws.String(strings.TrimPrefix(version, etagVersionPrefix))
+	}
+
+	output, err := client.GetObject(input)
+	if err != nil {
+		return errors.Wrapf(err, "getting object s3://%s/%s", bucket, key)
+	}
+	defer output.Body.Close()
+
+	err = archive.Extract(output.Body, dir)
+	if err != nil {
+		return err
+	}
+
+	f.Logger.Printf("Successfully downloaded s3://%s/%s in path %q", bucket, key, dir)
+
+	return nil
+}
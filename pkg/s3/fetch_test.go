@@ -0,0 +1,99 @@
+package s3_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/s3"
+)
+
+func TestS3Fetcher(t *testing.T) {
+	spec.Run(t, "testS3Fetcher", testS3Fetcher)
+}
+
+func testS3Fetcher(t *testing.T, when spec.G, it spec.S) {
+	const bucket = "some-bucket"
+
+	var (
+		server  *fakeS3Server
+		output  *bytes.Buffer
+		fetcher *s3.Fetcher
+		dir     string
+	)
+
+	it.Before(func() {
+		server = newFakeS3Server(t, bucket)
+		output = &bytes.Buffer{}
+		fetcher = &s3.Fetcher{
+			Logger:      log.New(output, "", 0),
+			Credentials: credentials.NewStaticCredentials("some-access-key", "some-secret-key", ""),
+			Endpoint:    server.URL,
+		}
+
+		var err error
+		dir, err = ioutil.TempDir("", "fetch_test")
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	addObject := func(key, testFile, etag, versionID string) {
+		content, err := ioutil.ReadFile(filepath.Join("testdata", testFile))
+		require.NoError(t, err)
+
+		server.objects[key] = fakeObject{Content: content, ETag: etag, VersionID: versionID, LastModified: time.Now()}
+	}
+
+	for _, f := range []string{"test.zip", "test.tar", "test.tar.gz"} {
+		testFile := f
+		it("unpacks "+testFile, func() {
+			addObject("app/"+testFile, testFile, `"some-etag"`, "")
+
+			err := fetcher.Fetch(dir, bucket, "app/"+testFile, `etag:"some-etag"`)
+			require.NoError(t, err)
+
+			file, err := ioutil.ReadFile(filepath.Join(dir, "testdir", "testfile"))
+			require.NoError(t, err)
+			require.Equal(t, "test file contents", string(file))
+
+			require.Contains(t, output.String(), "Successfully downloaded s3://some-bucket/app/"+testFile)
+			require.Contains(t, server.requests[0].Header.Get("Authorization"), "Credential=some-access-key/")
+		})
+	}
+
+	it("fetches the resolved version id", func() {
+		addObject("app.tar.gz", "test.tar.gz", `"some-etag"`, "some-version-id")
+
+		err := fetcher.Fetch(dir, bucket, "app.tar.gz", "version-id:some-version-id")
+		require.NoError(t, err)
+
+		require.Equal(t, "some-version-id", server.requests[0].URL.Query().Get("versionId"))
+	})
+
+	it("errors when the object has changed since it was resolved", func() {
+		addObject("app.tar.gz", "test.tar.gz", `"new-etag"`, "")
+
+		err := fetcher.Fetch(dir, bucket, "app.tar.gz", `etag:"old-etag"`)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "getting object s3://some-bucket/app.tar.gz")
+	})
+
+	it("errors on unexpected file types", func() {
+		addObject("app.txt", "test.txt", `"some-etag"`, "")
+
+		err := fetcher.Fetch(dir, bucket, "app.txt", "")
+		require.EqualError(t, err, "unexpected file type, must be one of .zip, .tar.gz, .tar, .jar")
+	})
+}
@@ -0,0 +1,126 @@
+package s3
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+type Resolver struct {
+	K8sClient k8sclient.Interface
+}
+
+func NewResolver(k8sClient k8sclient.Interface) *Resolver {
+	return &Resolver{
+		K8sClient: k8sClient,
+	}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	source := sourceResolver.Spec.Source.S3
+
+	creds, err := r.credentials(ctx, sourceResolver.Namespace, source.Credentials)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	client, err := newClient(source.Endpoint, source.Region, creds)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	key := source.Key
+	if key == "" {
+		key, err = latestObject(ctx, client, source.Bucket, source.Prefix)
+		if err != nil {
+			return corev1alpha1.ResolvedSourceConfig{}, err
+		}
+	}
+
+	head, err := client.HeadObjectWithContext(ctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(source.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, errors.Wrapf(err, "getting object s3://%s/%s", source.Bucket, key)
+	}
+
+	return corev1alpha1.ResolvedSourceConfig{
+		S3: &corev1alpha1.ResolvedS3Source{
+			Bucket:      source.Bucket,
+			Key:         source.Key,
+			Prefix:      source.Prefix,
+			Endpoint:    source.Endpoint,
+			Region:      source.Region,
+			Credentials: source.Credentials,
+			Object:      key,
+			Version:     objectVersion(head.VersionId, head.ETag),
+			SubPath:     sourceResolver.Spec.Source.SubPath,
+		},
+	}, nil
+}
+
+func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
+	return sourceResolver.IsS3()
+}
+
+func (r *Resolver) credentials(ctx context.Context, namespace string, creds *corev1alpha1.S3Credentials) (*credentials.Credentials, error) {
+	if creds == nil {
+		return credentials.AnonymousCredentials, nil
+	}
+
+	secret, err := r.K8sClient.CoreV1().Secrets(namespace).Get(ctx, creds.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting s3 credentials secret %s", creds.SecretRef.Name)
+	}
+
+	return SecretCredentials(secret), nil
+}
+
+// latestObject returns the key of the most recently modified object with the prefix.
+// S3 cannot list objects by modification time, so every object under the prefix is
+// listed on each poll. Sources with a key only need a HEAD request.
+func latestObject(ctx context.Context, client *awss3.S3, bucket, prefix string) (string, error) {
+	var (
+		latestKey      string
+		latestModified time.Time
+	)
+
+	err := client.ListObjectsV2PagesWithContext(ctx, &awss3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *awss3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			key, modified := aws.StringValue(object.Key), aws.TimeValue(object.LastModified)
+
+			// skip the placeholder objects some clients create for folders
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+
+			if modified.After(latestModified) || (modified.Equal(latestModified) && key > latestKey) {
+				latestKey, latestModified = key, modified
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "listing objects in s3://%s/%s", bucket, prefix)
+	}
+
+	if latestKey == "" {
+		return "", errors.Errorf("no objects found in s3://%s/%s", bucket, prefix)
+	}
+
+	return latestKey, nil
+}
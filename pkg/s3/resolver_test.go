@@ -0,0 +1,194 @@
+package s3_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/s3"
+)
+
+func TestS3Resolver(t *testing.T) {
+	spec.Run(t, "testS3Resolver", testS3Resolver)
+}
+
+func testS3Resolver(t *testing.T, when spec.G, it spec.S) {
+	const (
+		namespace = "some-namespace"
+		bucket    = "some-bucket"
+	)
+
+	var (
+		server   *fakeS3Server
+		resolver *s3.Resolver
+		modified = time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	it.Before(func() {
+		server = newFakeS3Server(t, bucket)
+
+		resolver = s3.NewResolver(fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "s3-secret",
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				s3.AccessKeyIDKey:     []byte("some-access-key"),
+				s3.SecretAccessKeyKey: []byte("some-secret-key"),
+			},
+		}))
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	sourceResolver := func(source *corev1alpha1.S3) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-source-resolver",
+				Namespace: namespace,
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					S3:      source,
+					SubPath: "some-sub-path",
+				},
+			},
+		}
+	}
+
+	it("resolves the version id of a key", func() {
+		server.objects["app.tar.gz"] = fakeObject{ETag: `"some-etag"`, VersionID: "some-version-id", LastModified: modified}
+
+		source := &corev1alpha1.S3{
+			Bucket:   bucket,
+			Key:      "app.tar.gz",
+			Endpoint: server.URL,
+		}
+		resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver(source))
+		require.NoError(t, err)
+
+		require.Equal(t, corev1alpha1.ResolvedSourceConfig{
+			S3: &corev1alpha1.ResolvedS3Source{
+				Bucket:   bucket,
+				Key:      "app.tar.gz",
+				Endpoint: server.URL,
+				Object:   "app.tar.gz",
+				Version:  "version-id:some-version-id",
+				SubPath:  "some-sub-path",
+			},
+		}, resolvedSource)
+		require.True(t, resolvedSource.S3.IsPollable())
+	})
+
+	it("resolves the etag of a key in a bucket without versioning", func() {
+		server.objects["app.tar.gz"] = fakeObject{ETag: `"some-etag"`, VersionID: "null", LastModified: modified}
+
+		source := &corev1alpha1.S3{
+			Bucket:   bucket,
+			Key:      "app.tar.gz",
+			Endpoint: server.URL,
+		}
+		resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver(source))
+		require.NoError(t, err)
+
+		require.Equal(t, `etag:"some-etag"`, resolvedSource.S3.Version)
+	})
+
+	it("resolves the most recently modified object with a prefix", func() {
+		server.objects["builds/"] = fakeObject{ETag: `"folder"`, LastModified: modified.Add(time.Hour)}
+		server.objects["builds/app-1.tar.gz"] = fakeObject{ETag: `"etag-1"`, LastModified: modified}
+		server.objects["builds/app-2.tar.gz"] = fakeObject{ETag: `"etag-2"`, LastModified: modified.Add(time.Minute)}
+		server.objects["other/app-3.tar.gz"] = fakeObject{ETag: `"etag-3"`, LastModified: modified.Add(time.Hour)}
+
+		source := &corev1alpha1.S3{
+			Bucket:   bucket,
+			Prefix:   "builds/",
+			Endpoint: server.URL,
+		}
+		resolvedSource, err := resolver.Resolve(context.Background(), sourceResolver(source))
+		require.NoError(t, err)
+
+		require.Equal(t, &corev1alpha1.ResolvedS3Source{
+			Bucket:   bucket,
+			Prefix:   "builds/",
+			Endpoint: server.URL,
+			Object:   "builds/app-2.tar.gz",
+			Version:  `etag:"etag-2"`,
+			SubPath:  "some-sub-path",
+		}, resolvedSource.S3)
+		require.Equal(t, []corev1.EnvVar{
+			{Name: "S3_BUCKET", Value: bucket},
+			{Name: "S3_KEY", Value: "builds/app-2.tar.gz"},
+			{Name: "S3_ENDPOINT", Value: server.URL},
+			{Name: "S3_VERSION", Value: `etag:"etag-2"`},
+		}, resolvedSource.S3.SourceConfig().S3.BuildEnvVars())
+	})
+
+	it("returns an error when no objects match the prefix", func() {
+		source := &corev1alpha1.S3{
+			Bucket:   bucket,
+			Prefix:   "builds/",
+			Endpoint: server.URL,
+		}
+		_, err := resolver.Resolve(context.Background(), sourceResolver(source))
+		require.EqualError(t, err, "no objects found in s3://some-bucket/builds/")
+	})
+
+	it("signs requests with the credentials secret", func() {
+		server.objects["app.tar.gz"] = fakeObject{ETag: `"some-etag"`, LastModified: modified}
+
+		source := &corev1alpha1.S3{
+			Bucket:   bucket,
+			Key:      "app.tar.gz",
+			Endpoint: server.URL,
+			Region:   "some-region",
+			Credentials: &corev1alpha1.S3Credentials{
+				SecretRef: corev1.LocalObjectReference{Name: "s3-secret"},
+			},
+		}
+		_, err := resolver.Resolve(context.Background(), sourceResolver(source))
+		require.NoError(t, err)
+
+		require.Len(t, server.requests, 1)
+		require.Contains(t, server.requests[0].Header.Get("Authorization"), "Credential=some-access-key/")
+		require.Contains(t, server.requests[0].Header.Get("Authorization"), "/some-region/s3/aws4_request")
+	})
+
+	it("does not sign requests without a credentials secret", func() {
+		server.objects["app.tar.gz"] = fakeObject{ETag: `"some-etag"`, LastModified: modified}
+
+		source := &corev1alpha1.S3{
+			Bucket:   bucket,
+			Key:      "app.tar.gz",
+			Endpoint: server.URL,
+		}
+		_, err := resolver.Resolve(context.Background(), sourceResolver(source))
+		require.NoError(t, err)
+
+		require.Len(t, server.requests, 1)
+		require.Empty(t, server.requests[0].Header.Get("Authorization"))
+	})
+
+	it("returns an error when the credentials secret does not exist", func() {
+		source := &corev1alpha1.S3{
+			Bucket:   bucket,
+			Key:      "app.tar.gz",
+			Endpoint: server.URL,
+			Credentials: &corev1alpha1.S3Credentials{
+				SecretRef: corev1.LocalObjectReference{Name: "missing-secret"},
+			},
+		}
+		_, err := resolver.Resolve(context.Background(), sourceResolver(source))
+		require.EqualError(t, err, `getting s3 credentials secret missing-secret: secrets "missing-secret" not found`)
+	})
+}